@@ -0,0 +1,21 @@
+data "pipefy_ai_agents" "support" {
+  pipe_id = "<PIPE_ID>"
+}
+
+locals {
+  triage_agent = one([for a in data.pipefy_ai_agents.support.agents : a if a.name == "Triage"])
+}
+
+resource "pipefy_ai_agent_behavior" "escalate" {
+  agent_id    = local.triage_agent.id
+  name        = "Escalate urgent cards"
+  event_id    = "card_created"
+  instruction = "Move urgent cards with {{action:Escalate}}."
+
+  actions = {
+    "Escalate" = {
+      action_type          = "move_card"
+      destination_phase_id = "<PHASE_ID>"
+    }
+  }
+}
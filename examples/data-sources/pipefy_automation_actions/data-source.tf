@@ -0,0 +1,7 @@
+data "pipefy_automation_actions" "example" {
+  repo_id = "<PIPE_ID>"
+}
+
+output "action_ids" {
+  value = data.pipefy_automation_actions.example.actions[*].id
+}
@@ -0,0 +1,7 @@
+data "pipefy_automation_events" "example" {
+  repo_id = "<PIPE_ID>"
+}
+
+output "field_updated_params" {
+  value = one([for e in data.pipefy_automation_events.example.events : e.accepted_parameters if e.id == "field_updated"])
+}
@@ -19,3 +19,13 @@ provider "pipefy" {
   token_url     = "https://<your_single_tenant_domain>/oauth/token"
   endpoint      = "https://<your_single_tenant_domain>/graphql"
 }
+
+# Restricting where webhooks may send data
+
+provider "pipefy" {
+  token = "my_token"
+
+  webhook_url_policy = {
+    allowed_hosts = ["hooks.example.com", "*.internal.example.com"]
+  }
+}
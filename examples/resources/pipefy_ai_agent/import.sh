@@ -1,2 +1,5 @@
 # Import an existing AI agent using the format pipe_id/agent_uuid
 terraform import pipefy_ai_agent.example "<PIPE_ID>/<AGENT_UUID>"
+
+# Or by name, using the format pipe_id/name:<agent name>
+terraform import pipefy_ai_agent.example "<PIPE_ID>/name:Triage"
@@ -32,32 +32,29 @@ resource "pipefy_ai_agent" "triage" {
   instruction = "Route and enrich cards based on their content."
   active      = true
 
-  behaviors = [
-    {
-      name        = "Route new cards"
+  behaviors = {
+    "Route new cards" = {
       event_id    = "card_created"
-      instruction = "Move the card to the Ready phase when it is ready for work."
+      instruction = "When the card is ready for work, {{action:Move to Ready}}."
 
-      actions = [
-        {
-          name                 = "Move to Ready"
+      actions = {
+        "Move to Ready" = {
           action_type          = "move_card"
           destination_phase_id = pipefy_phase.ready.id
-        },
-      ]
-    },
-    {
-      name        = "Rewrite title"
-      event_id    = "field_updated"
-      instruction = "Improve the card title when the summary changes."
+        }
+      }
+    }
+
+    "Rewrite title" = {
+      event_id         = "field_updated"
+      instruction_file = "${path.module}/prompts/rewrite_title.txt"
 
       event_params = {
         trigger_field_ids = [pipefy_field.summary.internal_id]
       }
 
-      actions = [
-        {
-          name        = "Update title"
+      actions = {
+        "Update title" = {
           action_type = "update_card"
           pipe_id     = pipefy_pipe.example.id
 
@@ -67,11 +64,11 @@ resource "pipefy_ai_agent" "triage" {
               input_mode = "fill_with_ai"
             },
           ]
-        },
-      ]
-    },
-    {
-      name        = "Open follow-up"
+        }
+      }
+    }
+
+    "Open follow-up" = {
       event_id    = "card_moved"
       instruction = "Create a follow-up card when work reaches Ready."
 
@@ -79,9 +76,8 @@ resource "pipefy_ai_agent" "triage" {
         to_phase_id = pipefy_phase.ready.id
       }
 
-      actions = [
-        {
-          name        = "Create follow-up"
+      actions = {
+        "Create follow-up" = {
           action_type = "create_card"
           pipe_id     = pipefy_pipe.example.id
 
@@ -92,8 +88,8 @@ resource "pipefy_ai_agent" "triage" {
               value      = "Follow-up"
             },
           ]
-        },
-      ]
-    },
-  ]
+        }
+      }
+    }
+  }
 }
@@ -0,0 +1,2 @@
+# Import an existing AI agent behavior using the format agent_uuid/behavior_id
+terraform import pipefy_ai_agent_behavior.example "<AGENT_UUID>/<BEHAVIOR_ID>"
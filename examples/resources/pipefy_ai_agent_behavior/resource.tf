@@ -0,0 +1,25 @@
+resource "pipefy_ai_agent" "shared" {
+  pipe_id     = "<PIPE_ID>"
+  name        = "Shared assistant"
+  instruction = "Assist every team working on this pipe."
+
+  # behaviors are omitted so each team can manage its own below.
+}
+
+resource "pipefy_ai_agent_behavior" "route" {
+  agent_id    = pipefy_ai_agent.shared.id
+  name        = "Route new cards"
+  event_id    = "card_created"
+  instruction = "Move the card to the Ready phase when it is ready for work."
+
+  actions = {
+    "Move to Ready" = {
+      action_type          = "move_card"
+      destination_phase_id = "<READY_PHASE_ID>"
+    }
+    "Notify requester" = {
+      action_type       = "send_email_template"
+      email_template_id = "<EMAIL_TEMPLATE_ID>"
+    }
+  }
+}
@@ -0,0 +1,2 @@
+# Import an existing AI data source using the format organization_id/data_source_id
+terraform import pipefy_ai_data_source.example "<ORGANIZATION_ID>/<DATA_SOURCE_ID>"
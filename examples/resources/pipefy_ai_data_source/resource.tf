@@ -0,0 +1,25 @@
+resource "pipefy_ai_data_source" "handbook" {
+  organization_id = "<ORGANIZATION_ID>"
+  name            = "Support handbook"
+  file            = "${path.module}/knowledge/handbook.pdf"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+resource "pipefy_ai_data_source" "faq" {
+  organization_id = "<ORGANIZATION_ID>"
+  name            = "Public FAQ"
+  url             = "https://help.example.com/faq"
+}
+
+resource "pipefy_ai_agent" "support" {
+  pipe_id     = "<PIPE_ID>"
+  name        = "Support"
+  instruction = "Answer using the support handbook and the FAQ."
+  data_source_ids = [
+    pipefy_ai_data_source.handbook.id,
+    pipefy_ai_data_source.faq.id,
+  ]
+}
@@ -54,3 +54,61 @@ resource "pipefy_automation" "example_ai" {
     expressions_structure = [[0]]
   })
 }
+
+resource "pipefy_phase" "done" {
+  pipe_id = pipefy_pipe.test.id
+  name    = "Done"
+}
+
+# Common events and actions can be configured with typed attributes instead
+# of JSON strings.
+resource "pipefy_automation" "example_typed" {
+  name           = "Mark moved cards as translated"
+  event_id       = "card_moved"
+  action_id      = "update_card_field"
+  event_repo_id  = pipefy_pipe.test.id
+  action_repo_id = pipefy_pipe.test.id
+
+  event = {
+    card_moved = { to_phase_id = pipefy_phase.done.id }
+  }
+
+  action = {
+    update_field = {
+      fields = [{
+        field_id   = pipefy_field.translation.internal_id
+        input_mode = "fixed_value"
+        value      = "Translated"
+      }]
+    }
+  }
+
+  condition_expression = {
+    match = "all"
+    expressions = [{
+      field_id  = pipefy_field.title.internal_id
+      operation = "present"
+    }]
+  }
+}
+
+# Scheduled automations run on a frequency or a cron expression.
+resource "pipefy_automation" "example_scheduled" {
+  name           = "Weekly translation reminder"
+  event_id       = "scheduler"
+  action_id      = "send_email_template"
+  event_repo_id  = pipefy_pipe.test.id
+  action_repo_id = pipefy_pipe.test.id
+
+  event = {
+    scheduled = {
+      cron       = "0 9 * * MON"
+      timezone   = "America/Sao_Paulo"
+      start_date = "2025-01-31"
+    }
+  }
+
+  action = {
+    send_email_template = { email_template_id = "<EMAIL_TEMPLATE_ID>" }
+  }
+}
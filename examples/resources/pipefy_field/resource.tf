@@ -27,3 +27,15 @@ resource "pipefy_field" "priority" {
   label    = "Priority"
   options  = ["Low", "Medium", "High"]
 }
+
+resource "pipefy_field" "supplier" {
+  phase_id = pipefy_phase.example.id
+  type     = "connector"
+  label    = "Supplier"
+
+  connector = {
+    repo_id              = "<SUPPLIERS_TABLE_ID>"
+    can_create_new       = false
+    can_connect_multiple = false
+  }
+}
@@ -0,0 +1,2 @@
+# Import the field order of a phase using the phase ID
+terraform import pipefy_field_order.example "<PHASE_ID>"
\ No newline at end of file
@@ -0,0 +1,26 @@
+resource "pipefy_phase" "example" {
+  pipe_id = "<PIPE_ID>"
+  name    = "Backlog"
+}
+
+resource "pipefy_field" "title" {
+  phase_id = pipefy_phase.example.id
+  type     = "short_text"
+  label    = "Title"
+}
+
+resource "pipefy_field" "priority" {
+  phase_id = pipefy_phase.example.id
+  type     = "select"
+  label    = "Priority"
+  options  = ["Low", "Medium", "High"]
+}
+
+resource "pipefy_field_order" "example" {
+  phase_id = pipefy_phase.example.id
+
+  field_uuids = [
+    pipefy_field.priority.uuid,
+    pipefy_field.title.uuid,
+  ]
+}
@@ -0,0 +1,5 @@
+# Import an existing organization webhook using the format organization_id/webhook_id
+terraform import pipefy_organization_webhook.example "<ORG_ID>/<WEBHOOK_ID>"
+
+# Note: headers is not read back from the API (it is sensitive), so after import
+# the first plan shows an in-place update that re-sends it from your config.
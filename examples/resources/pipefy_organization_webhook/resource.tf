@@ -0,0 +1,10 @@
+resource "pipefy_organization_webhook" "membership" {
+  organization_id = "<ORG_ID>"
+  name            = "Membership changes"
+  url             = "https://security.example.com/webhooks/pipefy"
+  actions         = ["user.invitation_sent", "user.invitation_acceptance", "user.removal_from_org"]
+
+  headers = jsonencode({
+    Authorization = "Bearer <TOKEN>"
+  })
+}
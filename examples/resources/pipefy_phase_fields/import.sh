@@ -0,0 +1,2 @@
+# Import every field of a phase using the phase ID
+terraform import pipefy_phase_fields.example "<PHASE_ID>"
\ No newline at end of file
@@ -0,0 +1,32 @@
+resource "pipefy_pipe" "example" {
+  name            = "Example Pipe"
+  organization_id = "<ORG_ID>"
+}
+
+resource "pipefy_phase" "example" {
+  pipe_id = pipefy_pipe.example.id
+  name    = "Backlog"
+}
+
+resource "pipefy_phase_fields" "example" {
+  phase_id = pipefy_phase.example.id
+
+  fields = [
+    {
+      type     = "short_text"
+      label    = "Title"
+      required = true
+    },
+    {
+      type    = "select"
+      label   = "Priority"
+      options = ["Low", "Medium", "High"]
+    },
+    {
+      type         = "long_text"
+      label        = "Details"
+      help         = "Anything the team should know"
+      minimal_view = false
+    },
+  ]
+}
@@ -0,0 +1,2 @@
+# Import the phase order of a pipe using the pipe ID
+terraform import pipefy_phase_order.example "<PIPE_ID>"
\ No newline at end of file
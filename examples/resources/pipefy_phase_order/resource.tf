@@ -0,0 +1,30 @@
+resource "pipefy_pipe" "example" {
+  name            = "Example Pipe"
+  organization_id = "<ORG_ID>"
+}
+
+resource "pipefy_phase" "todo" {
+  pipe_id = pipefy_pipe.example.id
+  name    = "To do"
+}
+
+resource "pipefy_phase" "doing" {
+  pipe_id = pipefy_pipe.example.id
+  name    = "Doing"
+}
+
+resource "pipefy_phase" "done" {
+  pipe_id = pipefy_pipe.example.id
+  name    = "Done"
+  done    = true
+}
+
+resource "pipefy_phase_order" "example" {
+  pipe_id = pipefy_pipe.example.id
+
+  phase_ids = [
+    pipefy_phase.todo.id,
+    pipefy_phase.doing.id,
+    pipefy_phase.done.id,
+  ]
+}
@@ -0,0 +1,2 @@
+# Import the transitions of a phase using the phase ID
+terraform import pipefy_phase_transitions.example "<PHASE_ID>"
\ No newline at end of file
@@ -0,0 +1,27 @@
+resource "pipefy_phase" "review" {
+  pipe_id = "<PIPE_ID>"
+  name    = "Review"
+}
+
+resource "pipefy_phase" "approved" {
+  pipe_id = "<PIPE_ID>"
+  name    = "Approved"
+  done    = true
+}
+
+resource "pipefy_phase" "rework" {
+  pipe_id = "<PIPE_ID>"
+  name    = "Rework"
+}
+
+# Cards under review can only be approved or sent back for rework.
+resource "pipefy_phase_transitions" "review" {
+  phase_id              = pipefy_phase.review.id
+  can_move_to_phase_ids = [pipefy_phase.approved.id, pipefy_phase.rework.id]
+}
+
+# Reworked cards can only go back to review.
+resource "pipefy_phase_transitions" "rework" {
+  phase_id              = pipefy_phase.rework.id
+  can_move_to_phase_ids = [pipefy_phase.review.id]
+}
@@ -0,0 +1,2 @@
+# Import every label of a pipe using the pipe ID
+terraform import pipefy_pipe_labels.example "<PIPE_ID>"
\ No newline at end of file
@@ -0,0 +1,14 @@
+resource "pipefy_pipe" "example" {
+  name            = "Example Pipe"
+  organization_id = "<ORG_ID>"
+}
+
+resource "pipefy_pipe_labels" "example" {
+  pipe_id = pipefy_pipe.example.id
+
+  labels = {
+    "Urgent"  = "#FF0000"
+    "Blocked" = "#FFA500"
+    "Waiting" = "#999999"
+  }
+}
\ No newline at end of file
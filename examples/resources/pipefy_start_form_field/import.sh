@@ -0,0 +1,2 @@
+# Import an existing Start Form Field using the format pipe_id/field_uuid
+terraform import pipefy_start_form_field.example "<PIPE_ID>/<FIELD_UUID>"
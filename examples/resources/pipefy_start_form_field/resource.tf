@@ -0,0 +1,19 @@
+resource "pipefy_pipe" "example" {
+  name            = "Example Pipe"
+  organization_id = "<ORG_ID>"
+}
+
+resource "pipefy_start_form_field" "requester" {
+  pipe_id  = pipefy_pipe.example.id
+  type     = "email"
+  label    = "Requester email"
+  required = true
+  help     = "Where updates about the request are sent"
+}
+
+resource "pipefy_start_form_field" "category" {
+  pipe_id = pipefy_pipe.example.id
+  type    = "select"
+  label   = "Category"
+  options = ["Hardware", "Software", "Access"]
+}
@@ -20,4 +20,15 @@ resource "pipefy_table_field" "priority" {
   label        = "Priority"
   options      = ["Low", "Medium", "High"]
   minimal_view = true
-}
\ No newline at end of file
+}
+
+resource "pipefy_table_field" "requests" {
+  table_id = pipefy_table.example.id
+  type     = "connector"
+  label    = "Purchase requests"
+
+  connector = {
+    repo_id              = "<PIPE_ID>"
+    can_connect_multiple = true
+  }
+}
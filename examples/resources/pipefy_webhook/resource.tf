@@ -24,3 +24,23 @@ resource "pipefy_webhook" "moves_from_phase" {
     from_phase_id = [tonumber(pipefy_phase.in_progress.id)]
   })
 }
+
+# headers_wo is never stored in state, so it can use ephemeral values. Bump
+# headers_wo_version to send a rotated token.
+variable "webhook_token" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "pipefy_webhook" "card_created" {
+  pipe_id = pipefy_pipe.example.id
+  name    = "New cards"
+  url     = "https://example.com/webhooks/pipefy"
+  actions = ["card.create"]
+
+  headers_wo = jsonencode({
+    Authorization = "Bearer ${var.webhook_token}"
+  })
+  headers_wo_version = 1
+}
@@ -1,14 +1,38 @@
 // Copyright (c) HashiCorp, Inc.
 // SPDX-License-Identifier: MPL-2.0
 
-// Package aiagentgql contains the GraphQL selection and typed wire payloads
-// shared by the pipefy_ai_agent resource.
+// Package aiagentgql contains the GraphQL selection, typed wire payloads and
+// list call shared by the pipefy_ai_agent and pipefy_ai_agent_behavior
+// resources and the pipefy_ai_agents data source.
 package aiagentgql
 
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/client"
+)
+
 const Selection = "uuid name instruction repoUuid dataSourceIds disabledAt behaviors { " +
 	"id name event_id event_params { to_phase_id triggerFieldIds } action_params { " +
 	"aiBehaviorParams { instruction actionsAttributes { id referenceId name actionType " +
-	"metadata { destinationPhaseId pipeId fieldsAttributes { fieldId inputMode value } } } } } }"
+	"metadata { destinationPhaseId pipeId emailTemplateId tableId connectorFieldId " +
+	"fieldsAttributes { fieldId inputMode value } } } } } }"
+
+// ListQuery lists the agents of the pipe with UUID repoUuid.
+const ListQuery = "query GetAiAgents_tf($repoUuid:ID!){ aiAgents(repoUuid:$repoUuid){ " + Selection + " } }"
+
+// List returns the agents of the pipe with UUID repoUUID.
+func List(ctx context.Context, api *client.ApiClient, repoUUID string) ([]Agent, error) {
+	var output struct {
+		AIAgents []Agent `json:"aiAgents"`
+	}
+	if err := api.DoGraphQL(ctx, ListQuery, map[string]any{"repoUuid": repoUUID}, &output); err != nil {
+		return nil, err
+	}
+	return output.AIAgents, nil
+}
 
 type Agent struct {
 	UUID          string     `json:"uuid"`
@@ -50,9 +74,14 @@ type Action struct {
 	Metadata    ActionMetadata `json:"metadata"`
 }
 
+// ActionMetadata holds the metadata of every action type; each type sets only
+// the members it uses.
 type ActionMetadata struct {
 	DestinationPhaseID *string `json:"destinationPhaseId"`
 	PipeID             *string `json:"pipeId"`
+	EmailTemplateID    *string `json:"emailTemplateId"`
+	TableID            *string `json:"tableId"`
+	ConnectorFieldID   *string `json:"connectorFieldId"`
 	Fields             []Field `json:"fieldsAttributes"`
 }
 
@@ -61,3 +90,114 @@ type Field struct {
 	InputMode string  `json:"inputMode"`
 	Value     *string `json:"value"`
 }
+
+// Template returns the instruction as a template: the action references
+// appended for actions the instruction does not mention are removed, and
+// references to the behavior's actions become {{action:NAME}} placeholders.
+// References to anything else are left as they are. The appended references
+// are trimmed whatever their order, since earlier versions appended them in
+// list order rather than by name.
+func (p AIBehaviorParams) Template() string {
+	sorted := append([]Action(nil), p.Actions...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	result := p.Instruction
+	trimmed := make([]bool, len(sorted))
+	for found := true; found; {
+		found = false
+		for index, action := range sorted {
+			suffix := "\n" + ActionReference(action.ReferenceID)
+			if !trimmed[index] && strings.HasSuffix(result, suffix) {
+				result = strings.TrimSuffix(result, suffix)
+				trimmed[index] = true
+				found = true
+			}
+		}
+	}
+	for _, action := range sorted {
+		result = strings.ReplaceAll(result, ActionReference(action.ReferenceID), "{{action:"+action.Name+"}}")
+	}
+	return result
+}
+
+// ActionReference is how an instruction refers to the action with the given
+// reference ID.
+func ActionReference(referenceID string) string {
+	return "%{action:" + referenceID + "}"
+}
+
+// Input returns the agent as an updateAiAgent input, so a change to one part of
+// the agent can send everything else back exactly as it was read.
+func (a Agent) Input() map[string]any {
+	behaviors := make([]map[string]any, len(a.Behaviors))
+	for index, behavior := range a.Behaviors {
+		behaviors[index] = behavior.Input()
+	}
+	dataSourceIDs := a.DataSourceIDs
+	if dataSourceIDs == nil {
+		dataSourceIDs = []string{}
+	}
+	return map[string]any{
+		"name": a.Name, "instruction": a.Instruction, "repoUuid": a.RepoUUID,
+		"dataSourceIds": dataSourceIDs, "behaviors": behaviors,
+	}
+}
+
+// Input returns the behavior as a behaviors element of an agent input. The
+// instruction is sent as read, with its action references still appended.
+func (b Behavior) Input() map[string]any {
+	input := map[string]any{"id": b.ID, "name": b.Name, "eventId": b.EventID}
+	eventParams := map[string]any{}
+	if b.EventParams.ToPhaseID != nil {
+		eventParams["to_phase_id"] = *b.EventParams.ToPhaseID
+	}
+	if len(b.EventParams.TriggerFieldIDs) > 0 {
+		eventParams["triggerFieldIds"] = b.EventParams.TriggerFieldIDs
+	}
+	if len(eventParams) > 0 {
+		input["eventParams"] = eventParams
+	}
+	params := b.ActionParams.AIBehaviorParams
+	actions := make([]map[string]any, len(params.Actions))
+	for index, action := range params.Actions {
+		actions[index] = action.Input()
+	}
+	input["actionParams"] = map[string]any{"aiBehaviorParams": map[string]any{
+		"instruction": params.Instruction, "actionsAttributes": actions,
+	}}
+	return input
+}
+
+// Input returns the action as an actionsAttributes element.
+func (a Action) Input() map[string]any {
+	metadata := map[string]any{}
+	if a.Metadata.DestinationPhaseID != nil {
+		metadata["destinationPhaseId"] = *a.Metadata.DestinationPhaseID
+	}
+	if a.Metadata.PipeID != nil {
+		metadata["pipeId"] = *a.Metadata.PipeID
+	}
+	if a.Metadata.EmailTemplateID != nil {
+		metadata["emailTemplateId"] = *a.Metadata.EmailTemplateID
+	}
+	if a.Metadata.TableID != nil {
+		metadata["tableId"] = *a.Metadata.TableID
+	}
+	if a.Metadata.ConnectorFieldID != nil {
+		metadata["connectorFieldId"] = *a.Metadata.ConnectorFieldID
+	}
+	if len(a.Metadata.Fields) > 0 {
+		fields := make([]map[string]any, len(a.Metadata.Fields))
+		for index, field := range a.Metadata.Fields {
+			value := ""
+			if field.Value != nil {
+				value = *field.Value
+			}
+			fields[index] = map[string]any{"fieldId": field.FieldID, "inputMode": field.InputMode, "value": value}
+		}
+		metadata["fieldsAttributes"] = fields
+	}
+	return map[string]any{
+		"id": a.ID, "name": a.Name, "actionType": a.ActionType,
+		"referenceId": a.ReferenceID, "metadata": metadata,
+	}
+}
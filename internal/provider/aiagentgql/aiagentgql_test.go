@@ -54,7 +54,8 @@ func TestSelectionContainsManagedFields(t *testing.T) {
 	required := []string{
 		"uuid", "repoUuid", "dataSourceIds", "disabledAt", "behaviors",
 		"event_id", "event_params", "aiBehaviorParams", "actionsAttributes",
-		"referenceId", "destinationPhaseId", "fieldsAttributes",
+		"referenceId", "destinationPhaseId", "emailTemplateId", "tableId",
+		"connectorFieldId", "fieldsAttributes",
 	}
 	for _, field := range required {
 		if !strings.Contains(aiagentgql.Selection, field) {
@@ -62,3 +63,87 @@ func TestSelectionContainsManagedFields(t *testing.T) {
 		}
 	}
 }
+
+func TestAgentInputRoundTrip(t *testing.T) {
+	const payload = `{
+		"uuid":"agent-uuid","name":"Triage","instruction":"Classify cards","repoUuid":"pipe-uuid",
+		"behaviors":[{
+			"id":"behavior-1","name":"On update","event_id":"field_updated",
+			"event_params":{"to_phase_id":null,"triggerFieldIds":["field-1"]},
+			"action_params":{"aiBehaviorParams":{
+				"instruction":"Rewrite\n%{action:reference-1}",
+				"actionsAttributes":[{
+					"id":"action-1","referenceId":"reference-1","name":"Update","actionType":"update_card",
+					"metadata":{"destinationPhaseId":null,"pipeId":"42","fieldsAttributes":[{"fieldId":"title","inputMode":"fill_with_ai","value":null}]}
+				}]
+			}}
+		}]
+	}`
+	var agent aiagentgql.Agent
+	if err := json.Unmarshal([]byte(payload), &agent); err != nil {
+		t.Fatalf("unmarshal AI agent payload: %v", err)
+	}
+	got, err := json.Marshal(agent.Input())
+	if err != nil {
+		t.Fatalf("marshal input: %v", err)
+	}
+	want := `{"behaviors":[{"actionParams":{"aiBehaviorParams":{"actionsAttributes":[{"actionType":"update_card","id":"action-1",` +
+		`"metadata":{"fieldsAttributes":[{"fieldId":"title","inputMode":"fill_with_ai","value":""}],"pipeId":"42"},` +
+		`"name":"Update","referenceId":"reference-1"}],"instruction":"Rewrite\n%{action:reference-1}"}},` +
+		`"eventId":"field_updated","eventParams":{"triggerFieldIds":["field-1"]},"id":"behavior-1","name":"On update"}],` +
+		`"dataSourceIds":[],"instruction":"Classify cards","name":"Triage","repoUuid":"pipe-uuid"}`
+	if string(got) != want {
+		t.Fatalf("input =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestActionInputKeepsTypedMetadata(t *testing.T) {
+	const payload = `[
+		{"id":"action-1","referenceId":"reference-1","name":"Notify","actionType":"send_email_template",
+			"metadata":{"emailTemplateId":"template-1","pipeId":null}},
+		{"id":"action-2","referenceId":"reference-2","name":"Record","actionType":"update_table_record",
+			"metadata":{"tableId":"table-1","connectorFieldId":"customer",
+				"fieldsAttributes":[{"fieldId":"status","inputMode":"fixed_value","value":"open"}]}}
+	]`
+	var actions []aiagentgql.Action
+	if err := json.Unmarshal([]byte(payload), &actions); err != nil {
+		t.Fatalf("unmarshal actions: %v", err)
+	}
+	want := []string{
+		`{"emailTemplateId":"template-1"}`,
+		`{"connectorFieldId":"customer","fieldsAttributes":[{"fieldId":"status","inputMode":"fixed_value","value":"open"}],"tableId":"table-1"}`,
+	}
+	for index, action := range actions {
+		got, err := json.Marshal(action.Input()["metadata"])
+		if err != nil {
+			t.Fatalf("marshal metadata: %v", err)
+		}
+		if string(got) != want[index] {
+			t.Errorf("%s metadata = %s, want %s", action.ActionType, got, want[index])
+		}
+	}
+}
+
+func TestTemplatePreservesUserContent(t *testing.T) {
+	const authored = "Keep %{action:user-authored} in the prompt"
+	params := aiagentgql.AIBehaviorParams{
+		Instruction: authored + "\n" + aiagentgql.ActionReference("generated-reference"),
+		Actions:     []aiagentgql.Action{{Name: "A", ReferenceID: "generated-reference"}},
+	}
+	if got := params.Template(); got != authored {
+		t.Fatalf("template changed user content: %q", got)
+	}
+}
+
+func TestTemplateTrimsReferencesInAnyOrder(t *testing.T) {
+	params := aiagentgql.AIBehaviorParams{
+		Instruction: "Route\n" + aiagentgql.ActionReference("ref-move") + "\n" + aiagentgql.ActionReference("ref-archive"),
+		Actions: []aiagentgql.Action{
+			{Name: "Move", ReferenceID: "ref-move"},
+			{Name: "Archive", ReferenceID: "ref-archive"},
+		},
+	}
+	if got := params.Template(); got != "Route" {
+		t.Fatalf("template = %q, want the appended references trimmed", got)
+	}
+}
@@ -0,0 +1,420 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package automationgql holds the GraphQL field selection and the typed
+// automation payload read by the pipefy_automation resource, plus the pure
+// normalization that maps event_params, action_params and condition to the
+// JSON strings kept in state.
+package automationgql
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// The params are GraphQL objects, so each needs an explicit sub-selection. Only
+// the keys listed here are refreshed; see Refresh for how other keys are kept.
+const (
+	EventParamsSelection  = "event_params{ to_phase_id triggerFieldIds triggerAutomationId scheduler_frequency }"
+	ActionParamsSelection = "action_params{ to_phase_id email_template_id }"
+	ConditionSelection    = "condition{ expressions{ structure_id field_address operation value } expressions_structure }"
+)
+
+const Selection = "id name action_id event_id active event_repo{ id } action_repo_v2{ ... on Pipe{ id } ... on Table{ id } } " +
+	EventParamsSelection + " " + ActionParamsSelection + " " + ConditionSelection
+
+// DetailSelection selects the params members whose names are only known from
+// the mutation input, not from a read of the API schema. They are fetched by
+// a separate query so that a name the API rejects fails that query alone and
+// not every read of an automation; see Automation.AddDetail.
+const DetailSelection = "event_params{ scheduler_cron scheduler_timezone scheduler_start_date } " +
+	"action_params{ aiParams{ value fieldIds } field_map{ fieldId inputMode value } }"
+
+var (
+	EventParamKeys  = []string{"to_phase_id", "triggerFieldIds", "triggerAutomationId", "scheduler_frequency"}
+	ActionParamKeys = []string{"to_phase_id", "email_template_id"}
+	ConditionKeys   = []string{"expressions", "expressions_structure"}
+
+	EventParamDetailKeys  = []string{"scheduler_cron", "scheduler_timezone", "scheduler_start_date"}
+	ActionParamDetailKeys = []string{"aiParams", "field_map"}
+)
+
+type Ref struct {
+	Id string `json:"id"`
+}
+
+type Automation struct {
+	Id           string          `json:"id"`
+	Name         string          `json:"name"`
+	ActionId     string          `json:"action_id"`
+	EventId      string          `json:"event_id"`
+	Active       bool            `json:"active"`
+	EventRepo    *Ref            `json:"event_repo"`
+	ActionRepoV2 *Ref            `json:"action_repo_v2"`
+	EventParams  json.RawMessage `json:"event_params"`
+	ActionParams json.RawMessage `json:"action_params"`
+	Condition    json.RawMessage `json:"condition"`
+
+	// Detailed reports whether the members of DetailSelection were added.
+	Detailed bool `json:"-"`
+}
+
+// AddDetail merges the params of detail, read with DetailSelection, into a.
+func (a *Automation) AddDetail(detail Automation) error {
+	event, err := mergeMembers(a.EventParams, detail.EventParams)
+	if err != nil {
+		return err
+	}
+	action, err := mergeMembers(a.ActionParams, detail.ActionParams)
+	if err != nil {
+		return err
+	}
+	a.EventParams, a.ActionParams, a.Detailed = event, action, true
+	return nil
+}
+
+// EventKeys returns the event_params keys a holds: EventParamKeys, plus
+// EventParamDetailKeys once AddDetail succeeded.
+func (a Automation) EventKeys() []string {
+	return a.keys(EventParamKeys, EventParamDetailKeys)
+}
+
+// ActionKeys is EventKeys for action_params.
+func (a Automation) ActionKeys() []string {
+	return a.keys(ActionParamKeys, ActionParamDetailKeys)
+}
+
+func (a Automation) keys(base, detail []string) []string {
+	if !a.Detailed {
+		return base
+	}
+	return append(append([]string{}, base...), detail...)
+}
+
+// mergeMembers returns base with the top-level members of extra added. Members
+// are kept raw, so numbers keep their precision.
+func mergeMembers(base, extra json.RawMessage) (json.RawMessage, error) {
+	var members map[string]json.RawMessage
+	for _, raw := range []json.RawMessage{base, extra} {
+		trimmed := bytes.TrimSpace(raw)
+		if len(trimmed) == 0 || string(trimmed) == "null" {
+			continue
+		}
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(trimmed, &obj); err != nil {
+			return nil, err
+		}
+		if members == nil {
+			members = map[string]json.RawMessage{}
+		}
+		for k, v := range obj {
+			members[k] = v
+		}
+	}
+	if members == nil {
+		return base, nil
+	}
+	return json.Marshal(members)
+}
+
+// The automation catalog lists the events and actions a repo supports.
+const (
+	EventsQuery  = "query GetAutomationEvents_tf($repoId:ID!){ automationEvents(repoId:$repoId){ id name acceptedParameters actionsBlacklist } }"
+	ActionsQuery = "query GetAutomationActions_tf($repoId:ID!){ automationActions(repoId:$repoId){ id name acceptedParameters eventsBlacklist } }"
+)
+
+// CatalogEntry is one event or action of the automation catalog.
+// AcceptedParameters is kept raw; see ParamKeys. Events list the actions they
+// cannot be combined with in ActionsBlacklist, actions the events in
+// EventsBlacklist.
+type CatalogEntry struct {
+	Id                 string          `json:"id"`
+	Name               string          `json:"name"`
+	AcceptedParameters json.RawMessage `json:"acceptedParameters"`
+	ActionsBlacklist   []string        `json:"actionsBlacklist"`
+	EventsBlacklist    []string        `json:"eventsBlacklist"`
+}
+
+// FindEntry returns the catalog entry with the given ID.
+func FindEntry(entries []CatalogEntry, id string) (CatalogEntry, bool) {
+	for _, e := range entries {
+		if e.Id == id {
+			return e, true
+		}
+	}
+	return CatalogEntry{}, false
+}
+
+// Compatible reports whether an event and an action may form one automation.
+func Compatible(event, action CatalogEntry) bool {
+	return !contains(event.ActionsBlacklist, action.Id) && !contains(action.EventsBlacklist, event.Id)
+}
+
+// UnknownKeys returns the sorted top-level keys of params the entry does not
+// accept. An entry that lists no keys accepts anything, since the catalog
+// then carries no information to check against.
+func UnknownKeys(params map[string]any, entry CatalogEntry) []string {
+	accepted := entry.ParamKeys()
+	if len(accepted) == 0 {
+		return nil
+	}
+	var unknown []string
+	for k := range params {
+		if !contains(accepted, k) {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// EventFieldIDs returns the field IDs referenced by event params. They belong
+// to the event repo.
+func EventFieldIDs(params map[string]any) []string {
+	return scalars(params["triggerFieldIds"])
+}
+
+// ActionFieldIDs returns the field IDs referenced by action params. They
+// belong to the action repo.
+func ActionFieldIDs(params map[string]any) []string {
+	var ids []string
+	for _, item := range asList(params["field_map"]) {
+		m, _ := item.(map[string]any)
+		ids = append(ids, scalars(m["fieldId"])...)
+	}
+	ai, _ := params["aiParams"].(map[string]any)
+	return append(ids, scalars(ai["fieldIds"])...)
+}
+
+// ConditionFieldIDs returns the field IDs a condition compares. They belong
+// to the event repo.
+func ConditionFieldIDs(condition map[string]any) []string {
+	var ids []string
+	for _, item := range asList(condition["expressions"]) {
+		m, _ := item.(map[string]any)
+		ids = append(ids, scalars(m["field_address"])...)
+	}
+	return ids
+}
+
+func asList(v any) []any {
+	items, _ := v.([]any)
+	return items
+}
+
+// scalars returns v, or the elements of v when it is a list, as strings.
+// Empty strings and non-scalar values are skipped.
+func scalars(v any) []string {
+	var out []string
+	items, ok := v.([]any)
+	if !ok {
+		items = []any{v}
+	}
+	for _, item := range items {
+		switch t := item.(type) {
+		case string:
+			if t != "" {
+				out = append(out, t)
+			}
+		case json.Number:
+			out = append(out, t.String())
+		}
+	}
+	return out
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ParamKeys returns the sorted parameter keys an entry accepts. The API
+// describes them either as a list of keys, a list of objects carrying the key
+// in "name" or "key", or an object keyed by parameter; anything else yields no
+// keys.
+func (e CatalogEntry) ParamKeys() []string {
+	keys := []string{}
+	var list []json.RawMessage
+	var obj map[string]json.RawMessage
+	switch {
+	case json.Unmarshal(e.AcceptedParameters, &list) == nil:
+		for _, item := range list {
+			var key string
+			if json.Unmarshal(item, &key) == nil {
+				keys = append(keys, key)
+				continue
+			}
+			var named struct {
+				Name string `json:"name"`
+				Key  string `json:"key"`
+			}
+			if json.Unmarshal(item, &named) == nil {
+				if named.Key != "" {
+					keys = append(keys, named.Key)
+				} else if named.Name != "" {
+					keys = append(keys, named.Name)
+				}
+			}
+		}
+	case json.Unmarshal(e.AcceptedParameters, &obj) == nil:
+		for k := range obj {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Delta returns the value to send on update for a params structure, given the
+// value sent for the prior state and the planned one. The API keeps what an
+// update omits, so a removed structure is sent as null and so are top-level
+// keys dropped from a structure that remains. send is false when neither side
+// has the structure.
+func Delta(prior, planned any) (value any, send bool) {
+	if planned == nil {
+		return nil, prior != nil
+	}
+	priorObj, ok := prior.(map[string]any)
+	plannedObj, ok2 := planned.(map[string]any)
+	if !ok || !ok2 {
+		return planned, true
+	}
+	out := make(map[string]any, len(plannedObj))
+	for k := range priorObj {
+		out[k] = nil
+	}
+	for k, v := range plannedObj {
+		out[k] = v
+	}
+	return out, true
+}
+
+// Kept returns the top-level keys that an update cleared with Delta but the
+// fetched params still hold. A structure sent as null is cleared as a whole;
+// otherwise only its members sent as null are. Keys outside selected are not
+// returned by the API and cannot be checked.
+func Kept(sent any, fetched json.RawMessage, selected []string) ([]string, error) {
+	obj, err := Object(fetched)
+	if err != nil {
+		return nil, err
+	}
+	sentObj, _ := sent.(map[string]any)
+	var kept []string
+	for _, k := range selected {
+		if _, set := obj[k]; !set {
+			continue
+		}
+		if v, ok := sentObj[k]; sent == nil || (ok && v == nil) {
+			kept = append(kept, k)
+		}
+	}
+	sort.Strings(kept)
+	return kept, nil
+}
+
+// Refresh returns the JSON object to store for a params attribute. It starts
+// from the fetched object, dropping null members and empty arrays or objects
+// the API returns for unset keys, then carries over the top-level keys of
+// prior that fall outside selected: the API cannot return those, so they are
+// kept as configured rather than reported as removed. It returns "" when
+// nothing remains. Numbers are kept verbatim, so large IDs do not lose
+// precision.
+func Refresh(prior string, fetched json.RawMessage, selected []string) (string, error) {
+	out, err := Object(fetched)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(prior) != "" {
+		obj, err := decodeObject(json.RawMessage(prior))
+		if err != nil {
+			return "", err
+		}
+		known := make(map[string]bool, len(selected))
+		for _, k := range selected {
+			known[k] = true
+		}
+		for k, v := range obj {
+			if !known[k] {
+				out[k] = v
+			}
+		}
+	}
+	if len(out) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Object decodes a fetched params object with null members and empty arrays or
+// objects dropped. It never returns a nil map on success.
+func Object(raw json.RawMessage) (map[string]any, error) {
+	obj, err := decodeObject(raw)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{}
+	for k, v := range obj {
+		if v = prune(v); v != nil {
+			out[k] = v
+		}
+	}
+	return out, nil
+}
+
+func decodeObject(raw json.RawMessage) (map[string]any, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || string(trimmed) == "null" {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	dec.UseNumber()
+	var obj map[string]any
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// prune drops null members and empty arrays or objects from objects, returning
+// nil when nothing is left of v.
+func prune(v any) any {
+	switch t := v.(type) {
+	case nil:
+		return nil
+	case map[string]any:
+		for k, e := range t {
+			if p := prune(e); p != nil {
+				t[k] = p
+			} else {
+				delete(t, k)
+			}
+		}
+		if len(t) == 0 {
+			return nil
+		}
+		return t
+	case []any:
+		if len(t) == 0 {
+			return nil
+		}
+		// Elements are pruned in place but never dropped, since positions
+		// can be meaningful (expressions are referenced by index).
+		for i, e := range t {
+			if p := prune(e); p != nil {
+				t[i] = p
+			}
+		}
+		return t
+	}
+	return v
+}
@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package automationgql_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/automationgql"
+)
+
+func TestRefresh(t *testing.T) {
+	selected := []string{"to_phase_id", "triggerFieldIds"}
+	cases := map[string]struct {
+		prior   string
+		fetched string
+		want    string
+	}{
+		"null fetched":            {fetched: `null`, want: ``},
+		"empty fetched":           {fetched: ``, want: ``},
+		"all members null":        {fetched: `{"to_phase_id":null,"triggerFieldIds":null}`, want: ``},
+		"empty array dropped":     {fetched: `{"to_phase_id":"1","triggerFieldIds":[]}`, want: `{"to_phase_id":"1"}`},
+		"keys sorted":             {fetched: `{"triggerFieldIds":["2"],"to_phase_id":"1"}`, want: `{"to_phase_id":"1","triggerFieldIds":["2"]}`},
+		"unselected prior kept":   {prior: `{"custom":true,"to_phase_id":"9"}`, fetched: `{"to_phase_id":"1"}`, want: `{"custom":true,"to_phase_id":"1"}`},
+		"selected prior replaced": {prior: `{"triggerFieldIds":["9"]}`, fetched: `{"to_phase_id":"1"}`, want: `{"to_phase_id":"1"}`},
+		"nested nulls dropped":    {fetched: `{"to_phase_id":{"a":null,"b":1}}`, want: `{"to_phase_id":{"b":1}}`},
+		"array elements kept":     {fetched: `{"triggerFieldIds":[{"a":null},{"b":""}]}`, want: `{"triggerFieldIds":[{},{"b":""}]}`},
+		"large number verbatim":   {fetched: `{"to_phase_id":9007199254740993}`, want: `{"to_phase_id":9007199254740993}`},
+	}
+	for name, c := range cases {
+		got, err := automationgql.Refresh(c.prior, json.RawMessage(c.fetched), selected)
+		if err != nil {
+			t.Fatalf("%s: Refresh error: %v", name, err)
+		}
+		if got != c.want {
+			t.Errorf("%s: Refresh = %s, want %s", name, got, c.want)
+		}
+	}
+}
+
+func TestRefresh_InvalidJSON(t *testing.T) {
+	if _, err := automationgql.Refresh("", json.RawMessage(`[1,2]`), nil); err == nil {
+		t.Error("Refresh of a non-object returned no error")
+	}
+	if _, err := automationgql.Refresh("{not json", json.RawMessage(`{}`), nil); err == nil {
+		t.Error("Refresh with an invalid prior returned no error")
+	}
+}
+
+func TestCatalogEntryParamKeys(t *testing.T) {
+	cases := map[string]struct {
+		raw  string
+		want []string
+	}{
+		"null":            {raw: `null`},
+		"list of keys":    {raw: `["triggerFieldIds","to_phase_id"]`, want: []string{"to_phase_id", "triggerFieldIds"}},
+		"list of objects": {raw: `[{"name":"to_phase_id"},{"key":"field_map","name":"Fields"}]`, want: []string{"field_map", "to_phase_id"}},
+		"object":          {raw: `{"email_template_id":{"type":"ID"}}`, want: []string{"email_template_id"}},
+		"scalar":          {raw: `"to_phase_id"`},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := automationgql.CatalogEntry{AcceptedParameters: json.RawMessage(tc.raw)}.ParamKeys()
+			if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Fatalf("ParamKeys() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCompatible(t *testing.T) {
+	event := automationgql.CatalogEntry{Id: "card_created", ActionsBlacklist: []string{"move_single_card"}}
+	action := automationgql.CatalogEntry{Id: "send_email_template", EventsBlacklist: []string{"scheduler"}}
+	if !automationgql.Compatible(event, action) {
+		t.Fatal("expected card_created and send_email_template to be compatible")
+	}
+	if automationgql.Compatible(event, automationgql.CatalogEntry{Id: "move_single_card"}) {
+		t.Fatal("expected the event blacklist to apply")
+	}
+	if automationgql.Compatible(automationgql.CatalogEntry{Id: "scheduler"}, action) {
+		t.Fatal("expected the action blacklist to apply")
+	}
+}
+
+func TestUnknownKeys(t *testing.T) {
+	params := map[string]any{"to_phase_id": "1", "typo": true, "other": 1}
+	entry := automationgql.CatalogEntry{AcceptedParameters: json.RawMessage(`["to_phase_id"]`)}
+	if got := automationgql.UnknownKeys(params, entry); strings.Join(got, ",") != "other,typo" {
+		t.Fatalf("UnknownKeys() = %v", got)
+	}
+	if got := automationgql.UnknownKeys(params, automationgql.CatalogEntry{}); got != nil {
+		t.Fatalf("UnknownKeys() without catalog keys = %v, want nil", got)
+	}
+}
+
+func TestFieldIDs(t *testing.T) {
+	event, _ := automationgql.Object(json.RawMessage(`{"triggerFieldIds":[420173505,"abc",""]}`))
+	action, _ := automationgql.Object(json.RawMessage(`{"field_map":[{"fieldId":"11"},{"inputMode":"fixed_value"}],"aiParams":{"value":"x","fieldIds":[12]}}`))
+	condition, _ := automationgql.Object(json.RawMessage(`{"expressions":[{"field_address":"13"},{"field_address":""}],"expressions_structure":[[0,1]]}`))
+	cases := map[string]struct {
+		got  []string
+		want string
+	}{
+		"event":     {automationgql.EventFieldIDs(event), "420173505,abc"},
+		"action":    {automationgql.ActionFieldIDs(action), "11,12"},
+		"condition": {automationgql.ConditionFieldIDs(condition), "13"},
+	}
+	for name, tc := range cases {
+		if strings.Join(tc.got, ",") != tc.want {
+			t.Errorf("%s: got %v, want %s", name, tc.got, tc.want)
+		}
+	}
+}
+
+func TestDelta(t *testing.T) {
+	cases := map[string]struct {
+		prior, planned any
+		want           string
+		send           bool
+	}{
+		"neither":      {},
+		"added":        {planned: map[string]any{"a": 1}, want: `{"a":1}`, send: true},
+		"removed":      {prior: map[string]any{"a": 1}, want: `null`, send: true},
+		"key removed":  {prior: map[string]any{"a": 1, "b": 2}, planned: map[string]any{"b": 3}, want: `{"a":null,"b":3}`, send: true},
+		"not objects":  {prior: []any{1}, planned: []any{2}, want: `[2]`, send: true},
+		"planned only": {planned: map[string]any{}, want: `{}`, send: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, send := automationgql.Delta(tc.prior, tc.planned)
+			if send != tc.send {
+				t.Fatalf("send = %v, want %v", send, tc.send)
+			}
+			if !send {
+				return
+			}
+			b, _ := json.Marshal(got)
+			if string(b) != tc.want {
+				t.Fatalf("Delta() = %s, want %s", b, tc.want)
+			}
+		})
+	}
+}
+
+func TestKept(t *testing.T) {
+	selected := []string{"a", "b", "c"}
+	cases := map[string]struct {
+		sent    any
+		fetched string
+		want    string
+	}{
+		"cleared":              {sent: nil, fetched: `null`},
+		"structure kept":       {sent: nil, fetched: `{"a":1,"b":[]}`, want: "a"},
+		"key cleared":          {sent: map[string]any{"a": nil, "b": 2}, fetched: `{"b":2}`},
+		"key kept":             {sent: map[string]any{"a": nil, "b": 2}, fetched: `{"a":1,"b":2}`, want: "a"},
+		"unselected not known": {sent: map[string]any{"x": nil}, fetched: `{"x":1}`},
+	}
+	for name, tc := range cases {
+		got, err := automationgql.Kept(tc.sent, json.RawMessage(tc.fetched), selected)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if strings.Join(got, ",") != tc.want {
+			t.Errorf("%s: Kept() = %v, want %s", name, got, tc.want)
+		}
+	}
+}
+
+func TestAddDetail(t *testing.T) {
+	a := automationgql.Automation{
+		EventParams:  json.RawMessage(`{"scheduler_frequency":"weekly"}`),
+		ActionParams: json.RawMessage(`null`),
+	}
+	if got := a.EventKeys(); strings.Join(got, ",") != strings.Join(automationgql.EventParamKeys, ",") {
+		t.Errorf("EventKeys before AddDetail = %v, want %v", got, automationgql.EventParamKeys)
+	}
+	detail := automationgql.Automation{
+		EventParams:  json.RawMessage(`{"scheduler_cron":"0 9 * * 1","scheduler_timezone":null}`),
+		ActionParams: json.RawMessage(`{"aiParams":{"fieldIds":[9007199254740993]}}`),
+	}
+	if err := a.AddDetail(detail); err != nil {
+		t.Fatalf("AddDetail error: %v", err)
+	}
+	if want := `{"scheduler_cron":"0 9 * * 1","scheduler_frequency":"weekly","scheduler_timezone":null}`; string(a.EventParams) != want {
+		t.Errorf("EventParams = %s, want %s", a.EventParams, want)
+	}
+	if want := `{"aiParams":{"fieldIds":[9007199254740993]}}`; string(a.ActionParams) != want {
+		t.Errorf("ActionParams = %s, want %s", a.ActionParams, want)
+	}
+	if got, want := strings.Join(a.ActionKeys(), ","), "to_phase_id,email_template_id,aiParams,field_map"; got != want {
+		t.Errorf("ActionKeys after AddDetail = %s, want %s", got, want)
+	}
+	if err := a.AddDetail(automationgql.Automation{EventParams: json.RawMessage(`[1]`)}); err == nil {
+		t.Error("AddDetail of a non-object returned no error")
+	}
+}
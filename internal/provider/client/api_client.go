@@ -13,6 +13,8 @@ import (
 	"io"
 	"net/http"
 	"strings"
+
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/validators"
 )
 
 type ApiClient struct {
@@ -21,6 +23,14 @@ type ApiClient struct {
 	Token    string
 	Version  string
 	TraceID  string
+	// UploadHTTP sends file uploads to presigned URLs. It must not add the API
+	// credentials, which presigned URLs reject; nil uses http.DefaultClient.
+	UploadHTTP *http.Client
+	// WebhookURLPolicy is the provider's webhook_url_policy, which webhook
+	// resources enforce on their url; nil allows any URL.
+	WebhookURLPolicy *validators.URLPolicy
+
+	webhookActions webhookActionsCache
 }
 
 // NewTraceID returns a W3C Trace Context trace-id: 16 random bytes as 32
@@ -142,3 +152,28 @@ func (c *ApiClient) DoGraphQL(ctx context.Context, query string, variables map[s
 	}
 	return nil
 }
+
+// Upload PUTs body to a presigned upload URL returned by the API.
+func (c *ApiClient) Upload(ctx context.Context, url, contentType string, body []byte) error {
+	httpClient := c.UploadHTTP
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return fmt.Errorf("upload http status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ package client
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"regexp"
@@ -289,3 +290,37 @@ func TestApiClient_DoGraphQL_SuccessDecode(t *testing.T) {
 		t.Fatalf("unexpected decode result: %+v", out)
 	}
 }
+
+func TestApiClient_Upload(t *testing.T) {
+	var gotMethod, gotType, gotAuth, gotBody string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotMethod, gotType, gotAuth, gotBody = r.Method, r.Header.Get("Content-Type"), r.Header.Get("Authorization"), string(body)
+	}))
+	defer ts.Close()
+
+	c := &ApiClient{HTTP: ts.Client(), Endpoint: ts.URL, Token: "secret", UploadHTTP: ts.Client()}
+	if err := c.Upload(t.Context(), ts.URL+"/upload?signature=x", "text/plain", []byte("hello")); err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+	if gotMethod != http.MethodPut || gotType != "text/plain" || gotBody != "hello" {
+		t.Fatalf("unexpected upload request: %s %q %q", gotMethod, gotType, gotBody)
+	}
+	if gotAuth != "" {
+		t.Fatalf("upload must not send the API token, got Authorization %q", gotAuth)
+	}
+}
+
+func TestApiClient_Upload_Non2xx(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("expired"))
+	}))
+	defer ts.Close()
+
+	c := &ApiClient{UploadHTTP: ts.Client()}
+	err := c.Upload(t.Context(), ts.URL, "", []byte("hello"))
+	if err == nil || !strings.Contains(err.Error(), "403") || !strings.Contains(err.Error(), "expired") {
+		t.Fatalf("expected status error, got: %v", err)
+	}
+}
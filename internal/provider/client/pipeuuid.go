@@ -1,24 +1,25 @@
 // Copyright (c) HashiCorp, Inc.
 // SPDX-License-Identifier: MPL-2.0
 
-package resources
+package client
 
 import (
 	"context"
 	"fmt"
-
-	"github.com/pipefy/terraform-provider-pipefy/internal/provider/client"
 )
 
 const getPipeUUIDQuery = "query GetPipeUuid_tf($id:ID!){ pipe(id:$id){ uuid } }"
 
-func resolvePipeUUID(ctx context.Context, api *client.ApiClient, pipeID string) (string, error) {
+// PipeUUID returns the UUID of the pipe with ID pipeID, which the API takes
+// instead of the ID in some operations, such as deleting phase fields and
+// listing AI agents.
+func (c *ApiClient) PipeUUID(ctx context.Context, pipeID string) (string, error) {
 	var output struct {
 		Pipe *struct {
 			UUID string `json:"uuid"`
 		} `json:"pipe"`
 	}
-	if err := api.DoGraphQL(ctx, getPipeUUIDQuery, map[string]any{"id": pipeID}, &output); err != nil {
+	if err := c.DoGraphQL(ctx, getPipeUUIDQuery, map[string]any{"id": pipeID}, &output); err != nil {
 		return "", fmt.Errorf("resolve pipe %q UUID: %w", pipeID, err)
 	}
 	if output.Pipe == nil || output.Pipe.UUID == "" {
@@ -1,7 +1,7 @@
 // Copyright (c) HashiCorp, Inc.
 // SPDX-License-Identifier: MPL-2.0
 
-package resources
+package client
 
 import (
 	"encoding/json"
@@ -10,11 +10,9 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
-
-	"github.com/pipefy/terraform-provider-pipefy/internal/provider/client"
 )
 
-func TestResolvePipeUUID(t *testing.T) {
+func TestApiClient_PipeUUID(t *testing.T) {
 	var operation string
 	api, closeServer := pipeUUIDTestClient(t, func(request gqlTestRequest) string {
 		operation = request.Query
@@ -25,16 +23,16 @@ func TestResolvePipeUUID(t *testing.T) {
 	})
 	defer closeServer()
 
-	got, err := resolvePipeUUID(t.Context(), api, "42")
+	got, err := api.PipeUUID(t.Context(), "42")
 	if err != nil || got != "pipe-uuid" {
-		t.Fatalf("resolvePipeUUID = (%q, %v), want (pipe-uuid, nil)", got, err)
+		t.Fatalf("PipeUUID = (%q, %v), want (pipe-uuid, nil)", got, err)
 	}
 	if !strings.Contains(operation, "query GetPipeUuid_tf") {
 		t.Fatalf("query operation missing GetPipeUuid_tf: %s", operation)
 	}
 }
 
-func TestResolvePipeUUIDErrors(t *testing.T) {
+func TestApiClient_PipeUUIDErrors(t *testing.T) {
 	cases := map[string]struct {
 		response string
 		want     string
@@ -47,7 +45,7 @@ func TestResolvePipeUUIDErrors(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			api, closeServer := pipeUUIDTestClient(t, func(gqlTestRequest) string { return tc.response })
 			defer closeServer()
-			_, err := resolvePipeUUID(t.Context(), api, "42")
+			_, err := api.PipeUUID(t.Context(), "42")
 			if err == nil || !strings.Contains(err.Error(), tc.want) {
 				t.Fatalf("error = %v, want containing %q", err, tc.want)
 			}
@@ -60,7 +58,7 @@ type gqlTestRequest struct {
 	Variables map[string]any `json:"variables"`
 }
 
-func pipeUUIDTestClient(t *testing.T, reply func(gqlTestRequest) string) (*client.ApiClient, func()) {
+func pipeUUIDTestClient(t *testing.T, reply func(gqlTestRequest) string) (*ApiClient, func()) {
 	t.Helper()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer r.Body.Close()
@@ -72,6 +70,6 @@ func pipeUUIDTestClient(t *testing.T, reply func(gqlTestRequest) string) (*clien
 		w.Header().Set("Content-Type", "application/json")
 		_, _ = io.WriteString(w, reply(request))
 	}))
-	api := &client.ApiClient{HTTP: server.Client(), Endpoint: server.URL, Token: "test"}
+	api := &ApiClient{HTTP: server.Client(), Endpoint: server.URL, Token: "test"}
 	return api, server.Close
 }
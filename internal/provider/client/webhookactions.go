@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/validators"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/webhookgql"
+)
+
+type webhookActionsCache struct {
+	mu      sync.Mutex
+	loaded  bool
+	actions []string
+}
+
+// WebhookActions returns the pipe webhook actions the API publishes, or nil
+// when it publishes none. An answer is kept for the life of the client; an
+// error is not, so the next call asks again.
+func (c *ApiClient) WebhookActions(ctx context.Context) ([]string, error) {
+	c.webhookActions.mu.Lock()
+	defer c.webhookActions.mu.Unlock()
+	if c.webhookActions.loaded {
+		return c.webhookActions.actions, nil
+	}
+	var out webhookgql.ActionsIntrospection
+	if err := c.DoGraphQL(ctx, webhookgql.ActionsQuery, nil, &out); err != nil {
+		return nil, err
+	}
+	c.webhookActions.actions = out.Actions(validators.PipeWebhookActions)
+	c.webhookActions.loaded = true
+	return c.webhookActions.actions, nil
+}
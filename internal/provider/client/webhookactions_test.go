@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApiClient_WebhookActionsCachesOnlyAnswers(t *testing.T) {
+	calls := 0
+	api, closeServer := pipeUUIDTestClient(t, func(gqlTestRequest) string {
+		calls++
+		if calls == 1 {
+			return `{"errors":[{"message":"timeout"}]}`
+		}
+		return `{"data":{"__type":{"enumValues":[{"name":"CARD_MOVE"},{"name":"CARD_ARCHIVE"}]}}}`
+	})
+	defer closeServer()
+
+	if _, err := api.WebhookActions(t.Context()); err == nil {
+		t.Fatal("WebhookActions returned no error for a failed request")
+	}
+	for range 2 {
+		got, err := api.WebhookActions(t.Context())
+		if err != nil || !reflect.DeepEqual(got, []string{"card.move", "card.archive"}) {
+			t.Fatalf("WebhookActions = (%v, %v), want ([card.move card.archive], nil)", got, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("API asked %d times, want 2: the error is retried and the answer cached", calls)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestUnit_AiAgentsDataSource_Read(t *testing.T) {
+	mock := &aiAgentMock{
+		pipeUUIDByID: map[string]string{"42": "pipe-uuid"},
+		otherAgents: []map[string]any{{
+			"uuid": "alpha-uuid", "name": "Alpha", "instruction": "Escalate", "disabledAt": nil,
+			"dataSourceIds": []string{"source-9", "source-3"},
+			"behaviors": []any{map[string]any{
+				"id": "behavior-9", "name": "On move", "event_id": "card_moved",
+				"event_params": map[string]any{"to_phase_id": "phase-3"},
+				"action_params": map[string]any{"aiBehaviorParams": map[string]any{
+					"instruction": "Use %{action:ref-notify} now\n%{action:ref-archive}",
+					"actionsAttributes": []any{
+						map[string]any{"id": "action-2", "referenceId": "ref-notify", "name": "Notify",
+							"actionType": "send_email_template", "metadata": map[string]any{"emailTemplateId": "template-1"}},
+						map[string]any{"id": "action-1", "referenceId": "ref-archive", "name": "Archive",
+							"actionType": "move_card", "metadata": map[string]any{"destinationPhaseId": "phase-9"}},
+					},
+				}},
+			}},
+		}},
+	}
+	server := newAiAgentServer(mock)
+	defer server.Close()
+	config := aiAgentConfig(server.URL, "true", false) + `
+	data "pipefy_ai_agents" "test" {
+		pipe_id    = "42"
+		depends_on = [pipefy_ai_agent.test]
+	}`
+	agents := tfjsonpath.New("agents")
+	alpha := agents.AtSliceIndex(0)
+	behavior := alpha.AtMapKey("behaviors").AtSliceIndex(0)
+	value := func(at tfjsonpath.Path, check knownvalue.Check) statecheck.StateCheck {
+		return statecheck.ExpectKnownValue("data.pipefy_ai_agents.test", at, check)
+	}
+	resource.UnitTest(t, aiAgentTestCase([]resource.TestStep{{
+		Config: config,
+		ConfigStateChecks: []statecheck.StateCheck{
+			value(agents.AtSliceIndex(1).AtMapKey("id"), knownvalue.StringExact("agent-uuid")),
+			value(agents.AtSliceIndex(1).AtMapKey("name"), knownvalue.StringExact("Triage")),
+			value(agents.AtSliceIndex(1).AtMapKey("behaviors").AtSliceIndex(0).AtMapKey("instruction"),
+				knownvalue.StringExact("Choose a destination")),
+			value(alpha.AtMapKey("active"), knownvalue.Bool(true)),
+			value(alpha.AtMapKey("data_source_ids"), knownvalue.ListExact([]knownvalue.Check{
+				knownvalue.StringExact("source-3"), knownvalue.StringExact("source-9"),
+			})),
+			value(behavior.AtMapKey("to_phase_id"), knownvalue.StringExact("phase-3")),
+			value(behavior.AtMapKey("instruction"), knownvalue.StringExact("Use {{action:Notify}} now")),
+			value(behavior.AtMapKey("actions").AtSliceIndex(0).AtMapKey("destination_phase_id"),
+				knownvalue.StringExact("phase-9")),
+			value(behavior.AtMapKey("actions").AtSliceIndex(1).AtMapKey("email_template_id"),
+				knownvalue.StringExact("template-1")),
+		},
+	}}))
+}
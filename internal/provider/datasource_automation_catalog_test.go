@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestUnit_AutomationCatalogDataSources_Read(t *testing.T) {
+	var repoIDs []any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var gr gqlReq
+		defer r.Body.Close()
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &gr)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch q := gr.Query; {
+		case strings.Contains(q, "automationEvents("):
+			repoIDs = append(repoIDs, gr.Variables["repoId"])
+			_, _ = io.WriteString(w, `{"data":{"automationEvents":[`+
+				`{"id":"card_created","name":"Card created","acceptedParameters":null},`+
+				`{"id":"field_updated","name":"Field updated","acceptedParameters":["triggerFieldIds"]}]}}`)
+		case strings.Contains(q, "automationActions("):
+			repoIDs = append(repoIDs, gr.Variables["repoId"])
+			_, _ = io.WriteString(w, `{"data":{"automationActions":[`+
+				`{"id":"update_card_field","name":"Update a field","acceptedParameters":[{"name":"field_map"}]}]}}`)
+		default:
+			_, _ = io.WriteString(w, `{"data":{}}`)
+		}
+	}))
+	defer srv.Close()
+
+	config := `
+	provider "pipefy" {
+		endpoint = "` + srv.URL + `"
+		token    = "testtoken"
+	}
+
+	data "pipefy_automation_events" "test" {
+		repo_id = "306729113"
+	}
+
+	data "pipefy_automation_actions" "test" {
+		repo_id = "306729113"
+	}
+	`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("data.pipefy_automation_events.test", tfjsonpath.New("events"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"id":                  knownvalue.StringExact("card_created"),
+							"name":                knownvalue.StringExact("Card created"),
+							"accepted_parameters": knownvalue.ListSizeExact(0),
+						}),
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"id":                  knownvalue.StringExact("field_updated"),
+							"name":                knownvalue.StringExact("Field updated"),
+							"accepted_parameters": knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("triggerFieldIds")}),
+						}),
+					})),
+					statecheck.ExpectKnownValue("data.pipefy_automation_actions.test", tfjsonpath.New("actions").AtSliceIndex(0).AtMapKey("accepted_parameters"),
+						knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("field_map")})),
+				},
+			},
+		},
+	})
+
+	for _, id := range repoIDs {
+		if id != "306729113" {
+			t.Fatalf("catalog queried with repoId %v", id)
+		}
+	}
+}
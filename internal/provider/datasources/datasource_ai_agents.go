@@ -0,0 +1,242 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/aiagentgql"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/client"
+)
+
+var _ datasource.DataSource = &AiAgentsDataSource{}
+
+// NewAiAgentsDataSource lists the AI agents of a pipe with their behaviors.
+func NewAiAgentsDataSource() datasource.DataSource { return &AiAgentsDataSource{} }
+
+type AiAgentsDataSource struct{ api *client.ApiClient }
+
+type AiAgentsDataSourceModel struct {
+	PipeId types.String   `tfsdk:"pipe_id"`
+	Agents []aiAgentModel `tfsdk:"agents"`
+}
+
+type aiAgentModel struct {
+	Id            types.String           `tfsdk:"id"`
+	Name          types.String           `tfsdk:"name"`
+	Instruction   types.String           `tfsdk:"instruction"`
+	Active        types.Bool             `tfsdk:"active"`
+	DataSourceIds []types.String         `tfsdk:"data_source_ids"`
+	Behaviors     []aiAgentBehaviorModel `tfsdk:"behaviors"`
+}
+
+type aiAgentBehaviorModel struct {
+	Id              types.String         `tfsdk:"id"`
+	Name            types.String         `tfsdk:"name"`
+	EventId         types.String         `tfsdk:"event_id"`
+	ToPhaseId       types.String         `tfsdk:"to_phase_id"`
+	TriggerFieldIds []types.String       `tfsdk:"trigger_field_ids"`
+	Instruction     types.String         `tfsdk:"instruction"`
+	Actions         []aiAgentActionModel `tfsdk:"actions"`
+}
+
+type aiAgentActionModel struct {
+	Id                 types.String        `tfsdk:"id"`
+	Name               types.String        `tfsdk:"name"`
+	ActionType         types.String        `tfsdk:"action_type"`
+	ReferenceId        types.String        `tfsdk:"reference_id"`
+	DestinationPhaseId types.String        `tfsdk:"destination_phase_id"`
+	PipeId             types.String        `tfsdk:"pipe_id"`
+	EmailTemplateId    types.String        `tfsdk:"email_template_id"`
+	TableId            types.String        `tfsdk:"table_id"`
+	ConnectorFieldId   types.String        `tfsdk:"connector_field_id"`
+	Fields             []aiAgentFieldModel `tfsdk:"fields"`
+}
+
+type aiAgentFieldModel struct {
+	FieldId   types.String `tfsdk:"field_id"`
+	InputMode types.String `tfsdk:"input_mode"`
+	Value     types.String `tfsdk:"value"`
+}
+
+func (d *AiAgentsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ai_agents"
+}
+
+func (d *AiAgentsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	computed := func(description string) dsschema.StringAttribute {
+		return dsschema.StringAttribute{Computed: true, Description: description}
+	}
+	field := dsschema.NestedAttributeObject{Attributes: map[string]dsschema.Attribute{
+		"field_id":   computed("The target field ID"),
+		"input_mode": computed("How the field is filled: `fill_with_ai`, `fixed_value` or `copy_from`"),
+		"value":      computed("The fixed value or source field ID; null for `fill_with_ai`"),
+	}}
+	action := dsschema.NestedAttributeObject{Attributes: map[string]dsschema.Attribute{
+		"id":                   computed("The ID of the action"),
+		"name":                 computed("The name of the action"),
+		"action_type":          computed("The action type"),
+		"reference_id":         computed("The reference the instruction uses for the action"),
+		"destination_phase_id": computed("The destination phase of `move_card` actions"),
+		"pipe_id":              computed("The target pipe of card actions"),
+		"email_template_id":    computed("The email template of `send_email_template` actions"),
+		"table_id":             computed("The target table of table record actions"),
+		"connector_field_id":   computed("The connection field of `update_table_record` actions"),
+		"fields": dsschema.ListNestedAttribute{
+			Computed: true, Description: "Ordered target field metadata", NestedObject: field,
+		},
+	}}
+	behavior := dsschema.NestedAttributeObject{Attributes: map[string]dsschema.Attribute{
+		"id":          computed("The ID of the behavior"),
+		"name":        computed("The name of the behavior"),
+		"event_id":    computed("The event that triggers the behavior"),
+		"to_phase_id": computed("The phase of `card_moved` events"),
+		"trigger_field_ids": dsschema.ListAttribute{
+			Computed: true, ElementType: types.StringType, Description: "The fields of `field_updated` events, sorted",
+		},
+		"instruction": computed("The instruction, with action references written as `{{action:NAME}}`"),
+		"actions": dsschema.ListNestedAttribute{
+			Computed: true, Description: "The actions of the behavior, sorted by name", NestedObject: action,
+		},
+	}}
+	resp.Schema = dsschema.Schema{
+		MarkdownDescription: "Lists the AI agents of a pipe with their behaviors, for referencing or importing agents managed elsewhere.",
+		Attributes: map[string]dsschema.Attribute{
+			"pipe_id": dsschema.StringAttribute{Required: true, Description: "The ID of the pipe"},
+			"agents": dsschema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The agents of the pipe, sorted by name",
+				NestedObject: dsschema.NestedAttributeObject{Attributes: map[string]dsschema.Attribute{
+					"id":          computed("The UUID of the agent"),
+					"name":        computed("The name of the agent"),
+					"instruction": computed("The agent-level instruction"),
+					"active":      dsschema.BoolAttribute{Computed: true, Description: "Whether the agent is enabled"},
+					"data_source_ids": dsschema.ListAttribute{
+						Computed: true, ElementType: types.StringType, Description: "The AI data source IDs, sorted",
+					},
+					"behaviors": dsschema.ListNestedAttribute{
+						Computed: true, Description: "The behaviors of the agent, sorted by name", NestedObject: behavior,
+					},
+				}},
+			},
+		},
+	}
+}
+
+func (d *AiAgentsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	api, ok := req.ProviderData.(*client.ApiClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *ApiClient, got %T", req.ProviderData))
+		return
+	}
+	d.api = api
+}
+
+func (d *AiAgentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AiAgentsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.PipeId.IsNull() || data.PipeId.ValueString() == "" {
+		resp.Diagnostics.AddError("missing pipe_id", "pipe_id must be provided")
+		return
+	}
+
+	repoUUID, err := d.api.PipeUUID(ctx, data.PipeId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("read AI agents failed", err.Error())
+		return
+	}
+	agents, err := aiagentgql.List(ctx, d.api, repoUUID)
+	if err != nil {
+		resp.Diagnostics.AddError("read AI agents failed", err.Error())
+		return
+	}
+
+	data.Agents = make([]aiAgentModel, 0, len(agents))
+	for _, agent := range agents {
+		data.Agents = append(data.Agents, agentToModel(agent))
+	}
+	sort.SliceStable(data.Agents, func(i, j int) bool {
+		return data.Agents[i].Name.ValueString() < data.Agents[j].Name.ValueString()
+	})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func agentToModel(agent aiagentgql.Agent) aiAgentModel {
+	model := aiAgentModel{
+		Id:            types.StringValue(agent.UUID),
+		Name:          types.StringValue(agent.Name),
+		Instruction:   types.StringValue(agent.Instruction),
+		Active:        types.BoolValue(agent.DisabledAt == nil),
+		DataSourceIds: sortedStrings(agent.DataSourceIDs),
+		Behaviors:     make([]aiAgentBehaviorModel, 0, len(agent.Behaviors)),
+	}
+	for _, behavior := range agent.Behaviors {
+		params := behavior.ActionParams.AIBehaviorParams
+		item := aiAgentBehaviorModel{
+			Id:              types.StringValue(behavior.ID),
+			Name:            types.StringValue(behavior.Name),
+			EventId:         types.StringValue(behavior.EventID),
+			ToPhaseId:       types.StringPointerValue(behavior.EventParams.ToPhaseID),
+			TriggerFieldIds: sortedStrings(behavior.EventParams.TriggerFieldIDs),
+			Instruction:     types.StringValue(params.Template()),
+			Actions:         make([]aiAgentActionModel, 0, len(params.Actions)),
+		}
+		for _, action := range params.Actions {
+			item.Actions = append(item.Actions, actionToModel(action))
+		}
+		sort.SliceStable(item.Actions, func(i, j int) bool {
+			return item.Actions[i].Name.ValueString() < item.Actions[j].Name.ValueString()
+		})
+		model.Behaviors = append(model.Behaviors, item)
+	}
+	sort.SliceStable(model.Behaviors, func(i, j int) bool {
+		return model.Behaviors[i].Name.ValueString() < model.Behaviors[j].Name.ValueString()
+	})
+	return model
+}
+
+func actionToModel(action aiagentgql.Action) aiAgentActionModel {
+	metadata := action.Metadata
+	model := aiAgentActionModel{
+		Id:                 types.StringValue(action.ID),
+		Name:               types.StringValue(action.Name),
+		ActionType:         types.StringValue(action.ActionType),
+		ReferenceId:        types.StringValue(action.ReferenceID),
+		DestinationPhaseId: types.StringPointerValue(metadata.DestinationPhaseID),
+		PipeId:             types.StringPointerValue(metadata.PipeID),
+		EmailTemplateId:    types.StringPointerValue(metadata.EmailTemplateID),
+		TableId:            types.StringPointerValue(metadata.TableID),
+		ConnectorFieldId:   types.StringPointerValue(metadata.ConnectorFieldID),
+		Fields:             make([]aiAgentFieldModel, 0, len(metadata.Fields)),
+	}
+	for _, field := range metadata.Fields {
+		model.Fields = append(model.Fields, aiAgentFieldModel{
+			FieldId:   types.StringValue(field.FieldID),
+			InputMode: types.StringValue(field.InputMode),
+			Value:     types.StringPointerValue(field.Value),
+		})
+	}
+	return model
+}
+
+func sortedStrings(values []string) []types.String {
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	result := make([]types.String, 0, len(sorted))
+	for _, value := range sorted {
+		result = append(result, types.StringValue(value))
+	}
+	return result
+}
@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/automationgql"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/client"
+)
+
+var _ datasource.DataSource = &AutomationCatalogDataSource{}
+
+// NewAutomationEventsDataSource lists the automation events a repo supports.
+func NewAutomationEventsDataSource() datasource.DataSource {
+	return &AutomationCatalogDataSource{kind: "event", query: automationgql.EventsQuery, field: "automationEvents"}
+}
+
+// NewAutomationActionsDataSource lists the automation actions a repo supports.
+func NewAutomationActionsDataSource() datasource.DataSource {
+	return &AutomationCatalogDataSource{kind: "action", query: automationgql.ActionsQuery, field: "automationActions"}
+}
+
+// AutomationCatalogDataSource backs both catalog data sources, which differ
+// only in the query they run and the attribute holding the entries.
+type AutomationCatalogDataSource struct {
+	api   *client.ApiClient
+	kind  string // "event" or "action"
+	query string
+	field string
+}
+
+type automationCatalogEntryModel struct {
+	Id                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	AcceptedParameters types.List   `tfsdk:"accepted_parameters"`
+}
+
+func (d *AutomationCatalogDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_automation_" + d.kind + "s"
+}
+
+func (d *AutomationCatalogDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = dsschema.Schema{
+		MarkdownDescription: fmt.Sprintf("Lists the automation %ss available to a pipe or table, for use as `%s_id` in `pipefy_automation`.", d.kind, d.kind),
+		Attributes: map[string]dsschema.Attribute{
+			"repo_id": dsschema.StringAttribute{Required: true, Description: "The ID of the pipe or table"},
+			d.kind + "s": dsschema.ListNestedAttribute{
+				Computed:    true,
+				Description: fmt.Sprintf("The automation %ss, in API order", d.kind),
+				NestedObject: dsschema.NestedAttributeObject{Attributes: map[string]dsschema.Attribute{
+					"id":   dsschema.StringAttribute{Computed: true, Description: "The ID to use in `pipefy_automation`"},
+					"name": dsschema.StringAttribute{Computed: true, Description: "The display name"},
+					"accepted_parameters": dsschema.ListAttribute{
+						Computed:    true,
+						ElementType: types.StringType,
+						Description: fmt.Sprintf("The parameter keys accepted in `%s_params`, sorted", d.kind),
+					},
+				}},
+			},
+		},
+	}
+}
+
+func (d *AutomationCatalogDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	api, ok := req.ProviderData.(*client.ApiClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *ApiClient, got %T", req.ProviderData))
+		return
+	}
+	d.api = api
+}
+
+func (d *AutomationCatalogDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var repoID types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("repo_id"), &repoID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if repoID.IsNull() || repoID.ValueString() == "" {
+		resp.Diagnostics.AddError("missing repo_id", "repo_id must be provided")
+		return
+	}
+
+	var out map[string][]automationgql.CatalogEntry
+	if err := d.api.DoGraphQL(ctx, d.query, map[string]any{"repoId": repoID.ValueString()}, &out); err != nil {
+		resp.Diagnostics.AddError("read automation "+d.kind+"s failed", err.Error())
+		return
+	}
+
+	entries := make([]automationCatalogEntryModel, 0, len(out[d.field]))
+	for _, e := range out[d.field] {
+		keys, diags := types.ListValueFrom(ctx, types.StringType, e.ParamKeys())
+		resp.Diagnostics.Append(diags...)
+		entries = append(entries, automationCatalogEntryModel{
+			Id:                 types.StringValue(e.Id),
+			Name:               types.StringValue(e.Name),
+			AcceptedParameters: keys,
+		})
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_id"), repoID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(d.kind+"s"), entries)...)
+}
@@ -37,3 +37,13 @@ func TestFindByUUID_EmptySlice(t *testing.T) {
 		t.Error("FindByUUID(nil, ...) returned ok=true, want false")
 	}
 }
+
+func TestConnectedRepoID(t *testing.T) {
+	if got := (fieldgql.Field{}).ConnectedRepoID(); got != "" {
+		t.Errorf("ConnectedRepoID() without a connected repo = %q, want empty", got)
+	}
+	f := fieldgql.Field{ConnectedRepo: &fieldgql.ConnectedRepo{Id: "301"}}
+	if got := f.ConnectedRepoID(); got != "301" {
+		t.Errorf("ConnectedRepoID() = %q, want 301", got)
+	}
+}
@@ -2,9 +2,12 @@
 // SPDX-License-Identifier: MPL-2.0
 
 // Package labelgql holds the GraphQL field selection and the typed label
-// payload shared across the pipefy_label resource's reads and writes.
+// payload shared across the pipefy_label and pipefy_pipe_labels resources'
+// reads and writes.
 package labelgql
 
+import "sort"
+
 const Selection = "id name color"
 
 type Label struct {
@@ -21,3 +24,44 @@ func FindByID(labels []Label, id string) (Label, bool) {
 	}
 	return Label{}, false
 }
+
+// Changes lists the mutations that turn a pipe's labels into a desired set.
+// Create entries have no Id; Update entries carry the existing Id and the
+// desired color.
+type Changes struct {
+	Create []Label
+	Update []Label
+	Delete []string
+}
+
+// Diff compares a pipe's current labels with the desired name-to-color set.
+// Labels are matched by name: the first label with a desired name is kept and
+// recolored when needed, while later labels sharing that name and labels whose
+// name is not desired are deleted. Creates are sorted by name so applies are
+// deterministic.
+func Diff(current []Label, desired map[string]string) Changes {
+	var changes Changes
+	kept := map[string]bool{}
+	for _, l := range current {
+		color, want := desired[l.Name]
+		if !want || kept[l.Name] {
+			changes.Delete = append(changes.Delete, l.Id)
+			continue
+		}
+		kept[l.Name] = true
+		if l.Color != color {
+			changes.Update = append(changes.Update, Label{Id: l.Id, Name: l.Name, Color: color})
+		}
+	}
+	names := make([]string, 0, len(desired))
+	for name := range desired {
+		if !kept[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		changes.Create = append(changes.Create, Label{Name: name, Color: desired[name]})
+	}
+	return changes
+}
@@ -4,6 +4,7 @@
 package labelgql_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/pipefy/terraform-provider-pipefy/internal/provider/labelgql"
@@ -37,3 +38,37 @@ func TestFindByID_EmptySlice(t *testing.T) {
 		t.Error("FindByID(nil, ...) returned ok=true, want false")
 	}
 }
+
+func TestDiff(t *testing.T) {
+	current := []labelgql.Label{
+		{Id: "1", Name: "Bug", Color: "#FF0000"},
+		{Id: "2", Name: "Feature", Color: "#00FF00"},
+		{Id: "3", Name: "Bug", Color: "#FF0000"},
+		{Id: "4", Name: "Ad hoc", Color: "#000000"},
+	}
+	desired := map[string]string{
+		"Bug":     "#FF0000",
+		"Feature": "#0000FF",
+		"Urgent":  "#FFA500",
+		"Blocked": "#999999",
+	}
+	got := labelgql.Diff(current, desired)
+
+	if want := []labelgql.Label{{Name: "Blocked", Color: "#999999"}, {Name: "Urgent", Color: "#FFA500"}}; !reflect.DeepEqual(got.Create, want) {
+		t.Errorf("Create = %v, want %v", got.Create, want)
+	}
+	if want := []labelgql.Label{{Id: "2", Name: "Feature", Color: "#0000FF"}}; !reflect.DeepEqual(got.Update, want) {
+		t.Errorf("Update = %v, want %v", got.Update, want)
+	}
+	if want := []string{"3", "4"}; !reflect.DeepEqual(got.Delete, want) {
+		t.Errorf("Delete = %v, want %v", got.Delete, want)
+	}
+}
+
+func TestDiff_InSync(t *testing.T) {
+	current := []labelgql.Label{{Id: "1", Name: "Bug", Color: "#FF0000"}}
+	got := labelgql.Diff(current, map[string]string{"Bug": "#FF0000"})
+	if len(got.Create)+len(got.Update)+len(got.Delete) != 0 {
+		t.Errorf("Diff of an in-sync set = %+v, want no changes", got)
+	}
+}
@@ -16,3 +16,10 @@ func LockRepo(repoID string) func() {
 	mu.Lock()
 	return mu.Unlock
 }
+
+// LockAiAgent serializes read-modify-write updates of one AI agent, whose
+// behaviors are replaced as a whole by every update. Like LockRepo, it only
+// covers this provider process, not applies from other states.
+func LockAiAgent(agentUUID string) func() {
+	return LockRepo("ai_agent/" + agentUUID)
+}
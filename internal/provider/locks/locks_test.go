@@ -123,3 +123,10 @@ func TestLockRepoMultipleCalls(t *testing.T) {
 	unlock2()
 
 }
+
+func TestLockAiAgentIsSeparateFromRepo(t *testing.T) {
+	unlockRepo := LockRepo("abc")
+	unlockAgent := LockAiAgent("abc")
+	unlockAgent()
+	unlockRepo()
+}
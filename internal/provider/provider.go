@@ -10,7 +10,9 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -18,6 +20,7 @@ import (
 	"github.com/pipefy/terraform-provider-pipefy/internal/provider/client"
 	"github.com/pipefy/terraform-provider-pipefy/internal/provider/datasources"
 	"github.com/pipefy/terraform-provider-pipefy/internal/provider/resources"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/validators"
 	"golang.org/x/oauth2/clientcredentials"
 )
 
@@ -40,6 +43,14 @@ type PipefyProviderModel struct {
 	ClientID     types.String `tfsdk:"client_id"`
 	ClientSecret types.String `tfsdk:"client_secret"`
 	TokenURL     types.String `tfsdk:"token_url"`
+
+	WebhookURLPolicy *webhookURLPolicyModel `tfsdk:"webhook_url_policy"`
+}
+
+type webhookURLPolicyModel struct {
+	AllowedHosts   []types.String `tfsdk:"allowed_hosts"`
+	RequireHTTPS   types.Bool     `tfsdk:"require_https"`
+	DenyPrivateIPs types.Bool     `tfsdk:"deny_private_ips"`
 }
 
 func (p *PipefyProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -72,6 +83,25 @@ func (p *PipefyProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				MarkdownDescription: "Service Account Token Endpoint URL. Defaults to https://app.pipefy.com/oauth/token. Can also be set via PIPEFY_TOKEN_URL environment variable.",
 				Optional:            true,
 			},
+			"webhook_url_policy": schema.SingleNestedAttribute{
+				MarkdownDescription: "Restricts the URLs `pipefy_webhook` and `pipefy_organization_webhook` may send data to. URLs that break the policy fail at plan time, before anything is sent.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"allowed_hosts": schema.ListAttribute{
+						MarkdownDescription: "Hosts webhooks may point to: exact hosts like `hooks.example.com`, or wildcards like `*.example.com` that match any subdomain but not the domain itself. Any host is allowed when unset.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"require_https": schema.BoolAttribute{
+						MarkdownDescription: "Reject plain http URLs. Defaults to `true`.",
+						Optional:            true,
+					},
+					"deny_private_ips": schema.BoolAttribute{
+						MarkdownDescription: "Reject URLs whose host is `localhost` or a loopback, private, carrier-grade NAT (`100.64.0.0/10`), link-local or unspecified IP address, or a numeric host that is not a dotted-decimal IP address, such as `2130706433`, `127.1` or `0x7f.0.0.1`. Host names are not resolved. Defaults to `true`.",
+						Optional:            true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -137,22 +167,68 @@ func (p *PipefyProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	api := &client.ApiClient{HTTP: httpClient, Endpoint: endpoint, Token: apiToken, Version: p.version, TraceID: client.NewTraceID()}
+	policy, diags := data.WebhookURLPolicy.policy()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	api := &client.ApiClient{
+		HTTP: httpClient, Endpoint: endpoint, Token: apiToken, Version: p.version, TraceID: client.NewTraceID(),
+		UploadHTTP:       &http.Client{Timeout: 5 * time.Minute},
+		WebhookURLPolicy: policy,
+	}
 
 	resp.DataSourceData = api
 	resp.ResourceData = api
 }
 
+// policy converts the configured webhook_url_policy, returning nil when it is
+// not set. Unknown values take their defaults.
+func (m *webhookURLPolicyModel) policy() (*validators.URLPolicy, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if m == nil {
+		return nil, diags
+	}
+	policy := &validators.URLPolicy{
+		RequireHTTPS:   m.RequireHTTPS.IsNull() || m.RequireHTTPS.IsUnknown() || m.RequireHTTPS.ValueBool(),
+		DenyPrivateIPs: m.DenyPrivateIPs.IsNull() || m.DenyPrivateIPs.IsUnknown() || m.DenyPrivateIPs.ValueBool(),
+	}
+	for index, host := range m.AllowedHosts {
+		if host.IsNull() || host.IsUnknown() {
+			continue
+		}
+		if err := validators.ValidateHostPattern(host.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root("webhook_url_policy").AtName("allowed_hosts").AtListIndex(index),
+				"Invalid webhook URL policy", err.Error(),
+			)
+			continue
+		}
+		policy.AllowedHosts = append(policy.AllowedHosts, host.ValueString())
+	}
+	return policy, diags
+}
+
 func (p *PipefyProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		resources.NewPipeResource,
 		resources.NewPhaseResource,
+		resources.NewPhaseOrderResource,
+		resources.NewPhaseTransitionsResource,
 		resources.NewFieldResource,
+		resources.NewStartFormFieldResource,
+		resources.NewPhaseFieldsResource,
+		resources.NewFieldOrderResource,
 		resources.NewAutomationResource,
 		resources.NewLabelResource,
+		resources.NewPipeLabelsResource,
 		resources.NewPipeRelationResource,
 		resources.NewWebhookResource,
+		resources.NewOrganizationWebhookResource,
 		resources.NewAiAgentResource,
+		resources.NewAiAgentBehaviorResource,
+		resources.NewAiDataSourceResource,
 		resources.NewTableResource,
 		resources.NewTableFieldResource,
 	}
@@ -166,6 +242,9 @@ func (p *PipefyProvider) DataSources(ctx context.Context) []func() datasource.Da
 	return []func() datasource.DataSource{
 		datasources.NewPipeDataSource,
 		datasources.NewPhaseDataSource,
+		datasources.NewAutomationEventsDataSource,
+		datasources.NewAutomationActionsDataSource,
+		datasources.NewAiAgentsDataSource,
 	}
 }
 
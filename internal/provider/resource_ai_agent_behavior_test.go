@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func existingAgentMock() *aiAgentMock {
+	return &aiAgentMock{
+		exists: true, name: "Triage", instruction: "Classify cards",
+		behaviors: []any{map[string]any{
+			"name": "On create", "eventId": "card_created",
+			"actionParams": map[string]any{"aiBehaviorParams": map[string]any{
+				"instruction": "Choose a destination\n%{action:ref-1}",
+				"actionsAttributes": []any{map[string]any{
+					"name": "Move", "actionType": "move_card", "referenceId": "ref-1",
+					"metadata": map[string]any{"destinationPhaseId": "phase-2"},
+				}},
+			}},
+		}},
+	}
+}
+
+func aiAgentBehaviorConfig(endpoint, agentID, instruction string) string {
+	return aiAgentProvider(endpoint) + `
+	resource "pipefy_ai_agent_behavior" "test" {
+		agent_id = ` + agentID + `
+		name = "Escalate"
+		event_id = "card_created"
+		instruction = "` + instruction + `"
+		actions = {
+			"Update" = {
+				action_type = "update_card"
+				pipe_id = "42"
+				fields = [{ field_id = "title", input_mode = "fill_with_ai" }]
+			}
+		}
+	}`
+}
+
+func TestUnit_AiAgentBehaviorResource_CRUD(t *testing.T) {
+	mock := existingAgentMock()
+	server := newAiAgentServer(mock)
+	defer server.Close()
+	resource.UnitTest(t, aiAgentTestCase([]resource.TestStep{
+		{
+			Config: aiAgentBehaviorConfig(server.URL, `"agent-uuid"`, "Escalate urgent cards"),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue(
+					"pipefy_ai_agent_behavior.test", tfjsonpath.New("id"), knownvalue.StringExact("behavior-2"),
+				),
+				statecheck.ExpectKnownValue(
+					"pipefy_ai_agent_behavior.test",
+					tfjsonpath.New("actions").AtMapKey("Update").AtMapKey("id"),
+					knownvalue.StringExact("action-1"),
+				),
+			},
+		},
+		{
+			Config: aiAgentBehaviorConfig(server.URL, `"agent-uuid"`, "Escalate all cards"),
+			Check: func(*terraform.State) error {
+				return assertBehaviorNames(mock.behaviors, "On create", "Escalate")
+			},
+		},
+		{
+			ResourceName: "pipefy_ai_agent_behavior.test", ImportState: true,
+			ImportStateId: "agent-uuid/behavior-2", ImportStateVerify: true,
+		},
+	}))
+	if err := assertBehaviorNames(mock.behaviors, "On create"); err != nil {
+		t.Fatalf("after destroy: %v", err)
+	}
+	instruction := aiBehaviorParams(mock.behaviors[0].(map[string]any))["instruction"]
+	if instruction != "Choose a destination\n%{action:ref-1}" {
+		t.Fatalf("unmanaged behavior was changed: instruction %q", instruction)
+	}
+}
+
+func TestUnit_AiAgentBehaviorResource_WithAgentWithoutBehaviors(t *testing.T) {
+	mock := &aiAgentMock{}
+	server := newAiAgentServer(mock)
+	defer server.Close()
+	config := func(agentInstruction string) string {
+		return aiAgentBehaviorConfig(server.URL, "pipefy_ai_agent.test.id", "Escalate urgent cards") + `
+		resource "pipefy_ai_agent" "test" {
+			pipe_id = "42"
+			name = "Triage"
+			instruction = "` + agentInstruction + `"
+		}`
+	}
+	resource.UnitTest(t, aiAgentTestCase([]resource.TestStep{
+		{Config: config("Classify cards")},
+		{
+			Config: config("Classify and escalate cards"),
+			Check: func(*terraform.State) error {
+				return assertBehaviorNames(mock.behaviors, "Escalate")
+			},
+		},
+		{Config: config("Classify and escalate cards"), PlanOnly: true},
+	}))
+}
+
+func TestUnit_AiAgentBehaviorResource_RejectsDuplicateName(t *testing.T) {
+	mock := existingAgentMock()
+	server := newAiAgentServer(mock)
+	defer server.Close()
+	config := aiAgentProvider(server.URL) + `
+	resource "pipefy_ai_agent_behavior" "test" {
+		agent_id = "agent-uuid"
+		name = "On create"
+		event_id = "card_created"
+		instruction = "Again"
+		actions = { "Move" = { action_type = "move_card", destination_phase_id = "phase-3" } }
+	}`
+	resource.UnitTest(t, aiAgentTestCase([]resource.TestStep{{
+		Config:      config,
+		ExpectError: regexp.MustCompile(`already has a behavior named "On create"`),
+	}}))
+}
+
+func assertBehaviorNames(behaviors []any, want ...string) error {
+	if len(behaviors) != len(want) {
+		return fmt.Errorf("behaviors = %v, want names %v", behaviors, want)
+	}
+	for index, raw := range behaviors {
+		behavior, _ := raw.(map[string]any)
+		if behavior["name"] != want[index] {
+			return fmt.Errorf("behavior %d is %v, want %q", index, behavior["name"], want[index])
+		}
+	}
+	return nil
+}
+
+func TestUnit_AiAgentBehaviorResource_DetectsConcurrentWrite(t *testing.T) {
+	mock := existingAgentMock()
+	mock.concurrentWrite = map[string]any{
+		"name": "Archive", "eventId": "card_created",
+		"actionParams": map[string]any{"aiBehaviorParams": map[string]any{
+			"instruction": "Archive old cards", "actionsAttributes": []any{},
+		}},
+	}
+	server := newAiAgentServer(mock)
+	defer server.Close()
+	resource.UnitTest(t, aiAgentTestCase([]resource.TestStep{{
+		Config:      aiAgentBehaviorConfig(server.URL, `"agent-uuid"`, "Escalate urgent cards"),
+		ExpectError: regexp.MustCompile(`changed\s+by\s+another\s+apply`),
+	}}))
+}
@@ -196,8 +196,8 @@ func TestUnit_AiAgentResource_UpdateStatusFailureKeepsConfigState(t *testing.T)
 				),
 				statecheck.ExpectKnownValue(
 					"pipefy_ai_agent.test",
-					tfjsonpath.New("behaviors").AtSliceIndex(1).AtMapKey("name"),
-					knownvalue.StringExact("On field update"),
+					tfjsonpath.New("behaviors").AtMapKey("Before create").AtMapKey("event_id"),
+					knownvalue.StringExact("field_updated"),
 				),
 			},
 		},
@@ -4,10 +4,15 @@
 package provider_test
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"sync"
@@ -16,6 +21,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
@@ -31,14 +37,20 @@ type aiAgentMock struct {
 	operations       []string
 	referenceHistory [][]string
 	pipeUUIDByID     map[string]string
+	pipeReferences   string
+	tableReferences  string
+	otherAgents      []map[string]any
 	failCreate       bool
 	failUpdate       bool
 	failStatus       bool
 	failRead         bool
 	readNull         bool
 	nullAfterUpdate  bool
-	failDelete       bool
-	deleteCalls      int
+	// concurrentWrite is a behavior another apply adds right after the next
+	// update.
+	concurrentWrite map[string]any
+	failDelete      bool
+	deleteCalls     int
 }
 
 func (mock *aiAgentMock) serveHTTP(w http.ResponseWriter, r *http.Request) {
@@ -67,8 +79,20 @@ func (mock *aiAgentMock) handle(operation string, variables map[string]any) stri
 		return mock.updateStatus(variables)
 	case "Read":
 		return mock.read()
+	case "List":
+		return mock.list()
 	case "Delete":
 		return mock.delete()
+	case "References":
+		if mock.pipeReferences == "" {
+			return `{"data":{}}`
+		}
+		return `{"data":{"pipe":` + mock.pipeReferences + `}}`
+	case "TableReferences":
+		if mock.tableReferences == "" {
+			return `{"data":{}}`
+		}
+		return `{"data":{"table":` + mock.tableReferences + `}}`
 	default:
 		return `{"data":{}}`
 	}
@@ -111,6 +135,10 @@ func (mock *aiAgentMock) update(variables map[string]any) string {
 	if mock.nullAfterUpdate {
 		mock.readNull = true
 	}
+	if mock.concurrentWrite != nil {
+		mock.behaviors = append(mock.behaviors, mock.concurrentWrite)
+		mock.concurrentWrite = nil
+	}
 	return `{"data":{"updateAiAgent":{"agent":{"uuid":"agent-uuid"}}}}`
 }
 
@@ -134,6 +162,18 @@ func (mock *aiAgentMock) read() string {
 	return `{"data":{"aiAgent":` + string(payload) + `}}`
 }
 
+func (mock *aiAgentMock) list() string {
+	agents := []any{}
+	if mock.exists {
+		agents = append(agents, mock.agentPayload())
+	}
+	for _, agent := range mock.otherAgents {
+		agents = append(agents, agent)
+	}
+	payload, _ := json.Marshal(agents)
+	return `{"data":{"aiAgents":` + string(payload) + `}}`
+}
+
 func (mock *aiAgentMock) delete() string {
 	mock.deleteCalls++
 	if mock.failDelete {
@@ -253,7 +293,8 @@ func aiAgentOperation(query string) string {
 	operations := map[string]string{
 		"GetPipeUuid_tf": "GetPipeUuid", "CreateAiAgent_tf": "Create",
 		"UpdateAiAgentStatus_tf": "Status", "UpdateAiAgent_tf": "Update",
-		"GetAiAgent_tf": "Read", "DeleteAiAgent_tf": "Delete",
+		"GetAiAgent_tf": "Read", "DeleteAiAgent_tf": "Delete", "GetPipeRepoFields_tf": "References",
+		"GetTableRepoFields_tf": "TableReferences", "GetAiAgents_tf": "List",
 	}
 	for marker, operation := range operations {
 		if strings.Contains(query, marker) {
@@ -277,7 +318,7 @@ func aiAgentProvider(endpoint string) string {
 func aiAgentConfig(endpoint string, active string, secondBehavior bool) string {
 	behaviors := aiAgentBehaviorMove()
 	if secondBehavior {
-		behaviors += "," + aiAgentBehaviorUpdate()
+		behaviors += "\n" + aiAgentBehaviorUpdate()
 	}
 	activeLine := ""
 	if active != "" {
@@ -290,35 +331,35 @@ func aiAgentConfig(endpoint string, active string, secondBehavior bool) string {
 		instruction = "Classify cards"
 		data_source_ids = ["source-1"]
 		` + activeLine + `
-		behaviors = [` + behaviors + `]
+		behaviors = {` + behaviors + `}
 	}`
 }
 
 func aiAgentBehaviorMove() string {
-	return `{
-		name = "On create"
+	return `"On create" = {
 		event_id = "card_created"
 		instruction = "Choose a destination"
-		actions = [{
-			name = "Move"
-			action_type = "move_card"
-			destination_phase_id = "phase-2"
-		}]
+		actions = {
+			"Move" = {
+				action_type = "move_card"
+				destination_phase_id = "phase-2"
+			}
+		}
 	}`
 }
 
 func aiAgentBehaviorUpdate() string {
-	return `{
-		name = "On field update"
+	return `"Before create" = {
 		event_id = "field_updated"
 		instruction = "Rewrite title"
 		event_params = { trigger_field_ids = ["field-1"] }
-		actions = [{
-			name = "Update"
-			action_type = "update_card"
-			pipe_id = "42"
-			fields = [{ field_id = "title", input_mode = "fill_with_ai" }]
-		}]
+		actions = {
+			"Update" = {
+				action_type = "update_card"
+				pipe_id = "42"
+				fields = [{ field_id = "title", input_mode = "fill_with_ai" }]
+			}
+		}
 	}`
 }
 
@@ -378,16 +419,63 @@ func assertAiAgentCRUD(t *testing.T, mock *aiAgentMock) {
 	assertStableReferences(t, mock.referenceHistory)
 }
 
+// assertStableReferences checks that adding a behavior which sorts before the
+// existing one keeps the existing action reference and adds a new one.
 func assertStableReferences(t *testing.T, history [][]string) {
 	t.Helper()
 	if len(history) < 2 || len(history[0]) != 1 || len(history[1]) != 2 {
 		t.Fatalf("unexpected reference history: %#v", history)
 	}
-	if history[0][0] == "" || history[0][0] != history[1][0] || history[1][1] == "" {
+	if history[0][0] == "" || history[0][0] != history[1][1] || history[1][0] == "" {
 		t.Fatalf("action reference IDs were not stable: %#v", history)
 	}
 }
 
+func TestUnit_AiAgentResource_ImportByName(t *testing.T) {
+	mock := &aiAgentMock{otherAgents: []map[string]any{{"uuid": "other-uuid", "name": "Router/Escalation"}}}
+	server := newAiAgentServer(mock)
+	defer server.Close()
+	config := aiAgentConfig(server.URL, "true", false)
+	importedAs := func(id string) resource.ImportStateCheckFunc {
+		return func(states []*terraform.InstanceState) error {
+			if len(states) != 1 || states[0].ID != id || states[0].Attributes["pipe_id"] != "42" {
+				return fmt.Errorf("imported states = %v, want %s in pipe 42", states, id)
+			}
+			return nil
+		}
+	}
+	importStep := func(id string) resource.TestStep {
+		return resource.TestStep{
+			Config: config, ResourceName: "pipefy_ai_agent.test", ImportState: true, ImportStateId: id,
+		}
+	}
+	byName := importStep("42/name:Triage")
+	byName.ImportStateCheck = importedAs("agent-uuid")
+	// Verifying against the managed agent checks that its behaviors are
+	// imported too, not only its identity.
+	byName.ImportStateVerify = true
+	slashed := importStep("42/name:Router/Escalation")
+	slashed.ImportStateCheck = importedAs("other-uuid")
+	missing := importStep("42/name:Unknown")
+	missing.ExpectError = regexp.MustCompile(`no\s+AI\s+agent\s+named\s+"Unknown"`)
+	resource.UnitTest(t, aiAgentTestCase([]resource.TestStep{{Config: config}, byName, slashed, missing}))
+}
+
+func TestUnit_AiAgentResource_ImportByAmbiguousName(t *testing.T) {
+	mock := &aiAgentMock{otherAgents: []map[string]any{{"uuid": "copy-uuid", "name": "Triage"}}}
+	server := newAiAgentServer(mock)
+	defer server.Close()
+	config := aiAgentConfig(server.URL, "true", false)
+	resource.UnitTest(t, aiAgentTestCase([]resource.TestStep{
+		{Config: config},
+		{
+			Config: config, ResourceName: "pipefy_ai_agent.test", ImportState: true,
+			ImportStateId: "42/name:Triage",
+			ExpectError:   regexp.MustCompile(`2\s+AI\s+agents\s+named\s+"Triage"`),
+		},
+	}))
+}
+
 func TestUnit_AiAgentResource_OmittedActiveSkipsStatus(t *testing.T) {
 	mock := &aiAgentMock{}
 	server := newAiAgentServer(mock)
@@ -435,28 +523,36 @@ type aiAgentValidationCase struct {
 
 func aiAgentValidationCases() map[string]aiAgentValidationCase {
 	return map[string]aiAgentValidationCase{
-		"no behaviors": {behaviors: "[]", want: "at least 1"},
+		"no behaviors": {behaviors: "{}", want: "at least 1"},
 		"no actions": {
-			behaviors: `[{name="B",event_id="card_created",instruction="I",actions=[]}]`,
+			behaviors: `{B={event_id="card_created",instruction="I",actions={}}}`,
 			want:      "at least 1",
 		},
+		"empty behavior name": {
+			behaviors: `{""={event_id="card_created",instruction="I",actions={M={action_type="move_card",destination_phase_id="2"}}}}`,
+			want:      "(?s)length.*at least 1",
+		},
 		"unsupported action": {
-			behaviors: behaviorWithAction(`{name="Bad",action_type="send_email_template"}`),
-			want:      "send_email_template.*expected one of",
+			behaviors: behaviorWithAction(`Bad={action_type="archive_card"}`),
+			want:      "archive_card.*expected one of",
 		},
 		"move metadata": {
-			behaviors: behaviorWithAction(`{name="Move",action_type="move_card"}`),
+			behaviors: behaviorWithAction(`Move={action_type="move_card"}`),
 			want:      "requires destination_phase_id",
 		},
 		"update metadata": {
-			behaviors: behaviorWithAction(`{name="Update",action_type="update_card",pipe_id="42"}`),
+			behaviors: behaviorWithAction(`Update={action_type="update_card",pipe_id="42"}`),
 			want:      "(?s)requires pipe_id and at.*least one field",
 		},
+		"email template metadata": {
+			behaviors: behaviorWithAction(`Notify={action_type="send_email_template",email_template_id="7",pipe_id="42"}`),
+			want:      `does\s+not\s+accept\s+pipe_id`,
+		},
 	}
 }
 
 func behaviorWithAction(action string) string {
-	return `[{name="B",event_id="card_created",instruction="I",actions=[` + action + `]}]`
+	return `{B={event_id="card_created",instruction="I",actions={` + action + `}}}`
 }
 
 func aiAgentValidationConfig(behaviors string) string {
@@ -468,3 +564,153 @@ func aiAgentValidationConfig(behaviors string) string {
 		behaviors = ` + behaviors + `
 	}`
 }
+
+func TestUnit_AiAgentResource_PlanValidatesReferences(t *testing.T) {
+	mock := &aiAgentMock{pipeReferences: `{
+		"start_form_fields":[{"id":"title","internal_id":"100","type":"short_text"}],
+		"phases":[
+			{"id":"phase-1","fields":[{"id":"summary","internal_id":"101","type":"long_text"},
+				{"id":"customer","internal_id":"104","type":"connector"}]},
+			{"id":"phase-2","fields":[{"id":"doc","internal_id":"102","type":"attachment"},
+				{"id":"note","internal_id":"103","type":"statement"}]}
+		]}`, tableReferences: `{"table_fields":[{"id":"status","internal_id":"200","type":"select"}]}`}
+	server := newAiAgentServer(mock)
+	defer server.Close()
+	config := func(behavior string) string {
+		return aiAgentProvider(server.URL) + `
+		resource "pipefy_ai_agent" "test" {
+			pipe_id = "42"
+			name = "Triage"
+			instruction = "Classify cards"
+			behaviors = {` + behavior + `}
+		}`
+	}
+	cases := map[string]struct {
+		behavior string
+		want     string
+	}{
+		"destination phase": {
+			behavior: `B={event_id="card_created",instruction="I",actions={M={action_type="move_card",destination_phase_id="phase-9"}}}`,
+			want:     `phase-9\s+does\s+not\s+belong`,
+		},
+		"trigger fields": {
+			behavior: `B={event_id="field_updated",instruction="I",event_params={trigger_field_ids=["101","999"]},
+				actions={M={action_type="move_card",destination_phase_id="phase-2"}}}`,
+			want: `IDs\s+999\s+do\s+not\s+exist`,
+		},
+		"missing target field": {
+			behavior: `B={event_id="card_created",instruction="I",actions={U={action_type="update_card",pipe_id="42",
+				fields=[{field_id="998",input_mode="fill_with_ai"}]}}}`,
+			want: `field\s+998\s+does\s+not\s+exist`,
+		},
+		"read-only target field": {
+			behavior: `B={event_id="card_created",instruction="I",actions={U={action_type="update_card",pipe_id="42",
+				fields=[{field_id="103",input_mode="fixed_value",value="x"}]}}}`,
+			want: `statement\s+field,\s+which\s+actions\s+cannot\s+write`,
+		},
+		"attachment filled with AI": {
+			behavior: `B={event_id="card_created",instruction="I",actions={U={action_type="update_card",pipe_id="42",
+				fields=[{field_id="102",input_mode="fill_with_ai"}]}}}`,
+			want: `cannot\s+be\s+filled\s+with\s+AI`,
+		},
+		"copy source": {
+			behavior: `B={event_id="card_created",instruction="I",actions={U={action_type="update_card",pipe_id="42",
+				fields=[{field_id="100",input_mode="copy_from",value="997"}]}}}`,
+			want: `source\s+field\s+997\s+does\s+not`,
+		},
+		"missing table field": {
+			behavior: `B={event_id="card_created",instruction="I",actions={R={action_type="update_table_record",
+				table_id="t1",connector_field_id="customer",fields=[{field_id="999",input_mode="fill_with_ai"}]}}}`,
+			want: `field\s+999\s+does\s+not\s+exist\s+in\s+table\s+t1`,
+		},
+		"record connector field": {
+			behavior: `B={event_id="card_created",instruction="I",actions={R={action_type="update_table_record",
+				table_id="t1",connector_field_id="summary",fields=[{field_id="status",input_mode="fill_with_ai"}]}}}`,
+			want: `long_text\s+field,\s+not\s+a\s+connector`,
+		},
+		"value for fill_with_ai": {
+			behavior: `B={event_id="card_created",instruction="I",actions={U={action_type="update_card",pipe_id="42",
+				fields=[{field_id="100",input_mode="fill_with_ai",value="x"}]}}}`,
+			want: `"fill_with_ai"\s+does\s+not\s+take\s+a\s+value`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, aiAgentTestCase([]resource.TestStep{{
+				Config: config(tc.behavior), PlanOnly: true, ExpectError: regexp.MustCompile(tc.want),
+			}}))
+		})
+	}
+	resource.UnitTest(t, aiAgentTestCase([]resource.TestStep{{
+		Config: config(`B={event_id="card_moved",instruction="I",event_params={to_phase_id="phase-2",trigger_field_ids=["101"]},
+			actions={U={action_type="update_card",pipe_id="42",fields=[{field_id="100",input_mode="copy_from",value="summary"}]},
+				R={action_type="update_table_record",table_id="t1",connector_field_id="customer",
+					fields=[{field_id="status",input_mode="fill_with_ai"}]}}}`),
+	}}))
+}
+
+func TestUnit_AiAgentResource_InstructionTemplates(t *testing.T) {
+	mock := &aiAgentMock{}
+	server := newAiAgentServer(mock)
+	defer server.Close()
+	file := filepath.Join(t.TempDir(), "route.txt")
+	write := func(content string) {
+		if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	template := "Route the card.\nWhen it is ready, {{action:Move}}."
+	write(template)
+	config := func(instruction string) string {
+		return aiAgentProvider(server.URL) + `
+		resource "pipefy_ai_agent" "test" {
+			pipe_id = "42"
+			name = "Triage"
+			instruction = "Classify cards"
+			behaviors = {
+				"Route" = {
+					event_id = "card_created"
+					` + instruction + `
+					actions = {
+						"Move" = { action_type = "move_card", destination_phase_id = "phase-2" }
+						"Notify" = { action_type = "send_email_template", email_template_id = "7" }
+					}
+				}
+			}
+		}`
+	}
+	fromFile := config(`instruction_file = "` + filepath.ToSlash(file) + `"`)
+	sum := sha256.Sum256([]byte(template))
+	resource.UnitTest(t, aiAgentTestCase([]resource.TestStep{
+		{
+			Config: fromFile,
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("pipefy_ai_agent.test",
+					tfjsonpath.New("behaviors").AtMapKey("Route").AtMapKey("instruction_hash"),
+					knownvalue.StringExact(hex.EncodeToString(sum[:]))),
+			},
+			Check: func(*terraform.State) error {
+				behavior, _ := mock.behaviors[0].(map[string]any)
+				instruction, _ := aiBehaviorParams(behavior)["instruction"].(string)
+				references := actionReferences(mock.behaviors)
+				want := "Route the card.\nWhen it is ready, %{action:" + references[0] + "}.\n%{action:" + references[1] + "}"
+				if instruction != want {
+					return fmt.Errorf("instruction = %q, want %q", instruction, want)
+				}
+				return nil
+			},
+		},
+		{Config: fromFile, PlanOnly: true},
+		{
+			PreConfig:          func() { write(template + "\nBe brief.") },
+			Config:             fromFile,
+			PlanOnly:           true,
+			ExpectNonEmptyPlan: true,
+		},
+		{
+			Config:      config(`instruction = "Use {{action:Mvoe}}"`),
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile(`(?s)Unknown\s+AI\s+agent\s+action\s+reference.*"Mvoe"`),
+		},
+	}))
+}
@@ -0,0 +1,221 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+type aiDataSourceMock struct {
+	mu      sync.Mutex
+	url     string
+	nextID  int
+	sources map[string]map[string]any
+	uploads []string
+	deleted []string
+}
+
+func newAiDataSourceServer(mock *aiDataSourceMock) *httptest.Server {
+	mock.sources = map[string]map[string]any{}
+	server := httptest.NewServer(http.HandlerFunc(mock.serveHTTP))
+	mock.url = server.URL
+	return server
+}
+
+func (mock *aiDataSourceMock) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	defer r.Body.Close()
+	body, _ := io.ReadAll(r.Body)
+	if r.Method == http.MethodPut {
+		mock.uploads = append(mock.uploads, r.URL.Path+":"+string(body))
+		return
+	}
+	var request gqlReq
+	_ = json.Unmarshal(body, &request)
+	input, _ := request.Variables["input"].(map[string]any)
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = io.WriteString(w, mock.handle(request.Query, request.Variables, input))
+}
+
+func (mock *aiDataSourceMock) handle(query string, variables, input map[string]any) string {
+	switch {
+	case strings.Contains(query, "CreatePresignedUrl_tf"):
+		return fmt.Sprintf(`{"data":{"createPresignedUrl":{"url":"%s/upload/%s","downloadUrl":"https://files.example/%s"}}}`,
+			mock.url, input["fileName"], input["fileName"])
+	case strings.Contains(query, "CreateAiDataSource_tf"):
+		mock.nextID++
+		id := fmt.Sprintf("source-%d", mock.nextID)
+		mock.sources[id] = input
+		return `{"data":{"createAiDataSource":{"dataSource":{"id":"` + id + `"}}}}`
+	case strings.Contains(query, "UpdateAiDataSource_tf"):
+		mock.sources[input["id"].(string)]["name"] = input["name"]
+		return `{"data":{"updateAiDataSource":{"dataSource":{"id":"` + input["id"].(string) + `"}}}}`
+	case strings.Contains(query, "GetAiDataSource_tf"):
+		source, ok := mock.sources[variables["id"].(string)]
+		if !ok {
+			return `{"data":{"aiDataSource":null}}`
+		}
+		return fmt.Sprintf(`{"data":{"aiDataSource":{"id":%q,"name":%q,"sourceType":%q}}}`,
+			variables["id"], source["name"], source["sourceType"])
+	case strings.Contains(query, "DeleteAiDataSource_tf"):
+		delete(mock.sources, input["id"].(string))
+		mock.deleted = append(mock.deleted, input["id"].(string))
+		return `{"data":{"deleteAiDataSource":{"success":true}}}`
+	default:
+		return `{"data":{}}`
+	}
+}
+
+func aiDataSourceConfig(endpoint, name, source string) string {
+	return aiAgentProvider(endpoint) + `
+	resource "pipefy_ai_data_source" "test" {
+		organization_id = "300"
+		name = "` + name + `"
+		` + source + `
+	}`
+}
+
+func TestUnit_AiDataSourceResource_File(t *testing.T) {
+	mock := &aiDataSourceMock{}
+	server := newAiDataSourceServer(mock)
+	defer server.Close()
+	file := filepath.Join(t.TempDir(), "handbook.md")
+	write := func(content string) func() {
+		return func() {
+			if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	write("# Handbook v1")()
+	source := `file = "` + filepath.ToSlash(file) + `"`
+	idIs := func(id string) statecheck.StateCheck {
+		return statecheck.ExpectKnownValue("pipefy_ai_data_source.test", tfjsonpath.New("id"), knownvalue.StringExact(id))
+	}
+	resource.UnitTest(t, aiAgentTestCase([]resource.TestStep{
+		{
+			Config: aiDataSourceConfig(server.URL, "Handbook", source),
+			ConfigStateChecks: []statecheck.StateCheck{
+				idIs("source-1"),
+				statecheck.ExpectKnownValue("pipefy_ai_data_source.test", tfjsonpath.New("content_hash"),
+					knownvalue.StringRegexp(regexp.MustCompile(`^[0-9a-f]{64}$`))),
+			},
+			Check: func(*terraform.State) error {
+				if len(mock.uploads) != 1 || mock.uploads[0] != "/upload/handbook.md:# Handbook v1" {
+					return fmt.Errorf("uploads = %v", mock.uploads)
+				}
+				if got := mock.sources["source-1"]["fileUrl"]; got != "https://files.example/handbook.md" {
+					return fmt.Errorf("fileUrl = %v", got)
+				}
+				return nil
+			},
+		},
+		{
+			Config:            aiDataSourceConfig(server.URL, "Team handbook", source),
+			ConfigStateChecks: []statecheck.StateCheck{idIs("source-1")},
+		},
+		{
+			PreConfig:         write("# Handbook v2"),
+			Config:            aiDataSourceConfig(server.URL, "Team handbook", source),
+			ConfigStateChecks: []statecheck.StateCheck{idIs("source-2")},
+			Check: func(*terraform.State) error {
+				if len(mock.deleted) != 1 || mock.deleted[0] != "source-1" {
+					return fmt.Errorf("deleted = %v, want the replaced source-1", mock.deleted)
+				}
+				return nil
+			},
+		},
+		{
+			Config:            aiDataSourceConfig(server.URL, "Team handbook", source+"\ncontent_type = \"text/plain\""),
+			ConfigStateChecks: []statecheck.StateCheck{idIs("source-3")},
+			Check: func(*terraform.State) error {
+				if got := mock.sources["source-3"]["contentType"]; got != "text/plain" {
+					return fmt.Errorf("contentType = %v", got)
+				}
+				return nil
+			},
+		},
+		{
+			ResourceName: "pipefy_ai_data_source.test", ImportState: true,
+			ImportStateId: "300/source-3", ImportStateVerify: true,
+			ImportStateVerifyIgnore: []string{"file", "content_hash"},
+		},
+	}))
+}
+
+func TestUnit_AiDataSourceResource_TextAndURL(t *testing.T) {
+	mock := &aiDataSourceMock{}
+	server := newAiDataSourceServer(mock)
+	defer server.Close()
+	resource.UnitTest(t, aiAgentTestCase([]resource.TestStep{
+		{
+			Config: aiDataSourceConfig(server.URL, "FAQ", `text = "Refunds take five days."`),
+			Check: func(*terraform.State) error {
+				if got := mock.sources["source-1"]["text"]; got != "Refunds take five days." {
+					return fmt.Errorf("text = %v", got)
+				}
+				return nil
+			},
+		},
+		{
+			Config: aiDataSourceConfig(server.URL, "FAQ", `url = "https://help.example/faq"`),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("pipefy_ai_data_source.test", tfjsonpath.New("id"),
+					knownvalue.StringExact("source-2")),
+			},
+			Check: func(*terraform.State) error {
+				if got := mock.sources["source-2"]["url"]; got != "https://help.example/faq" || len(mock.uploads) != 0 {
+					return fmt.Errorf("url = %v, uploads = %v", got, mock.uploads)
+				}
+				return nil
+			},
+		},
+		{
+			// The same value as text instead of a URL is a new data source.
+			Config: aiDataSourceConfig(server.URL, "FAQ", `text = "https://help.example/faq"`),
+			ConfigStateChecks: []statecheck.StateCheck{
+				statecheck.ExpectKnownValue("pipefy_ai_data_source.test", tfjsonpath.New("id"),
+					knownvalue.StringExact("source-3")),
+			},
+		},
+	}))
+}
+
+func TestUnit_AiDataSourceResource_Validation(t *testing.T) {
+	cases := map[string]struct {
+		source string
+		want   string
+	}{
+		"no source":     {source: ``, want: `(?s)Exactly\s+one\s+of`},
+		"two sources":   {source: `text = "a"` + "\n" + `url = "https://help.example"`, want: `(?s)Exactly\s+one\s+of`},
+		"missing file":  {source: `file = "does-not-exist.pdf"`, want: `Unreadable\s+AI\s+data\s+source\s+file`},
+		"type for text": {source: `text = "a"` + "\n" + `content_type = "text/plain"`, want: `(?s)file.*must\s+be\s+specified`},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, aiAgentTestCase([]resource.TestStep{{
+				Config:      aiDataSourceConfig("http://127.0.0.1:1", "Source", tc.source),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(tc.want),
+			}}))
+		})
+	}
+}
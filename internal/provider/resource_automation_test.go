@@ -5,6 +5,7 @@ package provider_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"github.com/hashicorp/terraform-plugin-testing/tfversion"
@@ -21,11 +23,70 @@ import (
 
 type automationState struct {
 	ID                  string
+	Name                string
+	EventId             string
+	ActionId            string
+	Active              bool
+	EventParams         any
+	ActionParams        any
+	Condition           any
 	CreatedEventParams  any
 	CreatedActionParams any
 	UpdatedEventParams  any
 	UpdatedActionParams any
 	DeletedCt           int
+	// IgnoreNullClears makes updates keep a structure sent as null, as an
+	// API that ignores the clear would.
+	IgnoreNullClears bool
+}
+
+// merge applies a create or update input the way the API does, keeping the
+// values that are not sent.
+func (st *automationState) merge(in map[string]any) {
+	if v, ok := in["name"].(string); ok {
+		st.Name = v
+	}
+	if v, ok := in["event_id"].(string); ok {
+		st.EventId = v
+	}
+	if v, ok := in["action_id"].(string); ok {
+		st.ActionId = v
+	}
+	if v, ok := in["active"].(bool); ok {
+		st.Active = v
+	}
+	if v, ok := in["event_params"]; ok {
+		st.EventParams = v
+	}
+	if v, ok := in["action_params"]; ok {
+		st.ActionParams = v
+	}
+	if v, ok := in["condition"]; ok && (v != nil || !st.IgnoreNullClears) {
+		st.Condition = v
+	}
+}
+
+func (st *automationState) toJSON() string {
+	eventID, actionID := st.EventId, st.ActionId
+	if eventID == "" {
+		eventID = "field_updated"
+	}
+	if actionID == "" {
+		actionID = "generate_with_ai"
+	}
+	b, _ := json.Marshal(map[string]any{
+		"id":             st.ID,
+		"name":           st.Name,
+		"action_id":      actionID,
+		"event_id":       eventID,
+		"active":         st.Active,
+		"event_repo":     map[string]any{"id": "306729113"},
+		"action_repo_v2": map[string]any{"id": "306729113"},
+		"event_params":   st.EventParams,
+		"action_params":  st.ActionParams,
+		"condition":      st.Condition,
+	})
+	return string(b)
 }
 
 func TestUnit_AutomationResource_CRUD(t *testing.T) {
@@ -52,9 +113,10 @@ func TestUnit_AutomationResource_CRUD(t *testing.T) {
 				if v, ok2 := in["action_params"]; ok2 {
 					st.CreatedActionParams = v
 				}
+				st.merge(in)
 			}
 			st.ID = "auto_1"
-			_, _ = io.WriteString(w, `{"data":{"createAutomation":{"automation":{"id":"`+st.ID+`","name":"When Summary changes, generate AI output","action_id":"generate_with_ai","event_id":"field_updated","active":true}}}}`)
+			_, _ = io.WriteString(w, `{"data":{"createAutomation":{"automation":`+st.toJSON()+`}}}`)
 		case strings.Contains(q, "updateAutomation"):
 			if in, ok := gr.Variables["input"].(map[string]any); ok {
 				if v, ok2 := in["event_params"]; ok2 {
@@ -63,13 +125,14 @@ func TestUnit_AutomationResource_CRUD(t *testing.T) {
 				if v, ok2 := in["action_params"]; ok2 {
 					st.UpdatedActionParams = v
 				}
+				st.merge(in)
 			}
-			_, _ = io.WriteString(w, `{"data":{"updateAutomation":{"automation":{"id":"`+st.ID+`"}}}}`)
+			_, _ = io.WriteString(w, `{"data":{"updateAutomation":{"automation":`+st.toJSON()+`}}}`)
 		case strings.Contains(q, "deleteAutomation"):
 			st.DeletedCt++
 			_, _ = io.WriteString(w, `{"data":{"deleteAutomation":{"success":true}}}`)
 		case strings.Contains(q, "automation("):
-			_, _ = io.WriteString(w, `{"data":{"automation":{"id":"`+st.ID+`","name":"When Summary changes, generate AI output","action_id":"generate_with_ai","event_id":"field_updated","active":true,"event_repo":{"id":"repo"},"action_repo_v2":{"id":"repo"}}}}`)
+			_, _ = io.WriteString(w, `{"data":{"automation":`+st.toJSON()+`}}`)
 		default:
 			_, _ = io.WriteString(w, `{"data":{}}`)
 		}
@@ -401,7 +464,7 @@ func TestUnit_AutomationResource_CreatePersistsStateWhenAutomationReturnedWithEr
 		case strings.Contains(q, "createAutomation"):
 			_, _ = io.WriteString(w, `{"data":{"createAutomation":{"automation":{"id":"auto_1","name":"Auto","action_id":"generate_with_ai","event_id":"field_updated","active":true},"error_details":[{"object_name":"field_map","object_key":"420173432","messages":["can't be blank"]}]}}}`)
 		case strings.Contains(q, "automation("):
-			_, _ = io.WriteString(w, `{"data":{"automation":{"id":"auto_1","name":"Auto","action_id":"generate_with_ai","event_id":"field_updated","active":true,"event_repo":{"id":"repo"},"action_repo_v2":{"id":"repo"}}}}`)
+			_, _ = io.WriteString(w, `{"data":{"automation":{"id":"auto_1","name":"Auto","action_id":"generate_with_ai","event_id":"field_updated","active":true,"event_repo":{"id":"306729113"},"action_repo_v2":{"id":"306729113"}}}}`)
 		case strings.Contains(q, "deleteAutomation"):
 			_, _ = io.WriteString(w, `{"data":{"deleteAutomation":{"success":true}}}`)
 		default:
@@ -504,7 +567,7 @@ func TestUnit_AutomationResource_UpdateSurfacesTopLevelErrors(t *testing.T) {
 		case strings.Contains(q, "deleteAutomation"):
 			_, _ = io.WriteString(w, `{"data":{"deleteAutomation":{"success":true}}}`)
 		case strings.Contains(q, "automation("):
-			_, _ = io.WriteString(w, `{"data":{"automation":{"id":"auto_1","name":"Auto","action_id":"generate_with_ai","event_id":"field_updated","active":true,"event_repo":{"id":"repo"},"action_repo_v2":{"id":"repo"}}}}`)
+			_, _ = io.WriteString(w, `{"data":{"automation":{"id":"auto_1","name":"Auto","action_id":"generate_with_ai","event_id":"field_updated","active":true,"event_repo":{"id":"306729113"},"action_repo_v2":{"id":"306729113"}}}}`)
 		default:
 			_, _ = io.WriteString(w, `{"data":{}}`)
 		}
@@ -568,7 +631,7 @@ func TestUnit_AutomationResource_UpdateSurfacesErrorDetails(t *testing.T) {
 		case strings.Contains(q, "deleteAutomation"):
 			_, _ = io.WriteString(w, `{"data":{"deleteAutomation":{"success":true}}}`)
 		case strings.Contains(q, "automation("):
-			_, _ = io.WriteString(w, `{"data":{"automation":{"id":"auto_1","name":"Auto","action_id":"generate_with_ai","event_id":"field_updated","active":true,"event_repo":{"id":"repo"},"action_repo_v2":{"id":"repo"}}}}`)
+			_, _ = io.WriteString(w, `{"data":{"automation":{"id":"auto_1","name":"Auto","action_id":"generate_with_ai","event_id":"field_updated","active":true,"event_repo":{"id":"306729113"},"action_repo_v2":{"id":"306729113"}}}}`)
 		default:
 			_, _ = io.WriteString(w, `{"data":{}}`)
 		}
@@ -610,3 +673,713 @@ func TestUnit_AutomationResource_UpdateSurfacesErrorDetails(t *testing.T) {
 		},
 	})
 }
+
+func TestUnit_AutomationResource_ReadRefreshesFromAPI(t *testing.T) {
+	st := &automationState{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var gr gqlReq
+		defer r.Body.Close()
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &gr)
+		w.Header().Set("Content-Type", "application/json")
+
+		in, _ := gr.Variables["input"].(map[string]any)
+		switch q := gr.Query; {
+		case strings.Contains(q, "createAutomation"):
+			st.ID = "auto_1"
+			st.merge(in)
+			_, _ = io.WriteString(w, `{"data":{"createAutomation":{"automation":`+st.toJSON()+`}}}`)
+		case strings.Contains(q, "updateAutomation"):
+			st.merge(in)
+			_, _ = io.WriteString(w, `{"data":{"updateAutomation":{"automation":`+st.toJSON()+`}}}`)
+		case strings.Contains(q, "deleteAutomation"):
+			_, _ = io.WriteString(w, `{"data":{"deleteAutomation":{"success":true}}}`)
+		case strings.Contains(q, "automation("):
+			_, _ = io.WriteString(w, `{"data":{"automation":`+st.toJSON()+`}}`)
+		default:
+			_, _ = io.WriteString(w, `{"data":{}}`)
+		}
+	}))
+	defer srv.Close()
+
+	config := `
+	provider "pipefy" {
+		endpoint = "` + srv.URL + `"
+		token    = "testtoken"
+	}
+
+	resource "pipefy_automation" "test" {
+		name           = "Auto"
+		event_id       = "field_updated"
+		action_id      = "generate_with_ai"
+		event_repo_id  = "306729113"
+		action_repo_id = "306729113"
+		active         = true
+
+		event_params = jsonencode({
+			triggerFieldIds = ["420173505"]
+		})
+
+		action_params = jsonencode({
+			aiParams = {
+				value    = "Summarize %%{420173505}"
+				fieldIds = ["420173432"]
+			}
+		})
+
+		condition = jsonencode({
+			expressions = [{
+				structure_id  = 0
+				field_address = "420173505"
+				operation     = "present"
+				value         = ""
+			}]
+			expressions_structure = [[0]]
+		})
+	}
+	`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				// Edits made in the UI are detected and reverted.
+				PreConfig: func() {
+					st.Name = "Renamed in UI"
+					st.Active = false
+					st.ActionParams = json.RawMessage(`{"aiParams":{"value":"Changed in UI","fieldIds":["420173432"]}}`)
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pipefy_automation.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("pipefy_automation.test", tfjsonpath.New("name"), knownvalue.StringExact("Auto")),
+					statecheck.ExpectKnownValue("pipefy_automation.test", tfjsonpath.New("active"), knownvalue.Bool(true)),
+				},
+			},
+			{
+				// Reordered keys, formatting and null members the API returns
+				// for unset keys are not a diff.
+				PreConfig: func() {
+					st.EventParams = json.RawMessage(`{ "triggerAutomationId": null, "to_phase_id": null, "triggerFieldIds": [ "420173505" ] }`)
+					st.Condition = json.RawMessage(`{"expressions_structure":[[0]],"expressions":[{"value":"","operation":"present","field_address":"420173505","structure_id":0}]}`)
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				ResourceName:      "pipefy_automation.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnit_AutomationResource_TypedBlocks(t *testing.T) {
+	st := &automationState{}
+	var created map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var gr gqlReq
+		defer r.Body.Close()
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &gr)
+		w.Header().Set("Content-Type", "application/json")
+
+		in, _ := gr.Variables["input"].(map[string]any)
+		switch q := gr.Query; {
+		case strings.Contains(q, "createAutomation"):
+			created = in
+			st.ID = "auto_1"
+			st.merge(in)
+			_, _ = io.WriteString(w, `{"data":{"createAutomation":{"automation":`+st.toJSON()+`}}}`)
+		case strings.Contains(q, "updateAutomation"):
+			st.merge(in)
+			_, _ = io.WriteString(w, `{"data":{"updateAutomation":{"automation":`+st.toJSON()+`}}}`)
+		case strings.Contains(q, "deleteAutomation"):
+			_, _ = io.WriteString(w, `{"data":{"deleteAutomation":{"success":true}}}`)
+		case strings.Contains(q, "automation("):
+			_, _ = io.WriteString(w, `{"data":{"automation":`+st.toJSON()+`}}`)
+		default:
+			_, _ = io.WriteString(w, `{"data":{}}`)
+		}
+	}))
+	defer srv.Close()
+
+	config := `
+	provider "pipefy" {
+		endpoint = "` + srv.URL + `"
+		token    = "testtoken"
+	}
+
+	resource "pipefy_automation" "test" {
+		name           = "Close moved cards"
+		event_id       = "card_moved"
+		action_id      = "update_card_field"
+		event_repo_id  = "306729113"
+		action_repo_id = "306729113"
+
+		event = {
+			card_moved = { to_phase_id = "338000001" }
+		}
+
+		action = {
+			update_field = {
+				fields = [{ field_id = "420173505", input_mode = "fixed_value", value = "Done" }]
+			}
+		}
+
+		condition_expression = {
+			match = "any"
+			expressions = [
+				{ field_id = "420173505", operation = "present" },
+				{ field_id = "420173432", operation = "equals", value = "yes" },
+			]
+		}
+	}
+	`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("pipefy_automation.test", tfjsonpath.New("event_params"), knownvalue.Null()),
+					statecheck.ExpectKnownValue("pipefy_automation.test", tfjsonpath.New("condition_expression").AtMapKey("match"), knownvalue.StringExact("any")),
+				},
+			},
+			{
+				// A target phase changed in the UI shows up in the typed block.
+				PreConfig: func() {
+					st.EventParams = json.RawMessage(`{"to_phase_id":"338000002","triggerFieldIds":null}`)
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pipefy_automation.test", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			{
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+
+	got, _ := json.Marshal(map[string]any{
+		"event_params":  created["event_params"],
+		"action_params": created["action_params"],
+		"condition":     created["condition"],
+	})
+	want := `{"action_params":{"field_map":[{"fieldId":"420173505","inputMode":"fixed_value","value":"Done"}]},` +
+		`"condition":{"expressions":[{"field_address":"420173505","operation":"present","structure_id":0,"value":""},` +
+		`{"field_address":"420173432","operation":"equals","structure_id":1,"value":"yes"}],"expressions_structure":[[0],[1]]},` +
+		`"event_params":{"to_phase_id":"338000001"}}`
+	if string(got) != want {
+		t.Fatalf("create input = %s, want %s", got, want)
+	}
+}
+
+func TestUnit_AutomationResource_TypedBlocksValidation(t *testing.T) {
+	base := `
+	provider "pipefy" {
+		endpoint = "http://127.0.0.1:1"
+		token    = "testtoken"
+	}
+
+	resource "pipefy_automation" "test" {
+		name           = "Auto"
+		event_id       = "card_created"
+		action_id      = "move_single_card"
+		event_repo_id  = "306729113"
+		action_repo_id = "306729113"
+		%s
+	}
+	`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(base, `event = { card_moved = { to_phase_id = "1" } }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`event_id "card_moved", but event_id is "card_created"`),
+			},
+			{
+				Config:      fmt.Sprintf(base, `action = {}`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`exactly one nested attribute must be set, got 0`),
+			},
+			{
+				Config: fmt.Sprintf(base, `
+		event        = { card_created = {} }
+		event_params = jsonencode({ to_phase_id = "1" })`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestUnit_AutomationResource_PlanValidatesAgainstCatalog(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var gr gqlReq
+		defer r.Body.Close()
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &gr)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch q := gr.Query; {
+		case strings.Contains(q, "automationEvents("):
+			_, _ = io.WriteString(w, `{"data":{"automationEvents":[`+
+				`{"id":"field_updated","name":"Field updated","acceptedParameters":["triggerFieldIds"],"actionsBlacklist":["move_single_card"]},`+
+				`{"id":"card_created","name":"Card created","acceptedParameters":null}]}}`)
+		case strings.Contains(q, "automationActions("):
+			_, _ = io.WriteString(w, `{"data":{"automationActions":[`+
+				`{"id":"generate_with_ai","name":"Generate with AI","acceptedParameters":["aiParams"]},`+
+				`{"id":"move_single_card","name":"Move card","acceptedParameters":["to_phase_id"]}]}}`)
+		case strings.Contains(q, "pipe(id:"):
+			_, _ = io.WriteString(w, `{"data":{"pipe":{"start_form_fields":[{"id":"summary","internal_id":"420173505"}],`+
+				`"phases":[{"fields":[{"id":"output","internal_id":"420173432"}]}]}}}`)
+		default:
+			_, _ = io.WriteString(w, `{"data":{}}`)
+		}
+	}))
+	defer srv.Close()
+
+	config := func(eventID, actionID, params string) string {
+		return `
+	provider "pipefy" {
+		endpoint = "` + srv.URL + `"
+		token    = "testtoken"
+	}
+
+	resource "pipefy_automation" "test" {
+		name           = "Auto"
+		event_id       = "` + eventID + `"
+		action_id      = "` + actionID + `"
+		event_repo_id  = "306729113"
+		action_repo_id = "306729113"
+		` + params + `
+	}
+	`
+	}
+	aiParams := `action_params = jsonencode({ aiParams = { value = "x", fieldIds = ["420173432"] } })`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config("card_moved", "generate_with_ai", aiParams),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`event "card_moved" is not available for repo 306729113`),
+			},
+			{
+				Config:      config("field_updated", "move_single_card", ""),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`action "move_single_card" cannot be combined with event "field_updated"`),
+			},
+			{
+				Config:      config("field_updated", "generate_with_ai", aiParams+"\n\t\tevent_params = jsonencode({ triggerFieldId = [420173505] })"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`event "field_updated" does not accept triggerFieldId`),
+			},
+			{
+				Config: config("field_updated", "generate_with_ai", `
+		action_params = jsonencode({ aiParams = { value = "x", fieldIds = ["999"] } })
+		event_params  = jsonencode({ triggerFieldIds = [420173505] })`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`field IDs 999 do not exist in repo 306729113`),
+			},
+			{
+				Config: config("card_created", "generate_with_ai", aiParams+`
+		condition_expression = {
+			expressions = [{ field_id = "summary", operation = "present" }]
+		}`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestUnit_AutomationResource_UpdateClearsRemovedParams(t *testing.T) {
+	st := &automationState{}
+	var updates []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var gr gqlReq
+		defer r.Body.Close()
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &gr)
+		w.Header().Set("Content-Type", "application/json")
+
+		in, _ := gr.Variables["input"].(map[string]any)
+		switch q := gr.Query; {
+		case strings.Contains(q, "createAutomation"):
+			st.ID = "auto_1"
+			st.merge(in)
+			_, _ = io.WriteString(w, `{"data":{"createAutomation":{"automation":`+st.toJSON()+`}}}`)
+		case strings.Contains(q, "updateAutomation"):
+			updates = append(updates, in)
+			st.merge(in)
+			_, _ = io.WriteString(w, `{"data":{"updateAutomation":{"automation":`+st.toJSON()+`}}}`)
+		case strings.Contains(q, "deleteAutomation"):
+			_, _ = io.WriteString(w, `{"data":{"deleteAutomation":{"success":true}}}`)
+		case strings.Contains(q, "automation("):
+			_, _ = io.WriteString(w, `{"data":{"automation":`+st.toJSON()+`}}`)
+		default:
+			_, _ = io.WriteString(w, `{"data":{}}`)
+		}
+	}))
+	defer srv.Close()
+
+	base := `
+	provider "pipefy" {
+		endpoint = "` + srv.URL + `"
+		token    = "testtoken"
+	}
+
+	resource "pipefy_automation" "test" {
+		name           = "Auto"
+		event_id       = "field_updated"
+		action_id      = "generate_with_ai"
+		event_repo_id  = "306729113"
+		action_repo_id = "306729113"
+		active         = true
+		%s
+	}
+	`
+	full := fmt.Sprintf(base, `
+		event_params = jsonencode({
+			triggerFieldIds = ["420173505"]
+			to_phase_id     = "338000001"
+		})
+
+		condition = jsonencode({
+			expressions           = [{ structure_id = 0, field_address = "420173505", operation = "present", value = "" }]
+			expressions_structure = [[0]]
+		})`)
+	trimmed := fmt.Sprintf(base, `
+		event_params = jsonencode({
+			triggerFieldIds = ["420173505"]
+		})`)
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: full,
+			},
+			{
+				Config: trimmed,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("pipefy_automation.test", tfjsonpath.New("condition"), knownvalue.Null()),
+					statecheck.ExpectKnownValue("pipefy_automation.test", tfjsonpath.New("event_params"), knownvalue.StringExact(`{"triggerFieldIds":["420173505"]}`)),
+				},
+			},
+			{
+				Config: trimmed,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+
+	if len(updates) != 1 {
+		t.Fatalf("expected one update, got %d", len(updates))
+	}
+	got, _ := json.Marshal(map[string]any{
+		"event_params": updates[0]["event_params"],
+		"condition":    updates[0]["condition"],
+	})
+	want := `{"condition":null,"event_params":{"to_phase_id":null,"triggerFieldIds":["420173505"]}}`
+	if string(got) != want {
+		t.Fatalf("update input = %s, want %s", got, want)
+	}
+	if _, ok := updates[0]["condition"]; !ok {
+		t.Fatal("expected the removed condition to be sent as null")
+	}
+	if _, ok := updates[0]["action_params"]; ok {
+		t.Fatalf("action_params was never set and should not be sent, got %v", updates[0]["action_params"])
+	}
+}
+
+func TestUnit_AutomationResource_Scheduled(t *testing.T) {
+	st := &automationState{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var gr gqlReq
+		defer r.Body.Close()
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &gr)
+		w.Header().Set("Content-Type", "application/json")
+
+		in, _ := gr.Variables["input"].(map[string]any)
+		switch q := gr.Query; {
+		case strings.Contains(q, "createAutomation"):
+			st.ID = "auto_1"
+			st.merge(in)
+			_, _ = io.WriteString(w, `{"data":{"createAutomation":{"automation":`+st.toJSON()+`}}}`)
+		case strings.Contains(q, "updateAutomation"):
+			st.merge(in)
+			_, _ = io.WriteString(w, `{"data":{"updateAutomation":{"automation":`+st.toJSON()+`}}}`)
+		case strings.Contains(q, "deleteAutomation"):
+			_, _ = io.WriteString(w, `{"data":{"deleteAutomation":{"success":true}}}`)
+		case strings.Contains(q, "automation("):
+			_, _ = io.WriteString(w, `{"data":{"automation":`+st.toJSON()+`}}`)
+		default:
+			_, _ = io.WriteString(w, `{"data":{}}`)
+		}
+	}))
+	defer srv.Close()
+
+	base := `
+	provider "pipefy" {
+		endpoint = "` + srv.URL + `"
+		token    = "testtoken"
+	}
+
+	resource "pipefy_automation" "test" {
+		name           = "Weekly review"
+		event_id       = "scheduler"
+		action_id      = "send_email_template"
+		event_repo_id  = "306729113"
+		action_repo_id = "306729113"
+
+		event = {
+			scheduled = {
+				%s
+				timezone   = "America/Sao_Paulo"
+				start_date = "2025-01-31"
+			}
+		}
+
+		action = {
+			send_email_template = { email_template_id = "310000001" }
+		}
+	}
+	`
+	config := fmt.Sprintf(base, `cron = "0 9 * * MON"`)
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(base, `cron = "0 9 * MON"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid cron expression`),
+			},
+			{
+				Config:      fmt.Sprintf(base, `frequency = "weekly"`+"\n\t\t\t\t"+`cron = "0 9 * * MON"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("pipefy_automation.test", tfjsonpath.New("event").AtMapKey("scheduled").AtMapKey("cron"), knownvalue.StringExact("0 9 * * MON")),
+				},
+			},
+			{
+				// The API reporting the start as a timestamp on the same day
+				// is not drift; a schedule edited in the UI is.
+				PreConfig: func() {
+					st.EventParams = json.RawMessage(`{"scheduler_cron":"0 18 * * FRI","scheduler_timezone":"America/Sao_Paulo","scheduler_start_date":"2025-01-31T00:00:00-03:00"}`)
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pipefy_automation.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("pipefy_automation.test", tfjsonpath.New("event").AtMapKey("scheduled").AtMapKey("cron"), knownvalue.StringExact("0 9 * * MON")),
+					},
+				},
+			},
+			{
+				PreConfig: func() {
+					st.EventParams = json.RawMessage(`{"scheduler_cron":"0 9 * * MON","scheduler_timezone":"America/Sao_Paulo","scheduler_start_date":"2025-01-31T00:00:00-03:00"}`)
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestUnit_AutomationResource_UpdateReportsIgnoredClear(t *testing.T) {
+	st := &automationState{IgnoreNullClears: true}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var gr gqlReq
+		defer r.Body.Close()
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &gr)
+		w.Header().Set("Content-Type", "application/json")
+
+		in, _ := gr.Variables["input"].(map[string]any)
+		switch q := gr.Query; {
+		case strings.Contains(q, "createAutomation"):
+			st.ID = "auto_1"
+			st.merge(in)
+			_, _ = io.WriteString(w, `{"data":{"createAutomation":{"automation":`+st.toJSON()+`}}}`)
+		case strings.Contains(q, "updateAutomation"):
+			st.merge(in)
+			_, _ = io.WriteString(w, `{"data":{"updateAutomation":{"automation":`+st.toJSON()+`}}}`)
+		case strings.Contains(q, "deleteAutomation"):
+			_, _ = io.WriteString(w, `{"data":{"deleteAutomation":{"success":true}}}`)
+		case strings.Contains(q, "automation("):
+			_, _ = io.WriteString(w, `{"data":{"automation":`+st.toJSON()+`}}`)
+		default:
+			_, _ = io.WriteString(w, `{"data":{}}`)
+		}
+	}))
+	defer srv.Close()
+
+	base := `
+	provider "pipefy" {
+		endpoint = "` + srv.URL + `"
+		token    = "testtoken"
+	}
+
+	resource "pipefy_automation" "test" {
+		name           = "Auto"
+		event_id       = "field_updated"
+		action_id      = "generate_with_ai"
+		event_repo_id  = "306729113"
+		action_repo_id = "306729113"
+		active         = true
+		event_params = jsonencode({
+			triggerFieldIds = ["420173505"]
+		})
+		%s
+	}
+	`
+	withCondition := fmt.Sprintf(base, `
+		condition = jsonencode({
+			expressions           = [{ structure_id = 0, field_address = "420173505", operation = "present", value = "" }]
+			expressions_structure = [[0]]
+		})`)
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: withCondition,
+			},
+			{
+				Config:      fmt.Sprintf(base, ""),
+				ExpectError: regexp.MustCompile(`(?s)did\s+not\s+clear\s+condition`),
+			},
+		},
+	})
+}
+
+func TestUnit_AutomationResource_ReadKeepsDetailWhenDetailQueryFails(t *testing.T) {
+	st := &automationState{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var gr gqlReq
+		defer r.Body.Close()
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &gr)
+		w.Header().Set("Content-Type", "application/json")
+
+		in, _ := gr.Variables["input"].(map[string]any)
+		switch q := gr.Query; {
+		case strings.Contains(q, "createAutomation"):
+			st.ID = "auto_1"
+			st.merge(in)
+			_, _ = io.WriteString(w, `{"data":{"createAutomation":{"automation":`+st.toJSON()+`}}}`)
+		case strings.Contains(q, "deleteAutomation"):
+			_, _ = io.WriteString(w, `{"data":{"deleteAutomation":{"success":true}}}`)
+		case strings.Contains(q, "GetAutomationDetail_tf"):
+			_, _ = io.WriteString(w, `{"errors":[{"message":"Field 'aiParams' doesn't exist on type 'AutomationActionParams'"}]}`)
+		case strings.Contains(q, "automation("):
+			_, _ = io.WriteString(w, `{"data":{"automation":`+st.toJSON()+`}}`)
+		default:
+			_, _ = io.WriteString(w, `{"data":{}}`)
+		}
+	}))
+	defer srv.Close()
+
+	config := `
+	provider "pipefy" {
+		endpoint = "` + srv.URL + `"
+		token    = "testtoken"
+	}
+
+	resource "pipefy_automation" "test" {
+		name           = "Auto"
+		event_id       = "field_updated"
+		action_id      = "generate_with_ai"
+		event_repo_id  = "306729113"
+		action_repo_id = "306729113"
+		active         = true
+		event_params = jsonencode({
+			triggerFieldIds = ["420173505"]
+		})
+		action_params = jsonencode({
+			aiParams = { value = "Summarize", fieldIds = ["420173432"] }
+		})
+	}
+	`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("pipefy_automation.test", tfjsonpath.New("action_params"),
+						knownvalue.StringExact(`{"aiParams":{"fieldIds":["420173432"],"value":"Summarize"}}`)),
+				},
+			},
+			{
+				RefreshState: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestUnit_FieldOrderResource(t *testing.T) {
+	st := &phaseFieldsState{}
+	st.add("short_text", "Name", 1)
+	st.add("email", "Email", 2)
+	st.add("date", "Due", 3)
+	srv := newPhaseFieldsServer(st)
+	defer srv.Close()
+
+	config := func(uuids string) string {
+		return `
+		provider "pipefy" {
+			endpoint = "` + srv.URL + `"
+			token    = "testtoken"
+		}
+
+		resource "pipefy_field_order" "test" {
+			phase_id    = "phase_1"
+			field_uuids = [` + uuids + `]
+		}
+		`
+	}
+	order := func(uuids ...string) statecheck.StateCheck {
+		var checks []knownvalue.Check
+		for _, u := range uuids {
+			checks = append(checks, knownvalue.StringExact(u))
+		}
+		return statecheck.ExpectKnownValue("pipefy_field_order.test", tfjsonpath.New("field_uuids"), knownvalue.ListExact(checks))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks:   skipBelow18,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:            config(`"uuid-3", "uuid-1", "uuid-2"`),
+				ConfigStateChecks: []statecheck.StateCheck{order("uuid-3", "uuid-1", "uuid-2")},
+			},
+			{
+				// A reorder made in the UI is drift and is put back.
+				PreConfig: func() {
+					st.Fields[0]["index"] = 0.5
+				},
+				Config: config(`"uuid-3", "uuid-1", "uuid-2"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pipefy_field_order.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{order("uuid-3", "uuid-1", "uuid-2")},
+			},
+			{
+				Config:      config(`"uuid-1", "uuid-9"`),
+				ExpectError: regexp.MustCompile(`do not belong to phase`),
+			},
+			{
+				ResourceName:                         "pipefy_field_order.test",
+				ImportState:                          true,
+				ImportStateId:                        "phase_1",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "phase_id",
+			},
+		},
+	})
+
+	want := map[string]float64{"uuid-3": 1, "uuid-1": 2, "uuid-2": 3}
+	for _, f := range st.Fields {
+		if f["index"] != want[f["uuid"].(string)] {
+			t.Errorf("field %s index = %v, want %v", f["uuid"], f["index"], want[f["uuid"].(string)])
+		}
+	}
+	if len(st.Fields) != 3 {
+		t.Errorf("expected destroy to leave the fields in place, got %d", len(st.Fields))
+	}
+}
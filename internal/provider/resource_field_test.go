@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"strconv"
 	"strings"
 	"testing"
@@ -23,14 +24,57 @@ import (
 
 type fieldState struct {
 	id, internalID, uuid, label, fieldType, optionsJSON string
+	phaseID                                             string
 	required                                            *bool
 	description, help, customValidation                 *string
 	editable, minimalView                               *bool
 	index                                               *float64
+	connector                                           connectorState
 	created                                             bool
 	deletedCt                                           int
 }
 
+// connectorState holds the connector settings of a mocked field.
+type connectorState struct {
+	repoID                                        *string
+	canCreateNew, canConnectExisting, canMultiple *bool
+}
+
+// write applies the connector variables of a create or update mutation.
+func (c *connectorState) write(vars map[string]any) {
+	if p := varStr(vars, "connectedRepoId"); p != nil {
+		c.repoID = p
+	}
+	if p := varBool(vars, "canCreateNewConnected"); p != nil {
+		c.canCreateNew = p
+	}
+	if p := varBool(vars, "canConnectExisting"); p != nil {
+		c.canConnectExisting = p
+	}
+	if p := varBool(vars, "canConnectMultiples"); p != nil {
+		c.canMultiple = p
+	}
+}
+
+// json renders the connector keys of a field payload. Flags the config left
+// unset take the API's defaults.
+func (c connectorState) json() string {
+	if c.repoID == nil {
+		return `,"connectedRepo":null,"canCreateNewConnected":null,"canConnectExisting":null,"canConnectMultiples":null`
+	}
+	orTrue := func(p *bool) *bool {
+		if p == nil {
+			yes := true
+			return &yes
+		}
+		return p
+	}
+	return `,"connectedRepo":{"id":` + jsonStr(c.repoID) + `}` +
+		`,"canCreateNewConnected":` + jsonBool(orTrue(c.canCreateNew)) +
+		`,"canConnectExisting":` + jsonBool(orTrue(c.canConnectExisting)) +
+		`,"canConnectMultiples":` + jsonBool(orTrue(c.canMultiple))
+}
+
 func optionsJSON(vars map[string]any, fallback string) string {
 	if opts, ok := vars["options"]; ok {
 		b, _ := json.Marshal(opts)
@@ -96,7 +140,7 @@ func fieldObj(st *fieldState) string {
 		`,"editable":` + jsonBool(st.editable) +
 		`,"minimal_view":` + jsonBool(st.minimalView) +
 		`,"custom_validation":` + jsonStr(st.customValidation) +
-		`,"index":` + jsonNum(st.index) + `}`
+		`,"index":` + jsonNum(st.index) + st.connector.json() + `}`
 }
 
 func fieldMockHandler(st *fieldState) http.HandlerFunc {
@@ -115,6 +159,7 @@ func fieldMockHandler(st *fieldState) http.HandlerFunc {
 		switch q := gr.Query; {
 		case strings.Contains(q, "createPhaseField"):
 			st.id, st.internalID, st.uuid = "field_123", "456", "field-uuid-1"
+			st.phaseID, _ = gr.Variables["phaseId"].(string)
 			st.label, _ = gr.Variables["label"].(string)
 			st.fieldType, _ = gr.Variables["type"].(string)
 			st.required = varBool(gr.Variables, "required")
@@ -129,6 +174,7 @@ func fieldMockHandler(st *fieldState) http.HandlerFunc {
 				st.index = &def
 			}
 			st.optionsJSON = optionsJSON(gr.Variables, "null")
+			st.connector.write(gr.Variables)
 			st.created = true
 			_, _ = io.WriteString(w, `{"data":{"createPhaseField":{"phase_field":`+fieldObj(st)+`}}}`)
 		case strings.Contains(q, "updatePhaseField"):
@@ -157,12 +203,15 @@ func fieldMockHandler(st *fieldState) http.HandlerFunc {
 				st.index = p
 			}
 			st.optionsJSON = optionsJSON(gr.Variables, st.optionsJSON)
+			st.connector.write(gr.Variables)
 			_, _ = io.WriteString(w, `{"data":{"updatePhaseField":{"phase_field":`+fieldObj(st)+`}}}`)
 		case strings.Contains(q, "deletePhaseField"):
 			st.deletedCt++
 			_, _ = io.WriteString(w, `{"data":{"deletePhaseField":{"success":true}}}`)
 		case strings.Contains(q, "repo_id"):
 			_, _ = io.WriteString(w, `{"data":{"phase":{"repo_id":123}}}`)
+		case strings.Contains(q, "startFormPhaseId"):
+			_, _ = io.WriteString(w, `{"data":{"pipe":{"startFormPhaseId":"start_form_1"}}}`)
 		case strings.Contains(q, "pipe("):
 			_, _ = io.WriteString(w, `{"data":{"pipe":{"uuid":"pipe-uuid-1"}}}`)
 		case strings.Contains(q, "phase("):
@@ -416,6 +465,112 @@ resource "pipefy_field" "test" {
 	})
 }
 
+func TestUnit_FieldResource_Connector(t *testing.T) {
+	st := &fieldState{}
+	srv := httptest.NewServer(fieldMockHandler(st))
+	defer srv.Close()
+
+	create := fieldConfig(srv.URL, `
+resource "pipefy_field" "test" {
+  phase_id = pipefy_phase.ph.id
+  type     = "connector"
+  label    = "Supplier"
+  connector = {
+    repo_id              = "301"
+    can_connect_multiple = false
+  }
+}
+`)
+	update := strings.ReplaceAll(create, `can_connect_multiple = false`, `can_connect_multiple = true
+    can_create_new       = false`)
+	replace := strings.ReplaceAll(update, `"301"`, `"302"`)
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks:   skipBelow18,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: create,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("pipefy_field.test", tfjsonpath.New("connector"), knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"repo_id":              knownvalue.StringExact("301"),
+						"can_create_new":       knownvalue.Bool(true),
+						"can_connect_existing": knownvalue.Bool(true),
+						"can_connect_multiple": knownvalue.Bool(false),
+					})),
+				},
+			},
+			{
+				Config:           update,
+				ConfigPlanChecks: planUpdate,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("pipefy_field.test", tfjsonpath.New("connector").AtMapKey("can_create_new"), knownvalue.Bool(false)),
+					statecheck.ExpectKnownValue("pipefy_field.test", tfjsonpath.New("connector").AtMapKey("can_connect_multiple"), knownvalue.Bool(true)),
+				},
+			},
+			{
+				ResourceName: "pipefy_field.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["pipefy_field.test"]
+					return rs.Primary.Attributes["phase_id"] + "/" + rs.Primary.Attributes["uuid"], nil
+				},
+				ImportStateVerify: true,
+			},
+			{
+				Config: replace,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pipefy_field.test", plancheck.ResourceActionReplace),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestUnit_FieldResource_ConnectorValidation(t *testing.T) {
+	cases := map[string]struct {
+		body    string
+		wantErr *regexp.Regexp
+	}{
+		"connector without settings": {
+			body:    `type = "connector"`,
+			wantErr: regexp.MustCompile(`type\s+"connector"\s+requires\s+a\s+connector\s+block`),
+		},
+		"settings on another type": {
+			body: `type      = "short_text"
+  connector = { repo_id = "301" }`,
+			wantErr: regexp.MustCompile(`connector\s+only\s+applies\s+to\s+fields\s+of\s+type\s+"connector"`),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				TerraformVersionChecks:   skipBelow18,
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: `
+provider "pipefy" {
+  token = "testtoken"
+}
+
+resource "pipefy_field" "test" {
+  phase_id = "phase_1"
+  label    = "Supplier"
+  ` + tc.body + `
+}
+`,
+						PlanOnly:    true,
+						ExpectError: tc.wantErr,
+					},
+				},
+			})
+		})
+	}
+}
+
 type collisionField struct {
 	id, internalID, uuid, label string
 }
@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// organizationWebhookMock tracks a single organization webhook and the inputs
+// it was created and updated with.
+type organizationWebhookMock struct {
+	mu             sync.Mutex
+	webhook        map[string]any // nil when it does not exist
+	organizationID any
+	inputs         []map[string]any
+	deleted        int
+}
+
+func (mock *organizationWebhookMock) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	defer r.Body.Close()
+	var gr gqlReq
+	b, _ := io.ReadAll(r.Body)
+	_ = json.Unmarshal(b, &gr)
+	w.Header().Set("Content-Type", "application/json")
+	input, _ := gr.Variables["input"].(map[string]any)
+
+	switch q := gr.Query; {
+	case strings.Contains(q, "CreateOrganizationWebhook_tf"):
+		mock.inputs = append(mock.inputs, input)
+		mock.organizationID = input["organization_id"]
+		mock.webhook = map[string]any{"id": "org_webhook_1"}
+		mock.store(input)
+		out, _ := json.Marshal(mock.webhook)
+		_, _ = io.WriteString(w, `{"data":{"createOrganizationWebhook":{"webhook":`+string(out)+`}}}`)
+	case strings.Contains(q, "UpdateOrganizationWebhook_tf"):
+		mock.inputs = append(mock.inputs, input)
+		mock.store(input)
+		_, _ = io.WriteString(w, `{"data":{"updateOrganizationWebhook":{"webhook":{"id":"org_webhook_1"}}}}`)
+	case strings.Contains(q, "DeleteOrganizationWebhook_tf"):
+		mock.deleted++
+		mock.webhook = nil
+		_, _ = io.WriteString(w, `{"data":{"deleteOrganizationWebhook":{"success":true}}}`)
+	case strings.Contains(q, "GetOrganizationWebhooks_tf"):
+		webhooks := []any{}
+		if mock.webhook != nil && gr.Variables["organizationId"] == mock.organizationID {
+			webhooks = append(webhooks, mock.webhook)
+		}
+		out, _ := json.Marshal(webhooks)
+		_, _ = io.WriteString(w, `{"data":{"organization":{"webhooks":`+string(out)+`}}}`)
+	default:
+		_, _ = io.WriteString(w, `{"data":{}}`)
+	}
+}
+
+func (mock *organizationWebhookMock) store(input map[string]any) {
+	for _, key := range []string{"name", "url", "actions"} {
+		mock.webhook[key] = input[key]
+	}
+	mock.webhook["filters"] = map[string]any{}
+	if filters, ok := input["filters"].(map[string]any); ok {
+		mock.webhook["filters"] = filters
+	}
+}
+
+func organizationWebhookConfig(endpoint, name, filters string) string {
+	return `
+	provider "pipefy" {
+		endpoint = "` + endpoint + `"
+		token    = "testtoken"
+	}
+
+	resource "pipefy_organization_webhook" "test" {
+		organization_id = "300"
+		name            = "` + name + `"
+		url             = "https://security.example.com/pipefy"
+		actions         = ["user.invitation_sent", "user.removal_from_org"]
+		headers         = jsonencode({ Authorization = "Bearer secret" })
+		` + filters + `
+	}
+	`
+}
+
+func TestUnit_OrganizationWebhookResource_CRUD(t *testing.T) {
+	mock := &organizationWebhookMock{}
+	srv := httptest.NewServer(http.HandlerFunc(mock.serveHTTP))
+	defer srv.Close()
+	filters := `filters = jsonencode({ email_domain = ["example.com"] })`
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: organizationWebhookConfig(srv.URL, "Membership", filters),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("pipefy_organization_webhook.test", tfjsonpath.New("id"), knownvalue.StringExact("org_webhook_1")),
+					statecheck.ExpectKnownValue("pipefy_organization_webhook.test", tfjsonpath.New("actions"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("user.invitation_sent"),
+						knownvalue.StringExact("user.removal_from_org"),
+					})),
+				},
+			},
+			{
+				// Filters removed outside Terraform are detected and restored.
+				PreConfig:          func() { mock.webhook["filters"] = map[string]any{} },
+				Config:             organizationWebhookConfig(srv.URL, "Membership", filters),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: organizationWebhookConfig(srv.URL, "Membership events", ""),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("pipefy_organization_webhook.test", tfjsonpath.New("name"), knownvalue.StringExact("Membership events")),
+					statecheck.ExpectKnownValue("pipefy_organization_webhook.test", tfjsonpath.New("filters"), knownvalue.Null()),
+				},
+			},
+			{
+				ResourceName:            "pipefy_organization_webhook.test",
+				ImportState:             true,
+				ImportStateId:           "300/org_webhook_1",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"headers"},
+			},
+			{
+				ResourceName:  "pipefy_organization_webhook.test",
+				ImportState:   true,
+				ImportStateId: "org_webhook_1",
+				ExpectError:   regexp.MustCompile(`expected organization_id/webhook_id`),
+			},
+		},
+	})
+
+	if len(mock.inputs) != 2 || mock.deleted != 1 {
+		t.Fatalf("inputs = %v, deleted = %d; want a create, an update and a delete", mock.inputs, mock.deleted)
+	}
+	if headers, ok := mock.inputs[0]["headers"].(string); !ok || headers != `{"Authorization":"Bearer secret"}` {
+		t.Fatalf("create headers = %#v, want the JSON string", mock.inputs[0]["headers"])
+	}
+	if filters, ok := mock.inputs[1]["filters"]; !ok || filters != nil {
+		t.Fatalf("update filters = %#v, want an explicit null", filters)
+	}
+}
+
+func TestUnit_OrganizationWebhookResource_Validations(t *testing.T) {
+	cases := map[string]struct {
+		url, headers string
+		wantErr      *regexp.Regexp
+	}{
+		"invalid url":          {url: "not-a-url", headers: `"{}"`, wantErr: regexp.MustCompile(`Invalid URL`)},
+		"invalid headers json": {url: "https://example.com/hook", headers: `"{not valid json"`, wantErr: regexp.MustCompile(`Invalid JSON String Value`)},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_8_0),
+				},
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: `
+						provider "pipefy" {
+							token = "testtoken"
+						}
+
+						resource "pipefy_organization_webhook" "test" {
+							organization_id = "300"
+							name            = "Hook"
+							url             = "` + tc.url + `"
+							actions         = ["user.invitation_sent"]
+							headers         = ` + tc.headers + `
+						}
+						`,
+						PlanOnly:    true,
+						ExpectError: tc.wantErr,
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,312 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+type phaseFieldsState struct {
+	Fields    []map[string]any
+	NextID    int
+	CreatedCt int
+	UpdatedCt int
+	DeletedCt int
+	// RefuseDelete makes deletePhaseField report success=false and keep
+	// the field.
+	RefuseDelete bool
+}
+
+func (st *phaseFieldsState) add(fieldType, label string, index float64) map[string]any {
+	st.NextID++
+	n := strconv.Itoa(st.NextID)
+	f := map[string]any{
+		"id": "field_" + n, "internal_id": n, "uuid": "uuid-" + n,
+		"label": label, "type": fieldType, "required": false, "options": []string{},
+		"description": nil, "help": nil, "editable": true, "minimal_view": false,
+		"custom_validation": nil, "index": index,
+	}
+	st.Fields = append(st.Fields, f)
+	return f
+}
+
+func (st *phaseFieldsState) labels() []string {
+	var out []string
+	for _, f := range st.Fields {
+		out = append(out, f["label"].(string))
+	}
+	return out
+}
+
+func newPhaseFieldsServer(st *phaseFieldsState) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer testtoken" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = io.WriteString(w, `{"errors":[{"message":"unauthorized"}]}`)
+			return
+		}
+		var gr gqlReq
+		defer r.Body.Close()
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &gr)
+		w.Header().Set("Content-Type", "application/json")
+
+		write := func(payload any) {
+			out, _ := json.Marshal(map[string]any{"data": payload})
+			_, _ = w.Write(out)
+		}
+		apply := func(f map[string]any) {
+			for _, k := range []string{"label", "required", "options", "description", "help", "editable", "customValidation", "index"} {
+				if v, ok := gr.Variables[k]; ok {
+					f[strings.Replace(k, "customValidation", "custom_validation", 1)] = v
+				}
+			}
+			if v, ok := gr.Variables["minimalView"]; ok {
+				f["minimal_view"] = v
+			}
+		}
+
+		switch q := gr.Query; {
+		case strings.Contains(q, "createPhaseField"):
+			st.CreatedCt++
+			fieldType, _ := gr.Variables["type"].(string)
+			f := st.add(fieldType, "", 0)
+			apply(f)
+			write(map[string]any{"createPhaseField": map[string]any{"phase_field": f}})
+		case strings.Contains(q, "updatePhaseField"):
+			st.UpdatedCt++
+			for _, f := range st.Fields {
+				if f["uuid"] == gr.Variables["uuid"] {
+					apply(f)
+					write(map[string]any{"updatePhaseField": map[string]any{"phase_field": f}})
+					return
+				}
+			}
+			_, _ = io.WriteString(w, `{"errors":[{"message":"field not found"}]}`)
+		case strings.Contains(q, "deletePhaseField"):
+			if st.RefuseDelete {
+				write(map[string]any{"deletePhaseField": map[string]any{"success": false}})
+				return
+			}
+			st.DeletedCt++
+			kept := st.Fields[:0]
+			for _, f := range st.Fields {
+				if f["id"] != gr.Variables["id"] {
+					kept = append(kept, f)
+				}
+			}
+			st.Fields = kept
+			write(map[string]any{"deletePhaseField": map[string]any{"success": true}})
+		case strings.Contains(q, "repo_id"):
+			write(map[string]any{"phase": map[string]any{"repo_id": 123}})
+		case strings.Contains(q, "pipe("):
+			write(map[string]any{"pipe": map[string]any{"uuid": "pipe-uuid-1"}})
+		case strings.Contains(q, "phase("):
+			fields := st.Fields
+			if fields == nil {
+				fields = []map[string]any{}
+			}
+			write(map[string]any{"phase": map[string]any{"fields": fields}})
+		default:
+			write(map[string]any{})
+		}
+	}))
+}
+
+func TestUnit_PhaseFieldsResource_CRUD(t *testing.T) {
+	st := &phaseFieldsState{}
+	st.add("short_text", "Stale", 1)
+	srv := newPhaseFieldsServer(st)
+	defer srv.Close()
+
+	config := func(fields string) string {
+		return `
+		provider "pipefy" {
+			endpoint = "` + srv.URL + `"
+			token    = "testtoken"
+		}
+
+		resource "pipefy_phase_fields" "test" {
+			phase_id = "phase_1"
+			fields   = [` + fields + `]
+		}
+		`
+	}
+	labels := func(want ...string) statecheck.StateCheck {
+		var checks []knownvalue.Check
+		for _, l := range want {
+			checks = append(checks, knownvalue.ObjectPartial(map[string]knownvalue.Check{"label": knownvalue.StringExact(l)}))
+		}
+		return statecheck.ExpectKnownValue("pipefy_phase_fields.test", tfjsonpath.New("fields"), knownvalue.ListExact(checks))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks:   skipBelow18,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`
+					{ type = "short_text", label = "Name", required = true },
+					{ type = "email", label = "Email" },
+				`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					labels("Name", "Email"),
+					statecheck.ExpectKnownValue("pipefy_phase_fields.test", tfjsonpath.New("fields").AtSliceIndex(0).AtMapKey("uuid"), knownvalue.NotNull()),
+				},
+			},
+			{
+				// A rename in place keeps the field.
+				Config: config(`
+					{ type = "short_text", label = "Full name", required = true },
+					{ type = "email", label = "Email" },
+				`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					labels("Full name", "Email"),
+				},
+			},
+			{
+				Config: config(`
+					{ type = "email", label = "Email" },
+					{ type = "short_text", label = "Full name", required = true },
+				`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					labels("Email", "Full name"),
+				},
+			},
+			{
+				PreConfig: func() { st.add("number", "Ad hoc", 9) },
+				Config: config(`
+					{ type = "email", label = "Email" },
+					{ type = "short_text", label = "Full name", required = true },
+				`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pipefy_phase_fields.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					labels("Email", "Full name"),
+				},
+			},
+			{
+				ResourceName:                         "pipefy_phase_fields.test",
+				ImportState:                          true,
+				ImportStateId:                        "phase_1",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "phase_id",
+			},
+		},
+	})
+
+	if got := st.labels(); len(got) != 0 {
+		t.Errorf("expected destroy to delete every managed field, left %v", got)
+	}
+	if st.CreatedCt != 2 {
+		t.Errorf("expected renames and reorders to update in place, got %d creates", st.CreatedCt)
+	}
+}
+
+func TestUnit_PhaseFieldsResource_CreateAdoptsExistingFields(t *testing.T) {
+	st := &phaseFieldsState{}
+	st.add("short_text", "Name", 1)
+	st.add("number", "Owned elsewhere", 2)
+	srv := newPhaseFieldsServer(st)
+	defer srv.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks:   skipBelow18,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "pipefy" {
+					endpoint = "` + srv.URL + `"
+					token    = "testtoken"
+				}
+
+				resource "pipefy_phase_fields" "test" {
+					phase_id = "phase_1"
+					fields   = [
+						{ type = "short_text", label = "Name", required = true },
+						{ type = "email", label = "Email" },
+					]
+				}
+				`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("pipefy_phase_fields.test", tfjsonpath.New("fields").AtSliceIndex(0).AtMapKey("uuid"), knownvalue.StringExact("uuid-1")),
+						plancheck.ExpectUnknownValue("pipefy_phase_fields.test", tfjsonpath.New("fields").AtSliceIndex(1).AtMapKey("uuid")),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("pipefy_phase_fields.test", tfjsonpath.New("fields").AtSliceIndex(0).AtMapKey("uuid"), knownvalue.StringExact("uuid-1")),
+				},
+			},
+		},
+	})
+
+	// The adopted field is updated in place; only Email is created, and the
+	// field the list does not declare is deleted after the plan warned about it.
+	if st.CreatedCt != 1 || st.UpdatedCt < 1 {
+		t.Errorf("creates = %d, updates = %d; want Name adopted and only Email created", st.CreatedCt, st.UpdatedCt)
+	}
+	if st.DeletedCt != 3 {
+		t.Errorf("deletes = %d, want the undeclared field on create plus both fields on destroy", st.DeletedCt)
+	}
+}
+
+func TestUnit_PhaseFieldsResource_DeleteReportsUnconfirmedDeletion(t *testing.T) {
+	st := &phaseFieldsState{}
+	srv := newPhaseFieldsServer(st)
+	defer srv.Close()
+
+	config := func(fields string) string {
+		return `
+		provider "pipefy" {
+			endpoint = "` + srv.URL + `"
+			token    = "testtoken"
+		}
+
+		resource "pipefy_phase_fields" "test" {
+			phase_id = "phase_1"
+			fields   = [` + fields + `]
+		}
+		`
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks:   skipBelow18,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`
+					{ type = "short_text", label = "Name" },
+					{ type = "email", label = "Email" },
+				`),
+			},
+			{
+				PreConfig:   func() { st.RefuseDelete = true },
+				Config:      config(`{ type = "short_text", label = "Name" }`),
+				ExpectError: regexp.MustCompile(`success=false`),
+			},
+			{
+				PreConfig: func() { st.RefuseDelete = false },
+				Config:    config(`{ type = "short_text", label = "Name" }`),
+			},
+		},
+	})
+}
@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+type phaseOrderState struct {
+	Phases    []map[string]any
+	UpdatedCt int
+	// NoIndexInput makes the API's UpdatePhaseInput lack index, as Pipefy's
+	// has when phases can only be positioned at creation.
+	NoIndexInput bool
+}
+
+func (st *phaseOrderState) setIndex(id string, index float64) {
+	for _, p := range st.Phases {
+		if p["id"] == id {
+			p["index"] = index
+		}
+	}
+}
+
+func newPhaseOrderServer(st *phaseOrderState) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer testtoken" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = io.WriteString(w, `{"errors":[{"message":"unauthorized"}]}`)
+			return
+		}
+		var gr gqlReq
+		defer r.Body.Close()
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &gr)
+		w.Header().Set("Content-Type", "application/json")
+
+		write := func(payload any) {
+			out, _ := json.Marshal(map[string]any{"data": payload})
+			_, _ = w.Write(out)
+		}
+
+		switch q := gr.Query; {
+		case strings.Contains(q, "__type"):
+			inputFields := []map[string]any{{"name": "id"}, {"name": "name"}, {"name": "done"}}
+			if !st.NoIndexInput {
+				inputFields = append(inputFields, map[string]any{"name": "index"})
+			}
+			write(map[string]any{"__type": map[string]any{"inputFields": inputFields}})
+		case strings.Contains(q, "updatePhase"):
+			st.UpdatedCt++
+			id, _ := gr.Variables["id"].(string)
+			index, _ := gr.Variables["index"].(float64)
+			st.setIndex(id, index)
+			write(map[string]any{"updatePhase": map[string]any{"phase": map[string]any{"id": id, "index": index}}})
+		case strings.Contains(q, "phases"):
+			write(map[string]any{"pipe": map[string]any{"phases": st.Phases}})
+		default:
+			write(map[string]any{})
+		}
+	}))
+}
+
+func TestUnit_PhaseOrderResource(t *testing.T) {
+	st := &phaseOrderState{Phases: []map[string]any{
+		{"id": "ph_a", "name": "A", "index": 1.0},
+		{"id": "ph_b", "name": "B", "index": 2.0},
+		{"id": "ph_c", "name": "C", "index": 3.0},
+	}}
+	srv := newPhaseOrderServer(st)
+	defer srv.Close()
+
+	config := func(ids string) string {
+		return `
+		provider "pipefy" {
+			endpoint = "` + srv.URL + `"
+			token    = "testtoken"
+		}
+
+		resource "pipefy_phase_order" "test" {
+			pipe_id   = "pipe_1"
+			phase_ids = [` + ids + `]
+		}
+		`
+	}
+	order := func(ids ...string) statecheck.StateCheck {
+		var checks []knownvalue.Check
+		for _, id := range ids {
+			checks = append(checks, knownvalue.StringExact(id))
+		}
+		return statecheck.ExpectKnownValue("pipefy_phase_order.test", tfjsonpath.New("phase_ids"), knownvalue.ListExact(checks))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks:   skipBelow18,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:            config(`"ph_c", "ph_a", "ph_b"`),
+				ConfigStateChecks: []statecheck.StateCheck{order("ph_c", "ph_a", "ph_b")},
+			},
+			{
+				// A reorder made in the UI is drift and is put back.
+				PreConfig: func() {
+					st.setIndex("ph_c", 3)
+					st.setIndex("ph_b", 1)
+				},
+				Config: config(`"ph_c", "ph_a", "ph_b"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pipefy_phase_order.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{order("ph_c", "ph_a", "ph_b")},
+			},
+			{
+				Config:      config(`"ph_a", "ph_x"`),
+				ExpectError: regexp.MustCompile(`do not belong to pipe`),
+			},
+			{
+				ResourceName:                         "pipefy_phase_order.test",
+				ImportState:                          true,
+				ImportStateId:                        "pipe_1",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "pipe_id",
+			},
+		},
+	})
+
+	for _, p := range st.Phases {
+		want := map[string]float64{"ph_c": 1, "ph_a": 2, "ph_b": 3}[p["id"].(string)]
+		if p["index"] != want {
+			t.Errorf("phase %s index = %v, want %v", p["id"], p["index"], want)
+		}
+	}
+}
+
+func TestUnit_PhaseOrderResource_IndexNotUpdatable(t *testing.T) {
+	st := &phaseOrderState{NoIndexInput: true, Phases: []map[string]any{
+		{"id": "ph_a", "name": "A", "index": 1.0},
+		{"id": "ph_b", "name": "B", "index": 2.0},
+	}}
+	srv := newPhaseOrderServer(st)
+	defer srv.Close()
+
+	config := func(ids string) string {
+		return `
+		provider "pipefy" {
+			endpoint = "` + srv.URL + `"
+			token    = "testtoken"
+		}
+
+		resource "pipefy_phase_order" "test" {
+			pipe_id   = "pipe_1"
+			phase_ids = [` + ids + `]
+		}
+		`
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks:   skipBelow18,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The phases are already in this order, so nothing needs to move.
+				Config: config(`"ph_a", "ph_b"`),
+			},
+			{
+				Config:      config(`"ph_b", "ph_a"`),
+				ExpectError: regexp.MustCompile(`does\s+not\s+accept\s+index\s+on\s+updatePhase`),
+			},
+		},
+	})
+
+	if st.UpdatedCt != 0 {
+		t.Errorf("expected no phase updates, got %d", st.UpdatedCt)
+	}
+}
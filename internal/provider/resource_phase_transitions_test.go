@@ -0,0 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+type phaseTransitionsState struct {
+	Targets   []string
+	UpdatedCt int
+	// PipeMissing makes the pipe of the phase not found.
+	PipeMissing bool
+}
+
+func newPhaseTransitionsServer(st *phaseTransitionsState) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer testtoken" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = io.WriteString(w, `{"errors":[{"message":"unauthorized"}]}`)
+			return
+		}
+		var gr gqlReq
+		defer r.Body.Close()
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &gr)
+		w.Header().Set("Content-Type", "application/json")
+
+		write := func(payload any) {
+			out, _ := json.Marshal(map[string]any{"data": payload})
+			_, _ = w.Write(out)
+		}
+		targets := func() []map[string]any {
+			out := []map[string]any{}
+			for _, id := range st.Targets {
+				out = append(out, map[string]any{"id": id})
+			}
+			return out
+		}
+
+		switch q := gr.Query; {
+		case strings.Contains(q, "updatePhase"):
+			st.UpdatedCt++
+			st.Targets = nil
+			ids, _ := gr.Variables["phaseIds"].([]any)
+			for _, id := range ids {
+				st.Targets = append(st.Targets, id.(string))
+			}
+			write(map[string]any{"updatePhase": map[string]any{"phase": map[string]any{"id": "ph_a", "cards_can_be_moved_to_phases": targets()}}})
+		case strings.Contains(q, "phases") && st.PipeMissing:
+			write(map[string]any{"pipe": nil})
+		case strings.Contains(q, "phases"):
+			write(map[string]any{"pipe": map[string]any{"phases": []map[string]any{
+				{"id": "ph_a", "name": "A", "index": 1},
+				{"id": "ph_b", "name": "B", "index": 2},
+				{"id": "ph_c", "name": "C", "index": 3},
+			}}})
+		case strings.Contains(q, "phase("):
+			write(map[string]any{"phase": map[string]any{"id": "ph_a", "name": "A", "repo_id": 301, "cards_can_be_moved_to_phases": targets()}})
+		default:
+			write(map[string]any{})
+		}
+	}))
+}
+
+func TestUnit_PhaseTransitionsResource(t *testing.T) {
+	st := &phaseTransitionsState{}
+	srv := newPhaseTransitionsServer(st)
+	defer srv.Close()
+
+	config := func(ids string) string {
+		return `
+		provider "pipefy" {
+			endpoint = "` + srv.URL + `"
+			token    = "testtoken"
+		}
+
+		resource "pipefy_phase_transitions" "test" {
+			phase_id              = "ph_a"
+			can_move_to_phase_ids = [` + ids + `]
+		}
+		`
+	}
+	targets := func(ids ...string) statecheck.StateCheck {
+		var checks []knownvalue.Check
+		for _, id := range ids {
+			checks = append(checks, knownvalue.StringExact(id))
+		}
+		return statecheck.ExpectKnownValue("pipefy_phase_transitions.test", tfjsonpath.New("can_move_to_phase_ids"), knownvalue.SetExact(checks))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks:   skipBelow18,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:            config(`"ph_b"`),
+				ConfigStateChecks: []statecheck.StateCheck{targets("ph_b")},
+			},
+			{
+				// A transition added in the UI is drift and is removed.
+				PreConfig: func() { st.Targets = append(st.Targets, "ph_c") },
+				Config:    config(`"ph_b"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pipefy_phase_transitions.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{targets("ph_b")},
+			},
+			{
+				// Known targets are checked when planning, before anything is applied.
+				Config:      config(`"ph_b", "ph_other_pipe"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`not other phases of pipe 301`),
+			},
+			{
+				Config:      config(`"ph_a"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`not other phases of pipe 301`),
+			},
+			{
+				Config:            config(``),
+				ConfigStateChecks: []statecheck.StateCheck{targets()},
+			},
+			{
+				ResourceName:                         "pipefy_phase_transitions.test",
+				ImportState:                          true,
+				ImportStateId:                        "ph_a",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "phase_id",
+			},
+		},
+	})
+}
+
+func TestUnit_PhaseTransitionsResource_MissingPipe(t *testing.T) {
+	st := &phaseTransitionsState{PipeMissing: true}
+	srv := newPhaseTransitionsServer(st)
+	defer srv.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks:   skipBelow18,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "pipefy" {
+					endpoint = "` + srv.URL + `"
+					token    = "testtoken"
+				}
+
+				resource "pipefy_phase_transitions" "test" {
+					phase_id              = "ph_a"
+					can_move_to_phase_ids = ["ph_b"]
+				}
+				`,
+				ExpectError: regexp.MustCompile(`pipe\s+301\s+of\s+phase\s+ph_a\s+not\s+found`),
+			},
+		},
+	})
+	if st.UpdatedCt != 0 {
+		t.Errorf("expected no update when the pipe is missing, got %d", st.UpdatedCt)
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+type pipeLabelsState struct {
+	Labels    []map[string]any
+	NextID    int
+	CreatedCt int
+	UpdatedCt int
+	DeletedCt int
+}
+
+func (st *pipeLabelsState) add(name, color string) {
+	st.NextID++
+	st.Labels = append(st.Labels, map[string]any{"id": "label_" + strconv.Itoa(st.NextID), "name": name, "color": color})
+}
+
+func newPipeLabelsServer(st *pipeLabelsState) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer testtoken" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = io.WriteString(w, `{"errors":[{"message":"unauthorized"}]}`)
+			return
+		}
+		var gr gqlReq
+		defer r.Body.Close()
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &gr)
+		w.Header().Set("Content-Type", "application/json")
+
+		write := func(payload any) {
+			out, _ := json.Marshal(map[string]any{"data": payload})
+			_, _ = w.Write(out)
+		}
+
+		switch q := gr.Query; {
+		case strings.Contains(q, "createLabel"):
+			st.CreatedCt++
+			name, _ := gr.Variables["name"].(string)
+			color, _ := gr.Variables["color"].(string)
+			st.add(name, color)
+			write(map[string]any{"createLabel": map[string]any{"label": st.Labels[len(st.Labels)-1]}})
+		case strings.Contains(q, "updateLabel"):
+			st.UpdatedCt++
+			id, _ := gr.Variables["id"].(string)
+			for _, l := range st.Labels {
+				if l["id"] == id {
+					l["name"] = gr.Variables["name"]
+					l["color"] = gr.Variables["color"]
+					write(map[string]any{"updateLabel": map[string]any{"label": l}})
+					return
+				}
+			}
+			_, _ = io.WriteString(w, `{"errors":[{"message":"label not found"}]}`)
+		case strings.Contains(q, "deleteLabel"):
+			st.DeletedCt++
+			id, _ := gr.Variables["id"].(string)
+			kept := st.Labels[:0]
+			for _, l := range st.Labels {
+				if l["id"] != id {
+					kept = append(kept, l)
+				}
+			}
+			st.Labels = kept
+			write(map[string]any{"deleteLabel": map[string]any{"success": true}})
+		case strings.Contains(q, "labels"):
+			labels := st.Labels
+			if labels == nil {
+				labels = []map[string]any{}
+			}
+			write(map[string]any{"pipe": map[string]any{"labels": labels}})
+		default:
+			write(map[string]any{})
+		}
+	}))
+}
+
+func TestUnit_PipeLabelsResource_CRUD(t *testing.T) {
+	st := &pipeLabelsState{}
+	st.add("Stale", "#000000")
+	srv := newPipeLabelsServer(st)
+	defer srv.Close()
+
+	config := func(labels string) string {
+		return `
+		provider "pipefy" {
+			endpoint = "` + srv.URL + `"
+			token    = "testtoken"
+		}
+
+		resource "pipefy_pipe_labels" "test" {
+			pipe_id = "pipe_1"
+			labels  = {` + labels + `}
+		}
+		`
+	}
+	labels := func(want map[string]string) statecheck.StateCheck {
+		checks := map[string]knownvalue.Check{}
+		for name, color := range want {
+			checks[name] = knownvalue.StringExact(color)
+		}
+		return statecheck.ExpectKnownValue("pipefy_pipe_labels.test", tfjsonpath.New("labels"), knownvalue.MapExact(checks))
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`
+					"Urgent"  = "#FF0000"
+					"Blocked" = "#FFA500"
+				`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					labels(map[string]string{"Urgent": "#FF0000", "Blocked": "#FFA500"}),
+					statecheck.ExpectKnownValue("pipefy_pipe_labels.test", tfjsonpath.New("label_ids").AtMapKey("Urgent"), knownvalue.NotNull()),
+				},
+			},
+			{
+				Config: config(`
+					"Urgent" = "#990000"
+				`),
+				ConfigStateChecks: []statecheck.StateCheck{
+					labels(map[string]string{"Urgent": "#990000"}),
+				},
+			},
+			{
+				PreConfig: func() { st.add("Ad hoc", "#123456") },
+				Config: config(`
+					"Urgent" = "#990000"
+				`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pipefy_pipe_labels.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					labels(map[string]string{"Urgent": "#990000"}),
+				},
+			},
+			{
+				ResourceName:                         "pipefy_pipe_labels.test",
+				ImportState:                          true,
+				ImportStateId:                        "pipe_1",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "pipe_id",
+			},
+		},
+	})
+
+	if st.UpdatedCt == 0 {
+		t.Errorf("expected a color change to update the label in place")
+	}
+	if len(st.Labels) != 0 {
+		t.Errorf("expected destroy to delete every managed label, %d left", len(st.Labels))
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"regexp"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -40,10 +41,14 @@ type pipeState struct {
 
 	Deleted           bool
 	PhaseDelCt        int
+	PhaseDelFailures  map[string]int
 	CreatePipeCt      int
 	UpdatePipeCt      int
 	CreateSawSettings bool
 	FailUpdate        bool
+
+	// mu serializes the handler: default phases are deleted concurrently.
+	mu sync.Mutex
 }
 
 func (st *pipeState) resetDefaults(name string) {
@@ -152,6 +157,9 @@ func newPipeServer(st *pipeState) *httptest.Server {
 		_ = json.Unmarshal(b, &gr)
 		w.Header().Set("Content-Type", "application/json")
 
+		st.mu.Lock()
+		defer st.mu.Unlock()
+
 		write := func(payload any) {
 			out, _ := json.Marshal(map[string]any{"data": payload})
 			_, _ = w.Write(out)
@@ -178,6 +186,11 @@ func newPipeServer(st *pipeState) *httptest.Server {
 			write(map[string]any{"deletePipe": map[string]any{"success": true}})
 		case strings.Contains(q, "deletePhase"):
 			st.PhaseDelCt++
+			if id, _ := gr.Variables["id"].(string); st.PhaseDelFailures[id] > 0 {
+				st.PhaseDelFailures[id]--
+				_, _ = io.WriteString(w, `{"errors":[{"message":"try again"}]}`)
+				return
+			}
 			write(map[string]any{"deletePhase": map[string]any{"clientMutationId": "", "success": true}})
 		case strings.Contains(q, "phases"):
 			pipe := st.toMap()
@@ -391,3 +404,118 @@ func TestUnit_PipeResource_PartialCreateTracksPipe(t *testing.T) {
 		},
 	})
 }
+
+func TestUnit_PipeResource_DefaultPhases(t *testing.T) {
+	st := &pipeState{}
+	srv := newPipeServer(st)
+	defer srv.Close()
+
+	config := func(mode string) string {
+		return `
+		provider "pipefy" {
+			endpoint = "` + srv.URL + `"
+			token    = "testtoken"
+		}
+
+		resource "pipefy_pipe" "test" {
+			name            = "My Pipe"
+			organization_id = "org_1"
+			default_phases  = "` + mode + `"
+		}
+		`
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("adopt"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("pipefy_pipe.test", tfjsonpath.New("default_phase_ids"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("phase_1"),
+						knownvalue.StringExact("phase_2"),
+					})),
+				},
+			},
+			{
+				Config:      config("discard"),
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+		},
+	})
+
+	if st.PhaseDelCt != 0 {
+		t.Errorf("adopt must keep the default phases, got %d deletions", st.PhaseDelCt)
+	}
+}
+
+func TestUnit_PipeResource_KeepDefaultPhases(t *testing.T) {
+	st := &pipeState{}
+	srv := newPipeServer(st)
+	defer srv.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "pipefy" {
+					endpoint = "` + srv.URL + `"
+					token    = "testtoken"
+				}
+
+				resource "pipefy_pipe" "test" {
+					name            = "My Pipe"
+					organization_id = "org_1"
+					default_phases  = "keep"
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("pipefy_pipe.test", tfjsonpath.New("default_phase_ids"), knownvalue.Null()),
+				},
+			},
+		},
+	})
+
+	if st.PhaseDelCt != 0 {
+		t.Errorf("keep must not delete the default phases, got %d deletions", st.PhaseDelCt)
+	}
+}
+
+func TestUnit_PipeResource_DeletePhasesRetries(t *testing.T) {
+	st := &pipeState{PhaseDelFailures: map[string]int{"phase_1": 1}}
+	srv := newPipeServer(st)
+	defer srv.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "pipefy" {
+					endpoint = "` + srv.URL + `"
+					token    = "testtoken"
+				}
+
+				resource "pipefy_pipe" "test" {
+					name            = "My Pipe"
+					organization_id = "org_1"
+				}
+				`,
+			},
+		},
+	})
+
+	if st.PhaseDelCt != 3 {
+		t.Errorf("expected one retried and one direct deletion (3 calls), got %d", st.PhaseDelCt)
+	}
+}
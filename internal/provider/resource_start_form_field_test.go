@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider_test
+
+import (
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func startFormFieldConfig(srvURL, label string) string {
+	return `
+provider "pipefy" {
+  endpoint = "` + srvURL + `"
+  token    = "testtoken"
+}
+
+resource "pipefy_start_form_field" "test" {
+  pipe_id  = "123"
+  type     = "short_text"
+  label    = "` + label + `"
+  required = true
+}
+`
+}
+
+func TestUnit_StartFormFieldResource_CRUD(t *testing.T) {
+	st := &fieldState{}
+	srv := httptest.NewServer(fieldMockHandler(st))
+	defer srv.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks:   skipBelow18,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: startFormFieldConfig(srv.URL, "Requester"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("pipefy_start_form_field.test", tfjsonpath.New("phase_id"), knownvalue.StringExact("start_form_1")),
+					statecheck.ExpectKnownValue("pipefy_start_form_field.test", tfjsonpath.New("uuid"), knownvalue.StringExact("field-uuid-1")),
+					statecheck.ExpectKnownValue("pipefy_start_form_field.test", tfjsonpath.New("required"), knownvalue.Bool(true)),
+				},
+			},
+			{
+				Config: startFormFieldConfig(srv.URL, "Requested by"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pipefy_start_form_field.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("pipefy_start_form_field.test", tfjsonpath.New("label"), knownvalue.StringExact("Requested by")),
+					statecheck.ExpectKnownValue("pipefy_start_form_field.test", tfjsonpath.New("phase_id"), knownvalue.StringExact("start_form_1")),
+				},
+			},
+			{
+				ResourceName:      "pipefy_start_form_field.test",
+				ImportState:       true,
+				ImportStateId:     "123/field-uuid-1",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "pipefy_start_form_field.test",
+				ImportState:   true,
+				ImportStateId: "field-uuid-1",
+				ExpectError:   regexp.MustCompile(`expected\s+pipe_id/field_uuid`),
+			},
+			{
+				// A replaced pipe replaces the field instead of editing the old start form.
+				Config: strings.ReplaceAll(startFormFieldConfig(srv.URL, "Requested by"), `"123"`, `"124"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("pipefy_start_form_field.test", plancheck.ResourceActionReplace),
+					},
+				},
+			},
+		},
+	})
+
+	if st.phaseID != "start_form_1" {
+		t.Fatalf("createPhaseField phaseId = %q, want the resolved start form phase", st.phaseID)
+	}
+	if st.deletedCt == 0 {
+		t.Fatal("expected the replaced field to be deleted")
+	}
+}
@@ -24,6 +24,7 @@ type tableFieldState struct {
 	required, unique                                    *bool
 	description, help, customValidation                 *string
 	minimalView                                         *bool
+	connector                                           connectorState
 	created                                             bool
 	deletedCt                                           int
 }
@@ -38,7 +39,7 @@ func tableFieldObj(st *tableFieldState) string {
 		`,"help":` + jsonStr(st.help) +
 		`,"minimal_view":` + jsonBool(st.minimalView) +
 		`,"custom_validation":` + jsonStr(st.customValidation) +
-		`,"unique":` + jsonBool(st.unique) + `}`
+		`,"unique":` + jsonBool(st.unique) + st.connector.json() + `}`
 }
 
 func tableFieldMockHandler(st *tableFieldState) http.HandlerFunc {
@@ -66,6 +67,7 @@ func tableFieldMockHandler(st *tableFieldState) http.HandlerFunc {
 			st.customValidation = varStr(gr.Variables, "customValidation")
 			st.unique = varBool(gr.Variables, "unique")
 			st.optionsJSON = optionsJSON(gr.Variables, "null")
+			st.connector.write(gr.Variables)
 			st.created = true
 			_, _ = io.WriteString(w, `{"data":{"createTableField":{"table_field":`+tableFieldObj(st)+`}}}`)
 		case strings.Contains(q, "updateTableField"):
@@ -91,6 +93,7 @@ func tableFieldMockHandler(st *tableFieldState) http.HandlerFunc {
 				st.unique = p
 			}
 			st.optionsJSON = optionsJSON(gr.Variables, st.optionsJSON)
+			st.connector.write(gr.Variables)
 			_, _ = io.WriteString(w, `{"data":{"updateTableField":{"table_field":`+tableFieldObj(st)+`}}}`)
 		case strings.Contains(q, "deleteTableField"):
 			st.deletedCt++
@@ -184,6 +187,63 @@ resource "pipefy_table_field" "test" {
 	}
 }
 
+func TestUnit_TableFieldResource_Connector(t *testing.T) {
+	st := &tableFieldState{}
+	srv := httptest.NewServer(tableFieldMockHandler(st))
+	defer srv.Close()
+
+	create := tableFieldConfig(srv.URL, `
+resource "pipefy_table_field" "test" {
+  table_id = pipefy_table.t.id
+  type     = "connector"
+  label    = "Owner pipe"
+  connector = {
+    repo_id        = "301"
+    can_create_new = false
+  }
+}
+`)
+	update := strings.ReplaceAll(create, `can_create_new = false`, `can_create_new = true`)
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks:   skipBelow18,
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: create,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("pipefy_table_field.test", tfjsonpath.New("connector"), knownvalue.ObjectExact(map[string]knownvalue.Check{
+						"repo_id":              knownvalue.StringExact("301"),
+						"can_create_new":       knownvalue.Bool(false),
+						"can_connect_existing": knownvalue.Bool(true),
+						"can_connect_multiple": knownvalue.Bool(true),
+					})),
+				},
+			},
+			{
+				Config:           update,
+				ConfigPlanChecks: planTableFieldUpdate,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("pipefy_table_field.test", tfjsonpath.New("connector").AtMapKey("can_create_new"), knownvalue.Bool(true)),
+				},
+			},
+			{
+				ResourceName: "pipefy_table_field.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["pipefy_table_field.test"]
+					return rs.Primary.Attributes["table_id"] + "/" + rs.Primary.Attributes["uuid"], nil
+				},
+				ImportStateVerify: true,
+			},
+		},
+	})
+
+	if st.connector.repoID == nil || *st.connector.repoID != "301" {
+		t.Fatalf("connectedRepoId = %v, want 301", st.connector.repoID)
+	}
+}
+
 func TestUnit_TableFieldResource_SchemaAttributes(t *testing.T) {
 	st := &tableFieldState{}
 	srv := httptest.NewServer(tableFieldMockHandler(st))
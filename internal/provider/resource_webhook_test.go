@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"regexp"
 	"strings"
 	"testing"
@@ -36,6 +37,7 @@ type webhookState struct {
 	UpdateFiltersType string
 	UpdateHeadersNull bool
 	UpdateFiltersNull bool
+	SentHeaders       []any // headers input of every create and update
 
 	DeletedCt int
 }
@@ -96,6 +98,7 @@ func newWebhookServer(st *webhookState) *httptest.Server {
 			st.Name, st.URL, st.Actions = webhookInputStrings(input)
 			if h, ok := input["headers"]; ok {
 				st.HeadersType = fmt.Sprintf("%T", h)
+				st.SentHeaders = append(st.SentHeaders, h)
 			}
 			if f, ok := input["filters"]; ok {
 				st.FiltersType = fmt.Sprintf("%T", f)
@@ -119,6 +122,7 @@ func newWebhookServer(st *webhookState) *httptest.Server {
 			if h, ok := input["headers"]; ok {
 				st.UpdateHeadersType = fmt.Sprintf("%T", h)
 				st.UpdateHeadersNull = h == nil
+				st.SentHeaders = append(st.SentHeaders, h)
 			}
 			if f, ok := input["filters"]; ok {
 				st.UpdateFiltersType = fmt.Sprintf("%T", f)
@@ -417,6 +421,104 @@ func TestUnit_WebhookResource_ClearOnRemove(t *testing.T) {
 	}
 }
 
+// TestUnit_WebhookResource_WriteOnlyHeaders proves that headers_wo is sent
+// without being stored, and that bumping headers_wo_version re-sends it.
+func TestUnit_WebhookResource_WriteOnlyHeaders(t *testing.T) {
+	st := &webhookState{}
+	srv := newWebhookServer(st)
+	defer srv.Close()
+	provider := webhookProviderBlock(srv.URL)
+
+	config := func(token string, version int) string {
+		return provider + fmt.Sprintf(`
+		resource "pipefy_webhook" "test" {
+			pipe_id            = pipefy_pipe.p.id
+			name               = "Card events"
+			url                = "https://example.com/hook"
+			actions            = ["card.create"]
+			headers_wo         = jsonencode({ Authorization = "Bearer %s" })
+			headers_wo_version = %d
+		}
+		`, token, version)
+	}
+	notStored := []statecheck.StateCheck{
+		statecheck.ExpectKnownValue("pipefy_webhook.test", tfjsonpath.New("headers_wo"), knownvalue.Null()),
+		statecheck.ExpectKnownValue("pipefy_webhook.test", tfjsonpath.New("headers"), knownvalue.Null()),
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{Config: config("secret", 1), ConfigStateChecks: notStored},
+			// A new value alone is not a change: nothing is stored to compare.
+			{Config: config("rotated", 1), PlanOnly: true},
+			{Config: config("rotated", 2), ConfigStateChecks: notStored},
+		},
+	})
+
+	want := []any{`{"Authorization":"Bearer secret"}`, `{"Authorization":"Bearer rotated"}`}
+	if !reflect.DeepEqual(st.SentHeaders, want) {
+		t.Fatalf("sent headers = %v, want %v", st.SentHeaders, want)
+	}
+}
+
+// TestUnit_WebhookResource_WriteOnlyHeadersValidation covers the pairing
+// rules of headers_wo, which need a Terraform version with write-only support.
+func TestUnit_WebhookResource_WriteOnlyHeadersValidation(t *testing.T) {
+	cases := map[string]struct {
+		body    string
+		wantErr *regexp.Regexp
+	}{
+		"headers_wo without version": {
+			body: `
+			url        = "https://example.com/hook"
+			actions    = ["card.create"]
+			headers_wo = jsonencode({ Authorization = "Bearer secret" })`,
+			wantErr: regexp.MustCompile(`(?s)headers_wo_version.*must\s+be\s+specified`),
+		},
+		"headers and headers_wo": {
+			body: `
+			url                = "https://example.com/hook"
+			actions            = ["card.create"]
+			headers            = jsonencode({ Authorization = "Bearer secret" })
+			headers_wo         = jsonencode({ Authorization = "Bearer secret" })
+			headers_wo_version = 1`,
+			wantErr: regexp.MustCompile(`Invalid\s+Attribute\s+Combination`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_11_0),
+				},
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: `
+						provider "pipefy" {
+							token = "testtoken"
+						}
+
+						resource "pipefy_webhook" "test" {
+							pipe_id = "2"
+							name    = "Hook"
+` + tc.body + `
+						}
+						`,
+						PlanOnly:    true,
+						ExpectError: tc.wantErr,
+					},
+				},
+			})
+		})
+	}
+}
+
 // TestUnit_WebhookResource_Validations guarantees every client-side validation
 // is wired to its attribute and rejects bad input at plan time: the URL
 // validator and the JSON validation on headers and filters.
@@ -481,6 +583,182 @@ func TestUnit_WebhookResource_Validations(t *testing.T) {
 	}
 }
 
+// TestUnit_WebhookResource_PlanValidatesActions covers the action and filter
+// checks, against the built-in list and against the list the API publishes.
+func TestUnit_WebhookResource_PlanValidatesActions(t *testing.T) {
+	builtIn := newWebhookServer(&webhookState{})
+	defer builtIn.Close()
+	published := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var gr gqlReq
+		defer r.Body.Close()
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &gr)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(gr.Query, "WebhookActions_tf") {
+			_, _ = io.WriteString(w, `{"data":{"__type":{"enumValues":[{"name":"CARD_CREATE"},{"name":"CARD_COMMENT_CREATE"}]}}}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"data":{}}`)
+	}))
+	defer published.Close()
+	// An enum with no known action is not the webhook actions enum.
+	unrelated := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"data":{"__type":{"enumValues":[{"name":"ACTIVE"},{"name":"INACTIVE"}]}}}`)
+	}))
+	defer unrelated.Close()
+
+	config := func(endpoint, body string) string {
+		return `
+		provider "pipefy" {
+			endpoint = "` + endpoint + `"
+			token    = "testtoken"
+		}
+
+		resource "pipefy_webhook" "test" {
+			pipe_id = "2"
+			name    = "Hook"
+			url     = "https://example.com/hook"
+` + body + `
+		}
+		`
+	}
+	cases := map[string]struct {
+		endpoint string
+		body     string
+		wantErr  *regexp.Regexp
+	}{
+		"misspelled action": {
+			endpoint: builtIn.URL,
+			body:     `actions = ["card.create", "card.moved"]`,
+			wantErr:  regexp.MustCompile(`did\s+you\s+mean\s+"card.move"\?`),
+		},
+		"filter for another action": {
+			endpoint: builtIn.URL,
+			body: `actions = ["card.move"]
+			filters = jsonencode({ field_id = [1] })`,
+			wantErr: regexp.MustCompile(`filter\s+"field_id"\s+applies\s+to\s+card.field_update`),
+		},
+		"filter with phase names": {
+			endpoint: builtIn.URL,
+			body: `actions = ["card.move"]
+			filters = jsonencode({ to_phase_id = ["Done"] })`,
+			wantErr: regexp.MustCompile(`non-empty\s+list\s+of\s+IDs`),
+		},
+		"published action": {
+			endpoint: published.URL,
+			body:     `actions = ["card.comment_create"]`,
+		},
+		"unrelated enum ignored": {
+			endpoint: unrelated.URL,
+			body:     `actions = ["card.move"]`,
+		},
+		"action the API does not publish": {
+			endpoint: published.URL,
+			body:     `actions = ["card.move"]`,
+			wantErr:  regexp.MustCompile(`(?s)"card.move"\s+is\s+not\s+a\s+supported\s+webhook\s+action.*card.comment_create`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_8_0),
+				},
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:             config(tc.endpoint, tc.body),
+						PlanOnly:           true,
+						ExpectNonEmptyPlan: tc.wantErr == nil,
+						ExpectError:        tc.wantErr,
+					},
+				},
+			})
+		})
+	}
+}
+
+// TestUnit_WebhookResource_URLPolicy covers the provider's webhook_url_policy
+// rejecting URLs at plan time for both webhook resources.
+func TestUnit_WebhookResource_URLPolicy(t *testing.T) {
+	srv := newWebhookServer(&webhookState{})
+	defer srv.Close()
+
+	config := func(policy, resourceType, url string) string {
+		scope := `pipe_id = "2"
+			actions = ["card.create"]`
+		if resourceType == "pipefy_organization_webhook" {
+			scope = `organization_id = "300"
+			actions         = ["user.invitation_sent"]`
+		}
+		return `
+		provider "pipefy" {
+			endpoint           = "` + srv.URL + `"
+			token              = "testtoken"
+			webhook_url_policy = ` + policy + `
+		}
+
+		resource "` + resourceType + `" "test" {
+			` + scope + `
+			name = "Hook"
+			url  = "` + url + `"
+		}
+		`
+	}
+	allowed := `{ allowed_hosts = ["hooks.example.com", "*.internal.example.com"] }`
+	cases := map[string]struct {
+		policy, resourceType, url string
+		wantErr                   *regexp.Regexp
+	}{
+		"allowed host": {
+			policy: allowed, resourceType: "pipefy_webhook", url: "https://hooks.example.com/pipefy",
+		},
+		"allowed subdomain": {
+			policy: allowed, resourceType: "pipefy_organization_webhook", url: "https://audit.internal.example.com/pipefy",
+		},
+		"host outside the list": {
+			policy: allowed, resourceType: "pipefy_webhook", url: "https://example.com/hook",
+			wantErr: regexp.MustCompile(`host\s+example.com\s+is\s+not\s+in\s+the\s+provider's\s+webhook_url_policy\s+allowed_hosts`),
+		},
+		"http by default": {
+			policy: `{}`, resourceType: "pipefy_webhook", url: "http://hooks.example.com/hook",
+			wantErr: regexp.MustCompile(`webhook_url_policy\s+requires\s+https`),
+		},
+		"http when allowed": {
+			policy: `{ require_https = false }`, resourceType: "pipefy_webhook", url: "http://hooks.example.com/hook",
+		},
+		"private address": {
+			policy: `{}`, resourceType: "pipefy_organization_webhook", url: "https://10.0.0.5/hook",
+			wantErr: regexp.MustCompile(`private\s+address\s+10.0.0.5`),
+		},
+		"invalid host pattern": {
+			policy: `{ allowed_hosts = ["https://hooks.example.com"] }`, resourceType: "pipefy_webhook", url: "https://hooks.example.com/hook",
+			wantErr: regexp.MustCompile(`Invalid\s+webhook\s+URL\s+policy`),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_8_0),
+				},
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:             config(tc.policy, tc.resourceType, tc.url),
+						PlanOnly:           true,
+						ExpectNonEmptyPlan: tc.wantErr == nil,
+						ExpectError:        tc.wantErr,
+					},
+				},
+			})
+		})
+	}
+}
+
 // TestUnit_WebhookResource_Import covers importing an existing webhook using the
 // pipe_id/webhook_id syntax, and rejecting a malformed import ID.
 func TestUnit_WebhookResource_Import(t *testing.T) {
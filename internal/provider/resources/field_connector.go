@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// connectorFieldType is the field type that links records of another pipe or
+// table, and the only one that takes connector settings.
+const connectorFieldType = "connector"
+
+// fieldConnectorModel is the connector block shared by pipefy_field and
+// pipefy_table_field.
+type fieldConnectorModel struct {
+	RepoId             types.String `tfsdk:"repo_id"`
+	CanCreateNew       types.Bool   `tfsdk:"can_create_new"`
+	CanConnectExisting types.Bool   `tfsdk:"can_connect_existing"`
+	CanConnectMultiple types.Bool   `tfsdk:"can_connect_multiple"`
+}
+
+func fieldConnectorAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:    true,
+		Description: "Connector settings. Required when type is `connector`, and not allowed for other types.",
+		Attributes: map[string]schema.Attribute{
+			"repo_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the pipe or table the field connects to. Changing it replaces the field.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"can_create_new": schema.BoolAttribute{
+				Optional:      true,
+				Computed:      true,
+				Description:   "Whether new connected cards or records can be created from the field",
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+			"can_connect_existing": schema.BoolAttribute{
+				Optional:      true,
+				Computed:      true,
+				Description:   "Whether existing cards or records can be connected from the field",
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+			"can_connect_multiple": schema.BoolAttribute{
+				Optional:      true,
+				Computed:      true,
+				Description:   "Whether the field can hold more than one connected card or record",
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+// validateFieldConnector requires the connector block for connector fields and
+// rejects it for every other type. An unknown type or block skips the check.
+func validateFieldConnector(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) {
+	var fieldType types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("type"), &fieldType)...)
+	var connector types.Object
+	diags.Append(config.GetAttribute(ctx, path.Root("connector"), &connector)...)
+	if diags.HasError() || fieldType.IsUnknown() || fieldType.IsNull() || connector.IsUnknown() {
+		return
+	}
+
+	switch isConnector := fieldType.ValueString() == connectorFieldType; {
+	case isConnector && connector.IsNull():
+		diags.AddAttributeError(path.Root("connector"), "Missing connector settings",
+			`type "connector" requires a connector block with the repo_id of the pipe or table to connect to.`)
+	case !isConnector && !connector.IsNull():
+		diags.AddAttributeError(path.Root("connector"), "Unexpected connector settings",
+			`connector only applies to fields of type "connector", got type "`+fieldType.ValueString()+`".`)
+	}
+}
+
+// addConnectorWriteVars sends the connector flags that have a concrete value.
+// The connected repo is only sent on create, as it cannot change afterwards.
+func addConnectorWriteVars(c *fieldConnectorModel, vars map[string]any) {
+	if c == nil {
+		return
+	}
+	if !c.CanCreateNew.IsNull() && !c.CanCreateNew.IsUnknown() {
+		vars["canCreateNewConnected"] = c.CanCreateNew.ValueBool()
+	}
+	if !c.CanConnectExisting.IsNull() && !c.CanConnectExisting.IsUnknown() {
+		vars["canConnectExisting"] = c.CanConnectExisting.ValueBool()
+	}
+	if !c.CanConnectMultiple.IsNull() && !c.CanConnectMultiple.IsUnknown() {
+		vars["canConnectMultiples"] = c.CanConnectMultiple.ValueBool()
+	}
+}
+
+// connectorFromAPI builds the connector block of a fetched field, or nil when
+// the field is not a connector or links to no pipe or table.
+func connectorFromAPI(fieldType, repoID string, canCreateNew, canConnectExisting, canConnectMultiple *bool) *fieldConnectorModel {
+	if fieldType != connectorFieldType || repoID == "" {
+		return nil
+	}
+	return &fieldConnectorModel{
+		RepoId:             types.StringValue(repoID),
+		CanCreateNew:       boolPtr(canCreateNew),
+		CanConnectExisting: boolPtr(canConnectExisting),
+		CanConnectMultiple: boolPtr(canConnectMultiple),
+	}
+}
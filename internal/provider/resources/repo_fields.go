@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/client"
+)
+
+const (
+	getPipeRepoFieldsQuery = "query GetPipeRepoFields_tf($id:ID!){ pipe(id:$id){ " +
+		"start_form_fields{ id internal_id type } phases{ id fields{ id internal_id type } } } }"
+	getTableRepoFieldsQuery = "query GetTableRepoFields_tf($id:ID!){ table(id:$id){ " +
+		"table_fields{ id internal_id type } } }"
+)
+
+type repoFieldPayload struct {
+	ID         string `json:"id"`
+	InternalID string `json:"internal_id"`
+	Type       string `json:"type"`
+}
+
+// repoFields are the phases of a pipe and the types of its fields, keyed by
+// both field ID and internal ID. A table has fields but no phases.
+type repoFields struct {
+	phases     map[string]bool
+	fieldTypes map[string]string
+}
+
+func newRepoFields() *repoFields {
+	return &repoFields{phases: map[string]bool{}, fieldTypes: map[string]string{}}
+}
+
+func (refs *repoFields) addFields(fields []repoFieldPayload) {
+	for _, field := range fields {
+		refs.fieldTypes[field.ID] = field.Type
+		refs.fieldTypes[field.InternalID] = field.Type
+	}
+}
+
+// hasField reports whether id is the ID or internal ID of a field.
+func (refs *repoFields) hasField(id string) bool {
+	_, ok := refs.fieldTypes[id]
+	return ok
+}
+
+func fetchPipeFields(ctx context.Context, api *client.ApiClient, pipeID string) (*repoFields, error) {
+	var output struct {
+		Pipe *struct {
+			StartFormFields []repoFieldPayload `json:"start_form_fields"`
+			Phases          []struct {
+				ID     string             `json:"id"`
+				Fields []repoFieldPayload `json:"fields"`
+			} `json:"phases"`
+		} `json:"pipe"`
+	}
+	if err := api.DoGraphQL(ctx, getPipeRepoFieldsQuery, map[string]any{"id": pipeID}, &output); err != nil {
+		return nil, err
+	}
+	if output.Pipe == nil {
+		return nil, fmt.Errorf("pipe %q was not found", pipeID)
+	}
+	refs := newRepoFields()
+	refs.addFields(output.Pipe.StartFormFields)
+	for _, phase := range output.Pipe.Phases {
+		refs.phases[phase.ID] = true
+		refs.addFields(phase.Fields)
+	}
+	return refs, nil
+}
+
+func fetchTableFields(ctx context.Context, api *client.ApiClient, tableID string) (*repoFields, error) {
+	var output struct {
+		Table *struct {
+			TableFields []repoFieldPayload `json:"table_fields"`
+		} `json:"table"`
+	}
+	if err := api.DoGraphQL(ctx, getTableRepoFieldsQuery, map[string]any{"id": tableID}, &output); err != nil {
+		return nil, err
+	}
+	if output.Table == nil {
+		return nil, fmt.Errorf("table %q was not found", tableID)
+	}
+	refs := newRepoFields()
+	refs.addFields(output.Table.TableFields)
+	return refs, nil
+}
+
+// fetchRepoFields loads repoID as a pipe or, when it is not a pipe, as a
+// table.
+func fetchRepoFields(ctx context.Context, api *client.ApiClient, repoID string) (*repoFields, error) {
+	refs, pipeErr := fetchPipeFields(ctx, api, repoID)
+	if pipeErr == nil {
+		return refs, nil
+	}
+	if refs, err := fetchTableFields(ctx, api, repoID); err == nil {
+		return refs, nil
+	}
+	return nil, fmt.Errorf("no pipe or table with id %s: %s", repoID, pipeErr.Error())
+}
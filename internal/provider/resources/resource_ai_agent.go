@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/pipefy/terraform-provider-pipefy/internal/provider/aiagentgql"
 	"github.com/pipefy/terraform-provider-pipefy/internal/provider/client"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/locks"
 )
 
 const createAIAgentMutation = "mutation CreateAiAgent_tf($input:CreateAgentInput!){ " +
@@ -74,7 +75,7 @@ func (r *AiAgentResource) Create(
 	if !ok {
 		return
 	}
-	repoUUID, err := resolvePipeUUID(ctx, r.api, model.PipeID.ValueString())
+	repoUUID, err := r.api.PipeUUID(ctx, model.PipeID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("create AI agent failed", err.Error())
 		return
@@ -113,6 +114,10 @@ func (r *AiAgentResource) createAgent(
 	model *AiAgentModel,
 	repoUUID string,
 ) error {
+	agent, err := model.graphQLInput(repoUUID)
+	if err != nil {
+		return err
+	}
 	var output struct {
 		CreateAIAgent struct {
 			Agent struct {
@@ -120,9 +125,7 @@ func (r *AiAgentResource) createAgent(
 			} `json:"agent"`
 		} `json:"createAiAgent"`
 	}
-	variables := map[string]any{"input": map[string]any{
-		"agent": model.graphQLInput(repoUUID),
-	}}
+	variables := map[string]any{"input": map[string]any{"agent": agent}}
 	if err := r.api.DoGraphQL(ctx, createAIAgentMutation, variables, &output); err != nil {
 		return err
 	}
@@ -225,7 +228,7 @@ func (r *AiAgentResource) Update(
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	repoUUID, err := resolvePipeUUID(ctx, r.api, plan.PipeID.ValueString())
+	repoUUID, err := r.api.PipeUUID(ctx, plan.PipeID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("update AI agent failed", err.Error())
 		return
@@ -234,6 +237,8 @@ func (r *AiAgentResource) Update(
 		resp.Diagnostics.AddError("update AI agent failed", "generate action reference IDs: "+err.Error())
 		return
 	}
+	unlock := locks.LockAiAgent(plan.ID.ValueString())
+	defer unlock()
 	r.applyUpdate(ctx, &plan, state, repoUUID, configuredActive, resp)
 }
 
@@ -294,7 +299,7 @@ func (r *AiAgentResource) verifyPipeOwnsAgent(
 	pipeID string,
 	agent aiagentgql.Agent,
 ) error {
-	repoUUID, err := resolvePipeUUID(ctx, r.api, pipeID)
+	repoUUID, err := r.api.PipeUUID(ctx, pipeID)
 	if err != nil {
 		return err
 	}
@@ -312,6 +317,27 @@ func (r *AiAgentResource) updateAgent(
 	model AiAgentModel,
 	repoUUID string,
 ) error {
+	agent, err := model.graphQLInput(repoUUID)
+	if err != nil {
+		return err
+	}
+	if model.Behaviors == nil {
+		// Behaviors are managed by pipefy_ai_agent_behavior; send them back as they are.
+		current, err := r.fetchAgent(ctx, model.ID.ValueString())
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return fmt.Errorf("aiAgent %q returned no agent", model.ID.ValueString())
+		}
+		agent["behaviors"] = current.Input()["behaviors"]
+	}
+	return updateAiAgent(ctx, r.api, model.ID.ValueString(), agent)
+}
+
+// updateAiAgent replaces the configuration of agent id, behaviors included,
+// with the given agent input.
+func updateAiAgent(ctx context.Context, api *client.ApiClient, id string, agent map[string]any) error {
 	var output struct {
 		UpdateAIAgent struct {
 			Agent struct {
@@ -319,10 +345,8 @@ func (r *AiAgentResource) updateAgent(
 			} `json:"agent"`
 		} `json:"updateAiAgent"`
 	}
-	variables := map[string]any{"input": map[string]any{
-		"uuid": model.ID.ValueString(), "agent": model.graphQLInput(repoUUID),
-	}}
-	if err := r.api.DoGraphQL(ctx, updateAIAgentMutation, variables, &output); err != nil {
+	variables := map[string]any{"input": map[string]any{"uuid": id, "agent": agent}}
+	if err := api.DoGraphQL(ctx, updateAIAgentMutation, variables, &output); err != nil {
 		return err
 	}
 	if output.UpdateAIAgent.Agent.UUID == "" {
@@ -351,10 +375,15 @@ func (r *AiAgentResource) fetchAgent(
 	ctx context.Context,
 	id string,
 ) (*aiagentgql.Agent, error) {
+	return fetchAiAgent(ctx, r.api, id)
+}
+
+// fetchAiAgent returns the agent, or nil when it no longer exists.
+func fetchAiAgent(ctx context.Context, api *client.ApiClient, id string) (*aiagentgql.Agent, error) {
 	var output struct {
 		AIAgent *aiagentgql.Agent `json:"aiAgent"`
 	}
-	err := r.api.DoGraphQL(ctx, getAIAgentQuery, map[string]any{"uuid": id}, &output)
+	err := api.DoGraphQL(ctx, getAIAgentQuery, map[string]any{"uuid": id}, &output)
 	if err != nil {
 		if isNotFoundError(err) {
 			return nil, nil
@@ -411,11 +440,21 @@ func (r *AiAgentResource) ImportState(
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
+	if pipeID, name, ok := cutImportName(req.ID); ok {
+		agentID, err := r.findAgentByName(ctx, pipeID, name)
+		if err != nil {
+			resp.Diagnostics.AddError("import AI agent failed", err.Error())
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("pipe_id"), pipeID)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), agentID)...)
+		return
+	}
 	parts, ok := splitImportID(req.ID)
 	if !ok || len(parts) != 2 {
 		resp.Diagnostics.AddError(
 			"invalid import ID",
-			fmt.Sprintf("got %q; expected pipe_id/agent_uuid", req.ID),
+			fmt.Sprintf("got %q; expected pipe_id/agent_uuid or pipe_id/name:<agent name>", req.ID),
 		)
 		return
 	}
@@ -423,6 +462,45 @@ func (r *AiAgentResource) ImportState(
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
 }
 
+// cutImportName splits a pipe_id/name:<agent name> import ID. The name is
+// everything after the prefix, so it may contain slashes.
+func cutImportName(id string) (pipeID, name string, ok bool) {
+	pipeID, rest, found := strings.Cut(id, "/")
+	name, named := strings.CutPrefix(rest, "name:")
+	if !found || !named || pipeID == "" || name == "" {
+		return "", "", false
+	}
+	return pipeID, name, true
+}
+
+// findAgentByName returns the UUID of the only agent of the pipe with the
+// given name.
+func (r *AiAgentResource) findAgentByName(ctx context.Context, pipeID, name string) (string, error) {
+	repoUUID, err := r.api.PipeUUID(ctx, pipeID)
+	if err != nil {
+		return "", err
+	}
+	agents, err := aiagentgql.List(ctx, r.api, repoUUID)
+	if err != nil {
+		return "", err
+	}
+	var matches []string
+	for _, agent := range agents {
+		if agent.Name == name {
+			matches = append(matches, agent.UUID)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("pipe %s has no AI agent named %q", pipeID, name)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("pipe %s has %d AI agents named %q (%s); import by UUID instead",
+			pipeID, len(matches), name, strings.Join(matches, ", "))
+	}
+}
+
 func isConfiguredBool(value types.Bool) bool {
 	return !value.IsNull() && !value.IsUnknown()
 }
@@ -0,0 +1,414 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/aiagentgql"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/client"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/locks"
+)
+
+var _ resource.Resource = &AiAgentBehaviorResource{}
+var _ resource.ResourceWithImportState = &AiAgentBehaviorResource{}
+var _ resource.ResourceWithValidateConfig = &AiAgentBehaviorResource{}
+var _ resource.ResourceWithModifyPlan = &AiAgentBehaviorResource{}
+
+// AiAgentBehaviorResource manages one behavior of an AI agent. Pipefy only
+// updates behaviors as the agent's complete list, so every change reads the
+// agent, swaps this behavior in or out and writes the list back under a
+// per-agent lock. The lock covers one provider process; see writeBehaviors
+// for applies from other states.
+type AiAgentBehaviorResource struct {
+	api *client.ApiClient
+}
+
+func NewAiAgentBehaviorResource() resource.Resource {
+	return &AiAgentBehaviorResource{}
+}
+
+type AiAgentBehaviorResourceModel struct {
+	ID              types.String                  `tfsdk:"id"`
+	AgentID         types.String                  `tfsdk:"agent_id"`
+	Name            types.String                  `tfsdk:"name"`
+	EventID         types.String                  `tfsdk:"event_id"`
+	Instruction     types.String                  `tfsdk:"instruction"`
+	InstructionFile types.String                  `tfsdk:"instruction_file"`
+	InstructionHash types.String                  `tfsdk:"instruction_hash"`
+	EventParams     *AiAgentEventParamsModel      `tfsdk:"event_params"`
+	Actions         map[string]AiAgentActionModel `tfsdk:"actions"`
+}
+
+func (m AiAgentBehaviorResourceModel) behavior() AiAgentBehaviorModel {
+	return AiAgentBehaviorModel{
+		ID: m.ID, EventID: m.EventID, Instruction: m.Instruction,
+		InstructionFile: m.InstructionFile, InstructionHash: m.InstructionHash,
+		EventParams: m.EventParams, Actions: m.Actions,
+	}
+}
+
+func (m *AiAgentBehaviorResourceModel) applyGraphQL(behavior aiagentgql.Behavior) {
+	refreshed := behaviorToModel(behavior, m.behavior())
+	m.ID = refreshed.ID
+	m.Name = types.StringValue(behavior.Name)
+	m.EventID = refreshed.EventID
+	m.Instruction = refreshed.Instruction
+	m.InstructionFile = refreshed.InstructionFile
+	m.InstructionHash = refreshed.InstructionHash
+	m.EventParams = refreshed.EventParams
+	m.Actions = refreshed.Actions
+}
+
+func (r *AiAgentBehaviorResource) Metadata(
+	ctx context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_ai_agent_behavior"
+}
+
+func (r *AiAgentBehaviorResource) Schema(
+	ctx context.Context,
+	req resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	attributes := behaviorAttributes()
+	attributes["agent_id"] = schema.StringAttribute{
+		Required: true, Description: "The UUID of the AI agent the behavior belongs to.",
+		PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+	}
+	attributes["name"] = requiredNonEmptyString("The behavior name, unique within the agent.")
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages one behavior of an AI agent and its actions, so behaviors of " +
+			"the same agent can live in different modules. The other behaviors of the agent are " +
+			"left as they are. Use it with a `pipefy_ai_agent` that omits `behaviors`. " +
+			"Behaviors of one agent are written one at a time within an apply, but applies from " +
+			"separate states are not coordinated: each write replaces the agent's whole behavior list, " +
+			"so a concurrent apply can overwrite another's change. A write that finds the agent changed " +
+			"by another apply fails and asks for a new plan, but not every overlap is detected, so do not " +
+			"rely on it for concurrent applies to one agent.",
+		Attributes: attributes,
+	}
+}
+
+func (r *AiAgentBehaviorResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+	api, ok := req.ProviderData.(*client.ApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data", fmt.Sprintf("expected *ApiClient, got %T", req.ProviderData),
+		)
+		return
+	}
+	r.api = api
+}
+
+func (r *AiAgentBehaviorResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var model AiAgentBehaviorResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for actionName, action := range model.Actions {
+		location := fmt.Sprintf("actions[%q]", actionName)
+		validateAction(location, path.Root("actions").AtMapKey(actionName), action, &resp.Diagnostics)
+	}
+}
+
+func (r *AiAgentBehaviorResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan AiAgentBehaviorResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	behavior := plan.behavior()
+	planInstruction(&behavior, path.Empty(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.InstructionHash = behavior.InstructionHash
+	if isEmptyEventParams(plan.EventParams) {
+		plan.EventParams = nil
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *AiAgentBehaviorResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan AiAgentBehaviorResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := ensureReferenceIDs(plan.Actions); err != nil {
+		resp.Diagnostics.AddError("create AI agent behavior failed", "generate action reference IDs: "+err.Error())
+		return
+	}
+	unlock := locks.LockAiAgent(plan.AgentID.ValueString())
+	defer unlock()
+	if err := r.writeBehavior(ctx, plan); err != nil {
+		resp.Diagnostics.AddError("create AI agent behavior failed", err.Error())
+		return
+	}
+	behavior, err := r.fetchBehavior(ctx, plan.AgentID.ValueString(), func(b aiagentgql.Behavior) bool {
+		return b.Name == plan.Name.ValueString()
+	})
+	if err == nil && behavior == nil {
+		err = fmt.Errorf(
+			"behavior %q was not found on agent %q after create", plan.Name.ValueString(), plan.AgentID.ValueString(),
+		)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("read AI agent behavior after create failed", err.Error())
+		return
+	}
+	plan.applyGraphQL(*behavior)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *AiAgentBehaviorResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state AiAgentBehaviorResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || !hasString(state.ID) {
+		return
+	}
+	behavior, err := r.fetchBehavior(ctx, state.AgentID.ValueString(), func(b aiagentgql.Behavior) bool {
+		return b.ID == state.ID.ValueString()
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("read AI agent behavior failed", err.Error())
+		return
+	}
+	if behavior == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.applyGraphQL(*behavior)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *AiAgentBehaviorResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan AiAgentBehaviorResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := ensureReferenceIDs(plan.Actions); err != nil {
+		resp.Diagnostics.AddError("update AI agent behavior failed", "generate action reference IDs: "+err.Error())
+		return
+	}
+	unlock := locks.LockAiAgent(plan.AgentID.ValueString())
+	defer unlock()
+	if err := r.writeBehavior(ctx, plan); err != nil {
+		resp.Diagnostics.AddError("update AI agent behavior failed", err.Error())
+		return
+	}
+	behavior, err := r.fetchBehavior(ctx, plan.AgentID.ValueString(), func(b aiagentgql.Behavior) bool {
+		return b.ID == plan.ID.ValueString()
+	})
+	if err == nil && behavior == nil {
+		err = fmt.Errorf(
+			"behavior %q was not found on agent %q after update", plan.ID.ValueString(), plan.AgentID.ValueString(),
+		)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("read AI agent behavior after update failed", err.Error())
+		return
+	}
+	plan.applyGraphQL(*behavior)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *AiAgentBehaviorResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state AiAgentBehaviorResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	agentID := state.AgentID.ValueString()
+	unlock := locks.LockAiAgent(agentID)
+	defer unlock()
+	agent, err := fetchAiAgent(ctx, r.api, agentID)
+	if err != nil {
+		resp.Diagnostics.AddError("delete AI agent behavior failed", err.Error())
+		return
+	}
+	if agent == nil {
+		return
+	}
+	input := agent.Input()
+	behaviors := make([]map[string]any, 0, len(agent.Behaviors))
+	for _, behavior := range agent.Behaviors {
+		if behavior.ID != state.ID.ValueString() {
+			behaviors = append(behaviors, behavior.Input())
+		}
+	}
+	if len(behaviors) == len(agent.Behaviors) {
+		return
+	}
+	input["behaviors"] = behaviors
+	if err := r.writeBehaviors(ctx, agentID, input, behaviors); err != nil {
+		resp.Diagnostics.AddError("delete AI agent behavior failed", err.Error())
+	}
+}
+
+func (r *AiAgentBehaviorResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	parts, ok := splitImportID(req.ID)
+	if !ok || len(parts) != 2 {
+		resp.Diagnostics.AddError(
+			"invalid import ID",
+			fmt.Sprintf("got %q; expected agent_uuid/behavior_id", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("agent_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+}
+
+// writeBehavior sends the agent back with model's behavior in place of the one
+// with the same ID, or appended when the model has no ID yet. The caller holds
+// the agent lock.
+func (r *AiAgentBehaviorResource) writeBehavior(ctx context.Context, model AiAgentBehaviorResourceModel) error {
+	agentID := model.AgentID.ValueString()
+	agent, err := fetchAiAgent(ctx, r.api, agentID)
+	if err != nil {
+		return err
+	}
+	if agent == nil {
+		return fmt.Errorf("AI agent %q does not exist", agentID)
+	}
+	name := model.Name.ValueString()
+	own, err := model.behavior().graphQLInput(name)
+	if err != nil {
+		return err
+	}
+	behaviors := make([]map[string]any, 0, len(agent.Behaviors)+1)
+	replaced := false
+	for _, behavior := range agent.Behaviors {
+		if hasString(model.ID) && behavior.ID == model.ID.ValueString() {
+			behaviors = append(behaviors, own)
+			replaced = true
+			continue
+		}
+		if behavior.Name == name {
+			return fmt.Errorf(
+				"agent %q already has a behavior named %q (id %s); import it as %s/%s",
+				agentID, name, behavior.ID, agentID, behavior.ID,
+			)
+		}
+		behaviors = append(behaviors, behavior.Input())
+	}
+	if !replaced {
+		if hasString(model.ID) {
+			return fmt.Errorf("behavior %q no longer exists on agent %q", model.ID.ValueString(), agentID)
+		}
+		behaviors = append(behaviors, own)
+	}
+	input := agent.Input()
+	input["behaviors"] = behaviors
+	return r.writeBehaviors(ctx, agentID, input, behaviors)
+}
+
+// writeBehaviors updates the agent and reads it back. The agent lock only
+// serializes this provider process, so an apply from another state can write
+// the list between the read and the write. Such a write is detected when it
+// leaves the agent with other behaviors than the ones sent; one that lands
+// after the read-back is not, and shows up as drift on the next plan.
+func (r *AiAgentBehaviorResource) writeBehaviors(
+	ctx context.Context,
+	agentID string,
+	input map[string]any,
+	behaviors []map[string]any,
+) error {
+	if err := updateAiAgent(ctx, r.api, agentID, input); err != nil {
+		return err
+	}
+	agent, err := fetchAiAgent(ctx, r.api, agentID)
+	if err != nil {
+		return err
+	}
+	sent := make([]string, len(behaviors))
+	for index, behavior := range behaviors {
+		sent[index], _ = behavior["name"].(string)
+	}
+	var read []string
+	if agent != nil {
+		for _, behavior := range agent.Behaviors {
+			read = append(read, behavior.Name)
+		}
+	}
+	slices.Sort(sent)
+	slices.Sort(read)
+	if !slices.Equal(sent, read) {
+		return fmt.Errorf(
+			"AI agent %q was changed by another apply while this behavior was written: "+
+				"wrote behaviors %v but the agent now has %v; plan and apply again", agentID, sent, read,
+		)
+	}
+	return nil
+}
+
+// fetchBehavior returns the first behavior of the agent that match accepts,
+// or nil when there is none or the agent no longer exists.
+func (r *AiAgentBehaviorResource) fetchBehavior(
+	ctx context.Context,
+	agentID string,
+	match func(aiagentgql.Behavior) bool,
+) (*aiagentgql.Behavior, error) {
+	agent, err := fetchAiAgent(ctx, r.api, agentID)
+	if err != nil || agent == nil {
+		return nil, err
+	}
+	for _, behavior := range agent.Behaviors {
+		if match(behavior) {
+			return &behavior, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/aiagentgql"
+)
+
+// actionPlaceholder matches {{action:NAME}} in an instruction template, where
+// NAME is the key of one of the behavior's actions.
+var actionPlaceholder = regexp.MustCompile(`\{\{\s*action:\s*([^{}]*?)\s*\}\}`)
+
+func instructionForAPI(instruction string, references []string) string {
+	result := instruction
+	for _, reference := range references {
+		result += "\n" + aiagentgql.ActionReference(reference)
+	}
+	return result
+}
+
+// renderInstruction resolves the placeholders of template to Pipefy action
+// references, then appends the references of the actions it does not mention,
+// sorted by name, as instructions without placeholders have always had.
+// unknown lists the placeholder names that match no action, and unused the
+// actions the template does not mention.
+func renderInstruction(
+	template string,
+	actions map[string]AiAgentActionModel,
+) (rendered string, unknown, unused []string) {
+	mentioned := map[string]bool{}
+	rendered = actionPlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		name := actionPlaceholder.FindStringSubmatch(placeholder)[1]
+		action, ok := actions[name]
+		if !ok {
+			if !mentioned[name] {
+				unknown = append(unknown, name)
+			}
+			mentioned[name] = true
+			return placeholder
+		}
+		mentioned[name] = true
+		return aiagentgql.ActionReference(action.ReferenceID.ValueString())
+	})
+	var references []string
+	for _, name := range sortedKeys(actions) {
+		if !mentioned[name] {
+			unused = append(unused, name)
+			references = append(references, actions[name].ReferenceID.ValueString())
+		}
+	}
+	return instructionForAPI(rendered, references), unknown, unused
+}
+
+// template returns the instruction template of the behavior, reading
+// instruction_file when it is set. known is false while either is unknown.
+func (behavior AiAgentBehaviorModel) template() (template string, known bool, err error) {
+	switch {
+	case behavior.Instruction.IsUnknown() || behavior.InstructionFile.IsUnknown():
+		return "", false, nil
+	case hasString(behavior.InstructionFile):
+		content, err := os.ReadFile(behavior.InstructionFile.ValueString())
+		if err != nil {
+			return "", false, err
+		}
+		return string(content), true, nil
+	default:
+		return behavior.Instruction.ValueString(), true, nil
+	}
+}
+
+// planInstruction sets the planned instruction_hash of behavior and checks its
+// template: placeholders that name no action are errors, and actions a
+// template with placeholders does not mention are warnings.
+func planInstruction(behavior *AiAgentBehaviorModel, at path.Path, diags *diag.Diagnostics) {
+	behavior.InstructionHash = types.StringUnknown()
+	templatePath := at.AtName("instruction")
+	if hasString(behavior.InstructionFile) {
+		templatePath = at.AtName("instruction_file")
+	}
+	template, known, err := behavior.template()
+	if err != nil {
+		diags.AddAttributeError(templatePath, "Unreadable AI agent instruction file", err.Error())
+		return
+	}
+	if !known {
+		return
+	}
+	behavior.InstructionHash = types.StringValue(contentHash([]byte(template)))
+	_, unknown, unused := renderInstruction(template, behavior.Actions)
+	if len(unknown) > 0 {
+		diags.AddAttributeError(templatePath, "Unknown AI agent action reference",
+			fmt.Sprintf("the instruction refers to %s, which the behavior does not define; defined actions: %s",
+				quotedList(unknown), quotedList(sortedKeys(behavior.Actions))))
+	}
+	if len(unused) > 0 && actionPlaceholder.MatchString(template) {
+		diags.AddAttributeWarning(templatePath, "Unused AI agent action",
+			fmt.Sprintf("the instruction does not mention %s; their references are appended at the end",
+				quotedList(unused)))
+	}
+}
+
+func quotedList(values []string) string {
+	quoted := make([]string, len(values))
+	for index, value := range values {
+		quoted[index] = fmt.Sprintf("%q", value)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// keepInstructionSource carries the configured template of prior over to
+// refreshed when it still renders to the instruction Pipefy returned, so
+// equivalent spellings and instruction_file do not show as drift.
+func keepInstructionSource(refreshed *AiAgentBehaviorModel, prior AiAgentBehaviorModel, apiInstruction string) {
+	refreshed.InstructionFile = prior.InstructionFile
+	text := refreshed.Instruction.ValueString()
+	if template, known, err := prior.template(); err == nil && known {
+		if rendered, _, _ := renderInstruction(template, refreshed.Actions); rendered == apiInstruction {
+			text = template
+		}
+	}
+	refreshed.InstructionHash = types.StringValue(contentHash([]byte(text)))
+	refreshed.Instruction = types.StringValue(text)
+	if hasString(prior.InstructionFile) {
+		refreshed.Instruction = types.StringNull()
+	}
+}
@@ -4,12 +4,21 @@
 package resources
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
 	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/aiagentgql"
 )
 
 func TestStringSetRoundTripIgnoresOrder(t *testing.T) {
@@ -25,16 +34,17 @@ func TestStringSetRoundTripIgnoresOrder(t *testing.T) {
 }
 
 func TestEnsureActionReferenceIDsIsStable(t *testing.T) {
-	model := aiAgentModelWithActions(
-		AiAgentActionModel{ReferenceID: types.StringUnknown()},
-		AiAgentActionModel{ReferenceID: types.StringValue("existing-reference")},
-	)
+	model := aiAgentModelWithActions(map[string]AiAgentActionModel{
+		"New":      {ReferenceID: types.StringUnknown()},
+		"Existing": {ReferenceID: types.StringValue("existing-reference")},
+	})
 	if err := ensureActionReferenceIDs(&model); err != nil {
 		t.Fatalf("generate reference IDs: %v", err)
 	}
-	first := model.Behaviors[0].Actions[0].ReferenceID.ValueString()
-	if first == "" || model.Behaviors[0].Actions[1].ReferenceID.ValueString() != "existing-reference" {
-		t.Fatalf("unexpected references after generation: %#v", model.Behaviors[0].Actions)
+	actions := model.Behaviors["B"].Actions
+	first := actions["New"].ReferenceID.ValueString()
+	if first == "" || actions["Existing"].ReferenceID.ValueString() != "existing-reference" {
+		t.Fatalf("unexpected references after generation: %#v", actions)
 	}
 	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(first) {
 		t.Fatalf("generated reference %q is not a UUIDv4", first)
@@ -42,7 +52,7 @@ func TestEnsureActionReferenceIDsIsStable(t *testing.T) {
 	if err := ensureActionReferenceIDs(&model); err != nil {
 		t.Fatalf("regenerate reference IDs: %v", err)
 	}
-	if model.Behaviors[0].Actions[0].ReferenceID.ValueString() != first {
+	if model.Behaviors["B"].Actions["New"].ReferenceID.ValueString() != first {
 		t.Fatal("generated reference ID changed between calls")
 	}
 }
@@ -54,16 +64,105 @@ func TestBehaviorInstructionRoundTrip(t *testing.T) {
 	if !strings.HasSuffix(apiValue, wantSuffix) {
 		t.Fatalf("API instruction %q missing suffix %q", apiValue, wantSuffix)
 	}
-	if got := normalizeBehaviorInstruction(apiValue, references); got != "Analyze the card" {
+	actions := []aiagentgql.Action{{Name: "B", ReferenceID: "reference-2"}, {Name: "A", ReferenceID: "reference-1"}}
+	params := aiagentgql.AIBehaviorParams{Instruction: apiValue, Actions: actions}
+	if got := params.Template(); got != "Analyze the card" {
 		t.Fatalf("normalized instruction = %q", got)
 	}
 }
 
-func TestBehaviorInstructionPreservesUserContent(t *testing.T) {
-	const authored = "Keep %{action:user-authored} in the prompt"
-	got := normalizeBehaviorInstruction(authored, []string{"generated-reference"})
-	if got != authored {
-		t.Fatalf("normalization changed user content: %q", got)
+func TestRenderInstructionResolvesActionNames(t *testing.T) {
+	actions := map[string]AiAgentActionModel{
+		"Move to Ready": {ReferenceID: types.StringValue("ref-move")},
+		"Notify":        {ReferenceID: types.StringValue("ref-notify")},
+		"Archive":       {ReferenceID: types.StringValue("ref-archive")},
+	}
+	rendered, unknown, unused := renderInstruction(
+		"Use {{action:Move to Ready}} or {{ action: Notify }}, never {{action:Close}}.", actions,
+	)
+	want := "Use %{action:ref-move} or %{action:ref-notify}, never {{action:Close}}.\n%{action:ref-archive}"
+	if rendered != want {
+		t.Fatalf("rendered = %q, want %q", rendered, want)
+	}
+	if !reflect.DeepEqual(unknown, []string{"Close"}) || !reflect.DeepEqual(unused, []string{"Archive"}) {
+		t.Fatalf("unknown = %v, unused = %v", unknown, unused)
+	}
+	apiActions := []aiagentgql.Action{
+		{Name: "Move to Ready", ReferenceID: "ref-move"},
+		{Name: "Notify", ReferenceID: "ref-notify"},
+		{Name: "Archive", ReferenceID: "ref-archive"},
+	}
+	got := aiagentgql.AIBehaviorParams{Instruction: rendered, Actions: apiActions}.Template()
+	if got != "Use {{action:Move to Ready}} or {{action:Notify}}, never {{action:Close}}." {
+		t.Fatalf("normalized = %q", got)
+	}
+}
+
+func TestPlanInstruction(t *testing.T) {
+	actions := map[string]AiAgentActionModel{"Move": {}, "Notify": {}}
+	file := filepath.Join(t.TempDir(), "prompt.txt")
+	if err := os.WriteFile(file, []byte("Route with {{action:Move}}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cases := map[string]struct {
+		behavior         AiAgentBehaviorModel
+		errors, warnings int
+	}{
+		"legacy instruction": {
+			behavior: AiAgentBehaviorModel{Instruction: types.StringValue("Route the card")},
+		},
+		"unknown action": {
+			behavior: AiAgentBehaviorModel{Instruction: types.StringValue("{{action:Move}} {{action:Mvoe}}")},
+			errors:   1, warnings: 1,
+		},
+		"file with unused action": {
+			behavior: AiAgentBehaviorModel{Instruction: types.StringNull(), InstructionFile: types.StringValue(file)},
+			warnings: 1,
+		},
+		"missing file": {
+			behavior: AiAgentBehaviorModel{Instruction: types.StringNull(), InstructionFile: types.StringValue(file + ".missing")},
+			errors:   1,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			behavior := tc.behavior
+			behavior.Actions = actions
+			planInstruction(&behavior, path.Root("behaviors").AtMapKey("B"), &diags)
+			if diags.ErrorsCount() != tc.errors || diags.WarningsCount() != tc.warnings {
+				t.Fatalf("got %d errors and %d warnings: %v", diags.ErrorsCount(), diags.WarningsCount(), diags)
+			}
+			if tc.errors == 0 {
+				template, _, _ := behavior.template()
+				if behavior.InstructionHash.ValueString() != contentHash([]byte(template)) {
+					t.Fatalf("instruction_hash = %s", behavior.InstructionHash)
+				}
+			}
+		})
+	}
+}
+
+func TestKeepInstructionSourceFollowsFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "prompt.txt")
+	if err := os.WriteFile(file, []byte("Route\n{{action:Move}}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	actions := map[string]AiAgentActionModel{"Move": {ReferenceID: types.StringValue("ref-move")}}
+	prior := AiAgentBehaviorModel{Instruction: types.StringNull(), InstructionFile: types.StringValue(file)}
+	refreshed := AiAgentBehaviorModel{Instruction: types.StringValue("Route"), Actions: actions}
+	keepInstructionSource(&refreshed, prior, "Route\n%{action:ref-move}")
+	if !refreshed.Instruction.IsNull() || refreshed.InstructionFile.ValueString() != file {
+		t.Fatalf("instruction source not kept: %#v", refreshed)
+	}
+	if refreshed.InstructionHash.ValueString() != contentHash([]byte("Route\n{{action:Move}}")) {
+		t.Fatalf("instruction_hash should match the file, got %s", refreshed.InstructionHash)
+	}
+
+	refreshed = AiAgentBehaviorModel{Instruction: types.StringValue("Edited in the UI"), Actions: actions}
+	keepInstructionSource(&refreshed, prior, "Edited in the UI\n%{action:ref-move}")
+	if refreshed.InstructionHash.ValueString() != contentHash([]byte("Edited in the UI")) {
+		t.Fatalf("remote edit should change instruction_hash, got %s", refreshed.InstructionHash)
 	}
 }
 
@@ -74,11 +173,11 @@ func TestActionShapeErrors(t *testing.T) {
 	}{
 		"move missing destination": {
 			action: actionModel("move_card"),
-			want:   `behavior[0].actions[0] action_type "move_card"`,
+			want:   `behaviors["On create"].actions["Move"] action_type "move_card"`,
 		},
 		"move rejects field metadata": {
 			action: actionModel("move_card", withDestination("phase-2"), withPipeID("55"), withFields()),
-			want:   "not pipe_id or fields",
+			want:   "does not accept pipe_id or fields",
 		},
 		"update missing fields": {
 			action: actionModel("update_card", withPipeID("55")),
@@ -88,14 +187,30 @@ func TestActionShapeErrors(t *testing.T) {
 			action: actionModel("create_card", withFields()),
 			want:   "requires pipe_id and at least one field",
 		},
-		"unsupported": {
+		"email template missing": {
 			action: actionModel("send_email_template"),
-			want:   `"send_email_template"; expected one of`,
+			want:   "requires email_template_id",
+		},
+		"table record missing connector": {
+			action: actionModel("update_table_record", withTable("table-1", ""), withFields()),
+			want:   "requires table_id, connector_field_id and at least one field",
+		},
+		"table record rejects pipe": {
+			action: actionModel("update_table_record", withTable("table-1", "customer"), withPipeID("55"), withFields()),
+			want:   "does not accept pipe_id",
+		},
+		"connected card rejects email template": {
+			action: actionModel("create_connected_card", withPipeID("55"), withFields(), withEmailTemplate("template-1")),
+			want:   "does not accept email_template_id",
+		},
+		"unsupported": {
+			action: actionModel("archive_card"),
+			want:   `"archive_card"; expected one of`,
 		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			err := actionShapeError(0, 0, tc.action)
+			err := actionShapeError(`behaviors["On create"].actions["Move"]`, tc.action)
 			if err == nil || !strings.Contains(err.Error(), tc.want) {
 				t.Fatalf("error = %v, want containing %q", err, tc.want)
 			}
@@ -108,9 +223,12 @@ func TestActionShapeAcceptsSupportedMetadata(t *testing.T) {
 		actionModel("move_card", withDestination("phase-2")),
 		actionModel("update_card", withPipeID("55"), withFields()),
 		actionModel("create_card", withPipeID("56"), withFields()),
+		actionModel("create_connected_card", withPipeID("57"), withFields()),
+		actionModel("send_email_template", withEmailTemplate("template-1")),
+		actionModel("update_table_record", withTable("table-1", "customer"), withFields()),
 	}
 	for index, action := range actions {
-		if err := actionShapeError(0, index, action); err != nil {
+		if err := actionShapeError(`actions["A"]`, action); err != nil {
 			t.Errorf("action %d rejected: %v", index, err)
 		}
 	}
@@ -123,7 +241,7 @@ func TestActionShapeDefersWhenMetadataUnknown(t *testing.T) {
 		actionModel("create_card", withUnknownPipeID(), withFields()),
 	}
 	for index, action := range actions {
-		if err := actionShapeError(0, index, action); err != nil {
+		if err := actionShapeError(`actions["A"]`, action); err != nil {
 			t.Errorf("action %d rejected unknown metadata: %v", index, err)
 		}
 	}
@@ -156,20 +274,20 @@ func TestFieldsGraphQLInputAlwaysSendsValue(t *testing.T) {
 }
 
 func TestNormalizeEmptyEventParams(t *testing.T) {
-	model := AiAgentModel{Behaviors: []AiAgentBehaviorModel{{
+	model := AiAgentModel{Behaviors: map[string]AiAgentBehaviorModel{"B": {
 		EventParams: &AiAgentEventParamsModel{
 			ToPhaseID:       types.StringNull(),
 			TriggerFieldIDs: stringsToSet(nil),
 		},
 	}}}
 	normalizeEmptyEventParams(&model)
-	if model.Behaviors[0].EventParams != nil {
-		t.Fatalf("empty event_params should normalize to nil, got %#v", model.Behaviors[0].EventParams)
+	if model.Behaviors["B"].EventParams != nil {
+		t.Fatalf("empty event_params should normalize to nil, got %#v", model.Behaviors["B"].EventParams)
 	}
 }
 
 func TestNormalizeEmptyEventParamsDefersUnknownTriggerFields(t *testing.T) {
-	model := AiAgentModel{Behaviors: []AiAgentBehaviorModel{{
+	model := AiAgentModel{Behaviors: map[string]AiAgentBehaviorModel{"B": {
 		EventParams: &AiAgentEventParamsModel{
 			ToPhaseID: types.StringNull(),
 			TriggerFieldIDs: types.SetValueMust(types.StringType, []attr.Value{
@@ -178,52 +296,203 @@ func TestNormalizeEmptyEventParamsDefersUnknownTriggerFields(t *testing.T) {
 		},
 	}}}
 	normalizeEmptyEventParams(&model)
-	if model.Behaviors[0].EventParams == nil {
+	if model.Behaviors["B"].EventParams == nil {
 		t.Fatal("event_params with unknown trigger_field_ids must not be cleared")
 	}
 }
 
-func TestRematchNestedIdentitiesOnReorder(t *testing.T) {
-	state := AiAgentModel{Behaviors: []AiAgentBehaviorModel{{
-		ID: types.StringValue("behavior-1"), Name: types.StringValue("On create"),
-		EventID: types.StringValue("card_created"),
-		Actions: []AiAgentActionModel{
-			{
+func TestKeepNestedIdentitiesFollowsNames(t *testing.T) {
+	state := AiAgentModel{Behaviors: map[string]AiAgentBehaviorModel{"On create": {
+		ID: types.StringValue("behavior-1"), EventID: types.StringValue("card_created"),
+		Actions: map[string]AiAgentActionModel{
+			"Move": {
 				ID: types.StringValue("action-1"), ReferenceID: types.StringValue("ref-move"),
-				Name: types.StringValue("Move"), ActionType: types.StringValue("move_card"),
-				DestinationPhaseID: types.StringValue("phase-2"),
-			},
-			{
-				ID: types.StringValue("action-2"), ReferenceID: types.StringValue("ref-update"),
-				Name: types.StringValue("Update"), ActionType: types.StringValue("update_card"),
-				PipeID: types.StringValue("42"),
+				ActionType: types.StringValue("move_card"),
 			},
 		},
 	}}}
-	// Simulate UseStateForUnknown copying by index after a reorder.
-	plan := AiAgentModel{Behaviors: []AiAgentBehaviorModel{{
-		ID: types.StringValue("behavior-1"), Name: types.StringValue("On create"),
-		EventID: types.StringValue("card_created"),
-		Actions: []AiAgentActionModel{
-			{
-				ID: types.StringValue("action-1"), ReferenceID: types.StringValue("ref-move"),
-				Name: types.StringValue("Update"), ActionType: types.StringValue("update_card"),
-				PipeID: types.StringValue("42"),
+	unknownAction := AiAgentActionModel{ID: types.StringUnknown(), ReferenceID: types.StringUnknown()}
+	plan := AiAgentModel{Behaviors: map[string]AiAgentBehaviorModel{
+		"A new behavior": {
+			ID: types.StringUnknown(), Actions: map[string]AiAgentActionModel{"Move": unknownAction},
+		},
+		"On create": {
+			ID: types.StringUnknown(), Actions: map[string]AiAgentActionModel{
+				"Move": unknownAction, "Update": unknownAction,
 			},
-			{
-				ID: types.StringValue("action-2"), ReferenceID: types.StringValue("ref-update"),
+		},
+	}}
+	keepNestedIdentities(&plan, state)
+	kept := plan.Behaviors["On create"]
+	if kept.ID.ValueString() != "behavior-1" || kept.Actions["Move"].ReferenceID.ValueString() != "ref-move" {
+		t.Fatalf("existing behavior lost its identity: %#v", kept)
+	}
+	if !kept.Actions["Update"].ReferenceID.IsUnknown() {
+		t.Fatalf("new action inherited an identity: %#v", kept.Actions["Update"])
+	}
+	added := plan.Behaviors["A new behavior"]
+	if !added.ID.IsUnknown() || !added.Actions["Move"].ReferenceID.IsUnknown() {
+		t.Fatalf("new behavior inherited an identity: %#v", added)
+	}
+}
+
+func TestBehaviorsToModelKeepsDuplicateNames(t *testing.T) {
+	behaviors := behaviorsToModel([]aiagentgql.Behavior{
+		{ID: "behavior-1", Name: "Route"},
+		{ID: "behavior-2", Name: "Route"},
+	}, nil)
+	if len(behaviors) != 2 || behaviors["Route"].ID.ValueString() != "behavior-1" ||
+		behaviors["Route#behavior-2"].ID.ValueString() != "behavior-2" {
+		t.Fatalf("duplicate behavior names not kept apart: %#v", behaviors)
+	}
+}
+
+func TestApplyGraphQLImportsBehaviors(t *testing.T) {
+	agent := aiagentgql.Agent{UUID: "agent-uuid", Name: "Triage", Behaviors: []aiagentgql.Behavior{
+		{ID: "behavior-1", Name: "On create", EventID: "card_created"},
+	}}
+
+	imported := AiAgentModel{Name: types.StringNull()}
+	imported.applyGraphQL(agent)
+	if _, ok := imported.Behaviors["On create"]; !ok {
+		t.Fatalf("imported behaviors = %v, want On create", imported.Behaviors)
+	}
+
+	// A managed agent without behaviors leaves them to pipefy_ai_agent_behavior.
+	managed := AiAgentModel{Name: types.StringValue("Triage")}
+	managed.applyGraphQL(agent)
+	if managed.Behaviors != nil {
+		t.Fatalf("managed behaviors = %v, want nil", managed.Behaviors)
+	}
+}
+
+func TestGraphQLInputSortsByName(t *testing.T) {
+	action := func(reference string) AiAgentActionModel {
+		return AiAgentActionModel{ReferenceID: types.StringValue(reference), ActionType: types.StringValue("move_card")}
+	}
+	model := AiAgentModel{Behaviors: map[string]AiAgentBehaviorModel{
+		"Second": {Instruction: types.StringValue("I"), Actions: map[string]AiAgentActionModel{
+			"Zed": action("ref-z"), "Alpha": action("ref-a"),
+		}},
+		"First": {Instruction: types.StringValue("I"), Actions: map[string]AiAgentActionModel{"Only": action("ref-o")}},
+	}}
+	input, err := model.graphQLInput("pipe-uuid")
+	if err != nil {
+		t.Fatalf("graphQLInput: %v", err)
+	}
+	behaviors := input["behaviors"].([]map[string]any)
+	if behaviors[0]["name"] != "First" || behaviors[1]["name"] != "Second" {
+		t.Fatalf("behaviors not sorted by name: %v", behaviors)
+	}
+	params := behaviors[1]["actionParams"].(map[string]any)["aiBehaviorParams"].(map[string]any)
+	if got := params["instruction"]; got != "I\n%{action:ref-a}\n%{action:ref-z}" {
+		t.Fatalf("instruction = %q, want references in action name order", got)
+	}
+}
+
+func TestAiAgentUpgradeStateFromV0(t *testing.T) {
+	ctx := context.Background()
+	r := &AiAgentResource{}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	upgrader := r.UpgradeState(ctx)[0]
+
+	old := aiAgentModelV0{
+		ID: types.StringValue("agent-uuid"), PipeID: types.StringValue("42"),
+		Name: types.StringValue("Triage"), Instruction: types.StringValue("Classify"),
+		Active: types.BoolValue(true), DataSourceIDs: stringsToSet(nil),
+		Behaviors: []aiAgentBehaviorModelV0{{
+			ID: types.StringValue("behavior-1"), Name: types.StringValue("On create"),
+			EventID: types.StringValue("card_created"), Instruction: types.StringValue("Route"),
+			Actions: []aiAgentActionModelV0{{
+				ID: types.StringValue("action-1"), ReferenceID: types.StringValue("ref-move"),
 				Name: types.StringValue("Move"), ActionType: types.StringValue("move_card"),
-				DestinationPhaseID: types.StringValue("phase-2"),
+				DestinationPhaseID: types.StringValue("phase-2"), PipeID: types.StringNull(),
+			}},
+		}, {
+			ID: types.StringValue("behavior-2"), Name: types.StringValue("On update"),
+			EventID: types.StringValue("field_updated"), Instruction: types.StringValue("Copy"),
+			EventParams: &aiAgentEventParamsModelV0{
+				ToPhaseID: types.StringNull(), TriggerFieldIDs: stringsToSet([]string{"field-1"}),
 			},
+			Actions: []aiAgentActionModelV0{{
+				ID: types.StringValue("action-2"), ReferenceID: types.StringValue("ref-create"),
+				Name: types.StringValue("Create"), ActionType: types.StringValue("create_card"),
+				DestinationPhaseID: types.StringNull(), PipeID: types.StringValue("43"),
+				Fields: []aiAgentFieldModelV0{{
+					FieldID: types.StringValue("title"), InputMode: types.StringValue("fixed_value"),
+					Value: types.StringValue("Copied"),
+				}},
+			}},
+		}},
+	}
+	upgrade := func(old aiAgentModelV0) resource.UpgradeStateResponse {
+		priorState := tfsdk.State{Schema: *upgrader.PriorSchema}
+		if diags := priorState.Set(ctx, &old); diags.HasError() {
+			t.Fatalf("build prior state: %v", diags)
+		}
+		resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &priorState}, &resp)
+		return resp
+	}
+
+	resp := upgrade(old)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("upgrade failed: %v", resp.Diagnostics)
+	}
+	var got AiAgentModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &got)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read upgraded state: %v", resp.Diagnostics)
+	}
+	action := got.Behaviors["On create"].Actions["Move"]
+	if got.Behaviors["On create"].ID.ValueString() != "behavior-1" ||
+		action.ReferenceID.ValueString() != "ref-move" || action.DestinationPhaseID.ValueString() != "phase-2" {
+		t.Fatalf("upgraded state lost identities: %#v", got.Behaviors)
+	}
+	onUpdate := got.Behaviors["On update"]
+	create := onUpdate.Actions["Create"]
+	if onUpdate.EventParams == nil || !onUpdate.EventParams.TriggerFieldIDs.Equal(stringsToSet([]string{"field-1"})) ||
+		len(create.Fields) != 1 || create.Fields[0].Value.ValueString() != "Copied" {
+		t.Fatalf("upgraded state lost event params or fields: %#v", onUpdate)
+	}
+
+	old.Behaviors = append(old.Behaviors, old.Behaviors[0])
+	if resp := upgrade(old); !resp.Diagnostics.HasError() {
+		t.Fatal("duplicate behavior names should fail the upgrade")
+	}
+}
+
+// Version 0 appended action references in list order, so a behavior whose
+// actions were not in name order must still refresh without an instruction
+// diff after the upgrade.
+func TestAiAgentUpgradeFromV0RefreshesUnsortedActions(t *testing.T) {
+	var diags diag.Diagnostics
+	upgraded := behaviorsFromV0([]aiAgentBehaviorModelV0{{
+		ID: types.StringValue("behavior-1"), Name: types.StringValue("On create"),
+		EventID: types.StringValue("card_created"), Instruction: types.StringValue("Route"),
+		Actions: []aiAgentActionModelV0{
+			{ID: types.StringValue("action-1"), ReferenceID: types.StringValue("ref-move"), Name: types.StringValue("Move"), ActionType: types.StringValue("move_card")},
+			{ID: types.StringValue("action-2"), ReferenceID: types.StringValue("ref-archive"), Name: types.StringValue("Archive"), ActionType: types.StringValue("move_card")},
 		},
-	}}}
-	rematchNestedIdentities(&plan, state)
-	actions := plan.Behaviors[0].Actions
-	if actions[0].ReferenceID.ValueString() != "ref-update" || actions[0].ID.ValueString() != "action-2" {
-		t.Fatalf("reordered Update kept wrong identity: %#v", actions[0])
+	}}, &diags)
+	if diags.HasError() {
+		t.Fatalf("upgrade failed: %v", diags)
 	}
-	if actions[1].ReferenceID.ValueString() != "ref-move" || actions[1].ID.ValueString() != "action-1" {
-		t.Fatalf("reordered Move kept wrong identity: %#v", actions[1])
+	prior := upgraded["On create"]
+	refreshed := behaviorToModel(aiagentgql.Behavior{
+		ID: "behavior-1", Name: "On create", EventID: "card_created",
+		ActionParams: aiagentgql.BehaviorActionRoot{AIBehaviorParams: aiagentgql.AIBehaviorParams{
+			Instruction: "Route\n" + aiagentgql.ActionReference("ref-move") + "\n" + aiagentgql.ActionReference("ref-archive"),
+			Actions: []aiagentgql.Action{
+				{ID: "action-1", ReferenceID: "ref-move", Name: "Move", ActionType: "move_card"},
+				{ID: "action-2", ReferenceID: "ref-archive", Name: "Archive", ActionType: "move_card"},
+			},
+		}},
+	}, prior)
+	if !refreshed.Instruction.Equal(prior.Instruction) || !refreshed.InstructionHash.Equal(prior.InstructionHash) {
+		t.Fatalf("refreshed instruction = %s (hash %s), want %s (hash %s)",
+			refreshed.Instruction, refreshed.InstructionHash, prior.Instruction, prior.InstructionHash)
 	}
 }
 
@@ -261,6 +530,17 @@ func withPipeID(id string) actionOption {
 	return func(action *AiAgentActionModel) { action.PipeID = types.StringValue(id) }
 }
 
+func withEmailTemplate(id string) actionOption {
+	return func(action *AiAgentActionModel) { action.EmailTemplateID = types.StringValue(id) }
+}
+
+func withTable(tableID, connectorFieldID string) actionOption {
+	return func(action *AiAgentActionModel) {
+		action.TableID = types.StringValue(tableID)
+		action.ConnectorFieldID = types.StringValue(connectorFieldID)
+	}
+}
+
 func withUnknownPipeID() actionOption {
 	return func(action *AiAgentActionModel) { action.PipeID = types.StringUnknown() }
 }
@@ -273,6 +553,32 @@ func withFields() actionOption {
 	}
 }
 
-func aiAgentModelWithActions(actions ...AiAgentActionModel) AiAgentModel {
-	return AiAgentModel{Behaviors: []AiAgentBehaviorModel{{Actions: actions}}}
+func aiAgentModelWithActions(actions map[string]AiAgentActionModel) AiAgentModel {
+	return AiAgentModel{Behaviors: map[string]AiAgentBehaviorModel{"B": {Actions: actions}}}
+}
+
+func TestFieldValueError(t *testing.T) {
+	field := func(mode string, value types.String) AiAgentFieldModel {
+		return AiAgentFieldModel{FieldID: types.StringValue("title"), InputMode: types.StringValue(mode), Value: value}
+	}
+	cases := map[string]struct {
+		field AiAgentFieldModel
+		want  string
+	}{
+		"fill with value":    {field("fill_with_ai", types.StringValue("x")), "does not take a value"},
+		"fill without value": {field("fill_with_ai", types.StringNull()), ""},
+		"fixed without":      {field("fixed_value", types.StringValue("")), "requires a non-empty value"},
+		"fixed with value":   {field("fixed_value", types.StringValue("x")), ""},
+		"copy without":       {field("copy_from", types.StringNull()), "requires a non-empty value"},
+		"copy unknown":       {field("copy_from", types.StringUnknown()), ""},
+		"other mode":         {field("ask_user", types.StringNull()), ""},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := fieldValueError(tc.field)
+			if (tc.want == "") != (got == "") || !strings.Contains(got, tc.want) {
+				t.Fatalf("fieldValueError = %q, want %q", got, tc.want)
+			}
+		})
+	}
 }
@@ -6,7 +6,7 @@ package resources
 import (
 	"crypto/rand"
 	"fmt"
-	"strings"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -14,20 +14,25 @@ import (
 )
 
 func ensureActionReferenceIDs(model *AiAgentModel) error {
-	for behaviorIndex := range model.Behaviors {
-		actions := model.Behaviors[behaviorIndex].Actions
-		for actionIndex := range actions {
-			reference := actions[actionIndex].ReferenceID
-			if !reference.IsNull() && !reference.IsUnknown() && reference.ValueString() != "" {
-				continue
-			}
-			generated, err := generateActionReferenceID()
-			if err != nil {
-				return err
-			}
-			actions[actionIndex].ReferenceID = types.StringValue(generated)
+	for _, behavior := range model.Behaviors {
+		if err := ensureReferenceIDs(behavior.Actions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ensureReferenceIDs(actions map[string]AiAgentActionModel) error {
+	for actionName, action := range actions {
+		if hasString(action.ReferenceID) {
+			continue
 		}
-		model.Behaviors[behaviorIndex].Actions = actions
+		generated, err := generateActionReferenceID()
+		if err != nil {
+			return err
+		}
+		action.ReferenceID = types.StringValue(generated)
+		actions[actionName] = action
 	}
 	return nil
 }
@@ -48,73 +53,65 @@ func prepareCreatedPartialState(model *AiAgentModel) {
 	if model.Active.IsUnknown() {
 		model.Active = types.BoolValue(false)
 	}
-	for behaviorIndex := range model.Behaviors {
-		behavior := &model.Behaviors[behaviorIndex]
+	for behaviorName, behavior := range model.Behaviors {
 		if behavior.ID.IsUnknown() {
 			behavior.ID = types.StringNull()
 		}
-		for actionIndex := range behavior.Actions {
-			if behavior.Actions[actionIndex].ID.IsUnknown() {
-				behavior.Actions[actionIndex].ID = types.StringNull()
+		for actionName, action := range behavior.Actions {
+			if action.ID.IsUnknown() {
+				action.ID = types.StringNull()
+				behavior.Actions[actionName] = action
 			}
 		}
+		model.Behaviors[behaviorName] = behavior
 	}
 }
 
-func instructionForAPI(instruction string, references []string) string {
-	result := instruction
-	for _, reference := range references {
-		result += "\n%{action:" + reference + "}"
-	}
-	return result
-}
-
-func normalizeBehaviorInstruction(instruction string, references []string) string {
-	result := instruction
-	for index := len(references) - 1; index >= 0; index-- {
-		suffix := "\n%{action:" + references[index] + "}"
-		if !strings.HasSuffix(result, suffix) {
-			return instruction
-		}
-		result = strings.TrimSuffix(result, suffix)
-	}
-	return result
-}
-
-func (model AiAgentModel) graphQLInput(repoUUID string) map[string]any {
+func (model AiAgentModel) graphQLInput(repoUUID string) (map[string]any, error) {
 	input := map[string]any{
 		"name":          model.Name.ValueString(),
 		"instruction":   model.Instruction.ValueString(),
 		"repoUuid":      repoUUID,
 		"dataSourceIds": stringSetValues(model.DataSourceIDs),
 	}
-	behaviors := make([]map[string]any, len(model.Behaviors))
-	for index, behavior := range model.Behaviors {
-		behaviors[index] = behavior.graphQLInput()
+	names := sortedKeys(model.Behaviors)
+	behaviors := make([]map[string]any, len(names))
+	for index, name := range names {
+		behavior, err := model.Behaviors[name].graphQLInput(name)
+		if err != nil {
+			return nil, err
+		}
+		behaviors[index] = behavior
 	}
 	input["behaviors"] = behaviors
-	return input
+	return input, nil
 }
 
-func (behavior AiAgentBehaviorModel) graphQLInput() map[string]any {
+// graphQLInput sends the actions sorted by name, which also fixes the order of
+// the action references appended to the instruction.
+func (behavior AiAgentBehaviorModel) graphQLInput(name string) (map[string]any, error) {
+	template, _, err := behavior.template()
+	if err != nil {
+		return nil, fmt.Errorf("behavior %q: %w", name, err)
+	}
 	input := map[string]any{
-		"name": behavior.Name.ValueString(), "eventId": behavior.EventID.ValueString(),
+		"name": name, "eventId": behavior.EventID.ValueString(),
 	}
 	if hasString(behavior.ID) {
 		input["id"] = behavior.ID.ValueString()
 	}
 	addEventParams(input, behavior.EventParams)
-	actions := make([]map[string]any, len(behavior.Actions))
-	references := make([]string, len(behavior.Actions))
-	for index, action := range behavior.Actions {
-		actions[index] = action.graphQLInput()
-		references[index] = action.ReferenceID.ValueString()
+	actionNames := sortedKeys(behavior.Actions)
+	actions := make([]map[string]any, len(actionNames))
+	for index, actionName := range actionNames {
+		actions[index] = behavior.Actions[actionName].graphQLInput(actionName)
 	}
+	instruction, _, _ := renderInstruction(template, behavior.Actions)
 	input["actionParams"] = map[string]any{"aiBehaviorParams": map[string]any{
-		"instruction":       instructionForAPI(behavior.Instruction.ValueString(), references),
+		"instruction":       instruction,
 		"actionsAttributes": actions,
 	}}
-	return input
+	return input, nil
 }
 
 func addEventParams(input map[string]any, params *AiAgentEventParamsModel) {
@@ -135,9 +132,9 @@ func addEventParams(input map[string]any, params *AiAgentEventParamsModel) {
 	input["eventParams"] = eventParams
 }
 
-func (action AiAgentActionModel) graphQLInput() map[string]any {
+func (action AiAgentActionModel) graphQLInput(name string) map[string]any {
 	input := map[string]any{
-		"name": action.Name.ValueString(), "actionType": action.ActionType.ValueString(),
+		"name": name, "actionType": action.ActionType.ValueString(),
 		"referenceId": action.ReferenceID.ValueString(),
 	}
 	if hasString(action.ID) {
@@ -150,6 +147,15 @@ func (action AiAgentActionModel) graphQLInput() map[string]any {
 	if hasString(action.PipeID) {
 		metadata["pipeId"] = action.PipeID.ValueString()
 	}
+	if hasString(action.EmailTemplateID) {
+		metadata["emailTemplateId"] = action.EmailTemplateID.ValueString()
+	}
+	if hasString(action.TableID) {
+		metadata["tableId"] = action.TableID.ValueString()
+	}
+	if hasString(action.ConnectorFieldID) {
+		metadata["connectorFieldId"] = action.ConnectorFieldID.ValueString()
+	}
 	if len(action.Fields) > 0 {
 		metadata["fieldsAttributes"] = fieldsGraphQLInput(action.Fields)
 	}
@@ -188,39 +194,61 @@ func stringSetValues(values types.Set) []string {
 }
 
 func (model *AiAgentModel) applyGraphQL(agent aiagentgql.Agent) {
+	// A null name means the agent is being imported, which takes the
+	// behaviors too. It is checked before name is refreshed below.
+	importing := model.Name.IsNull()
 	model.ID = types.StringValue(agent.UUID)
 	model.Name = types.StringValue(agent.Name)
 	model.Instruction = types.StringValue(agent.Instruction)
 	model.Active = types.BoolValue(agent.DisabledAt == nil)
 	model.DataSourceIDs = stringsToSet(agent.DataSourceIDs)
-	model.Behaviors = behaviorsToModel(agent.Behaviors)
+	// Unset behaviors belong to pipefy_ai_agent_behavior resources.
+	if model.Behaviors != nil || importing {
+		model.Behaviors = behaviorsToModel(agent.Behaviors, model.Behaviors)
+	}
 }
 
-func behaviorsToModel(behaviors []aiagentgql.Behavior) []AiAgentBehaviorModel {
-	result := make([]AiAgentBehaviorModel, len(behaviors))
-	for index, behavior := range behaviors {
-		actions := actionsToModel(behavior.ActionParams.AIBehaviorParams.Actions)
-		references := actionReferenceStrings(actions)
-		result[index] = AiAgentBehaviorModel{
-			ID: types.StringValue(behavior.ID), Name: types.StringValue(behavior.Name),
-			EventID: types.StringValue(behavior.EventID),
-			Instruction: types.StringValue(normalizeBehaviorInstruction(
-				behavior.ActionParams.AIBehaviorParams.Instruction, references,
-			)),
-			EventParams: eventParamsToModel(behavior.EventParams), Actions: actions,
-		}
+// behaviorsToModel maps the behaviors read from Pipefy, keeping the
+// instruction source of the prior behavior with the same name.
+func behaviorsToModel(
+	behaviors []aiagentgql.Behavior,
+	prior map[string]AiAgentBehaviorModel,
+) map[string]AiAgentBehaviorModel {
+	if len(behaviors) == 0 {
+		return nil
+	}
+	result := make(map[string]AiAgentBehaviorModel, len(behaviors))
+	for _, behavior := range behaviors {
+		name := uniqueName(result, behavior.Name, behavior.ID)
+		result[name] = behaviorToModel(behavior, prior[name])
 	}
 	return result
 }
 
-func actionsToModel(actions []aiagentgql.Action) []AiAgentActionModel {
-	result := make([]AiAgentActionModel, len(actions))
-	for index, action := range actions {
-		result[index] = AiAgentActionModel{
+func behaviorToModel(behavior aiagentgql.Behavior, prior AiAgentBehaviorModel) AiAgentBehaviorModel {
+	params := behavior.ActionParams.AIBehaviorParams
+	refreshed := AiAgentBehaviorModel{
+		ID:          types.StringValue(behavior.ID),
+		EventID:     types.StringValue(behavior.EventID),
+		Instruction: types.StringValue(params.Template()),
+		EventParams: eventParamsToModel(behavior.EventParams),
+		Actions:     actionsToModel(params.Actions),
+	}
+	keepInstructionSource(&refreshed, prior, params.Instruction)
+	return refreshed
+}
+
+func actionsToModel(actions []aiagentgql.Action) map[string]AiAgentActionModel {
+	result := make(map[string]AiAgentActionModel, len(actions))
+	for _, action := range actions {
+		result[uniqueName(result, action.Name, action.ID)] = AiAgentActionModel{
 			ID: types.StringValue(action.ID), ReferenceID: types.StringValue(action.ReferenceID),
-			Name: types.StringValue(action.Name), ActionType: types.StringValue(action.ActionType),
+			ActionType:         types.StringValue(action.ActionType),
 			DestinationPhaseID: types.StringPointerValue(action.Metadata.DestinationPhaseID),
 			PipeID:             types.StringPointerValue(action.Metadata.PipeID),
+			EmailTemplateID:    types.StringPointerValue(action.Metadata.EmailTemplateID),
+			TableID:            types.StringPointerValue(action.Metadata.TableID),
+			ConnectorFieldID:   types.StringPointerValue(action.Metadata.ConnectorFieldID),
 			Fields:             fieldsToModel(action.Metadata.Fields),
 		}
 	}
@@ -261,22 +289,26 @@ func eventParamsToModel(params aiagentgql.EventParams) *AiAgentEventParamsModel
 }
 
 func normalizeEmptyEventParams(model *AiAgentModel) {
-	for index := range model.Behaviors {
-		params := model.Behaviors[index].EventParams
-		if params == nil {
-			continue
-		}
-		// Unknown values are not "empty": defer until apply-time known values exist.
-		if params.ToPhaseID.IsUnknown() || params.TriggerFieldIDs.IsUnknown() ||
-			setHasUnknownElements(params.TriggerFieldIDs) {
-			continue
-		}
-		if !hasString(params.ToPhaseID) && len(stringSetValues(params.TriggerFieldIDs)) == 0 {
-			model.Behaviors[index].EventParams = nil
+	for name, behavior := range model.Behaviors {
+		if isEmptyEventParams(behavior.EventParams) {
+			behavior.EventParams = nil
+			model.Behaviors[name] = behavior
 		}
 	}
 }
 
+func isEmptyEventParams(params *AiAgentEventParamsModel) bool {
+	if params == nil {
+		return false
+	}
+	// Unknown values are not "empty": defer until apply-time known values exist.
+	if params.ToPhaseID.IsUnknown() || params.TriggerFieldIDs.IsUnknown() ||
+		setHasUnknownElements(params.TriggerFieldIDs) {
+		return false
+	}
+	return !hasString(params.ToPhaseID) && len(stringSetValues(params.TriggerFieldIDs)) == 0
+}
+
 func setHasUnknownElements(values types.Set) bool {
 	if values.IsNull() || values.IsUnknown() {
 		return false
@@ -290,93 +322,53 @@ func setHasUnknownElements(values types.Set) bool {
 	return false
 }
 
-// rematchNestedIdentities copies API ids / reference_ids from prior state by
-// content identity so list insert/reorder does not inherit the wrong index.
-func rematchNestedIdentities(plan *AiAgentModel, state AiAgentModel) {
-	usedBehaviors := make([]bool, len(state.Behaviors))
-	for behaviorIndex := range plan.Behaviors {
-		planBehavior := &plan.Behaviors[behaviorIndex]
-		stateBehavior, ok := matchBehavior(planBehavior, state.Behaviors, usedBehaviors)
+// keepNestedIdentities carries API ids and reference_ids over from prior state
+// for behaviors and actions whose names are unchanged. Map keys make this the
+// same element however the configuration is reordered; a new or renamed entry
+// stays unknown until it is created.
+func keepNestedIdentities(plan *AiAgentModel, state AiAgentModel) {
+	for name, planBehavior := range plan.Behaviors {
+		stateBehavior, ok := state.Behaviors[name]
 		if !ok {
-			clearNestedIdentities(planBehavior)
 			continue
 		}
-		planBehavior.ID = stateBehavior.ID
-		rematchActions(planBehavior, stateBehavior)
-	}
-}
-
-func clearNestedIdentities(behavior *AiAgentBehaviorModel) {
-	behavior.ID = types.StringUnknown()
-	for index := range behavior.Actions {
-		behavior.Actions[index].ID = types.StringUnknown()
-		behavior.Actions[index].ReferenceID = types.StringUnknown()
-	}
-}
-
-func rematchActions(planBehavior *AiAgentBehaviorModel, stateBehavior AiAgentBehaviorModel) {
-	used := make([]bool, len(stateBehavior.Actions))
-	for index := range planBehavior.Actions {
-		planAction := &planBehavior.Actions[index]
-		stateAction, ok := matchAction(planAction, stateBehavior.Actions, used)
-		if !ok {
-			planAction.ID = types.StringUnknown()
-			planAction.ReferenceID = types.StringUnknown()
-			continue
+		if planBehavior.ID.IsUnknown() {
+			planBehavior.ID = stateBehavior.ID
 		}
-		planAction.ID = stateAction.ID
-		planAction.ReferenceID = stateAction.ReferenceID
-	}
-}
-
-func matchBehavior(
-	plan *AiAgentBehaviorModel,
-	state []AiAgentBehaviorModel,
-	used []bool,
-) (AiAgentBehaviorModel, bool) {
-	for index, candidate := range state {
-		if used[index] {
-			continue
-		}
-		if candidate.Name.Equal(plan.Name) && candidate.EventID.Equal(plan.EventID) {
-			used[index] = true
-			return candidate, true
-		}
-	}
-	return AiAgentBehaviorModel{}, false
-}
-
-func matchAction(
-	plan *AiAgentActionModel,
-	state []AiAgentActionModel,
-	used []bool,
-) (AiAgentActionModel, bool) {
-	for index, candidate := range state {
-		if used[index] {
-			continue
-		}
-		if actionIdentityEqual(*plan, candidate) {
-			used[index] = true
-			return candidate, true
+		for actionName, planAction := range planBehavior.Actions {
+			stateAction, ok := stateBehavior.Actions[actionName]
+			if !ok {
+				continue
+			}
+			if planAction.ID.IsUnknown() {
+				planAction.ID = stateAction.ID
+			}
+			if planAction.ReferenceID.IsUnknown() {
+				planAction.ReferenceID = stateAction.ReferenceID
+			}
+			planBehavior.Actions[actionName] = planAction
 		}
+		plan.Behaviors[name] = planBehavior
 	}
-	return AiAgentActionModel{}, false
 }
 
-func actionIdentityEqual(left, right AiAgentActionModel) bool {
-	return left.Name.Equal(right.Name) &&
-		left.ActionType.Equal(right.ActionType) &&
-		optionalStringEqual(left.DestinationPhaseID, right.DestinationPhaseID) &&
-		optionalStringEqual(left.PipeID, right.PipeID)
+// uniqueName returns name, or name#id when another entry read from Pipefy
+// already uses it, so duplicates created outside Terraform show up in the plan
+// instead of overwriting each other.
+func uniqueName[V any](seen map[string]V, name, id string) string {
+	if _, taken := seen[name]; !taken {
+		return name
+	}
+	return name + "#" + id
 }
 
-func optionalStringEqual(left, right types.String) bool {
-	leftEmpty := !hasString(left)
-	rightEmpty := !hasString(right)
-	if leftEmpty || rightEmpty {
-		return leftEmpty && rightEmpty
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
 	}
-	return left.ValueString() == right.ValueString()
+	sort.Strings(keys)
+	return keys
 }
 
 func stringsToSet(values []string) types.Set {
@@ -386,11 +378,3 @@ func stringsToSet(values []string) types.Set {
 	}
 	return types.SetValueMust(types.StringType, elements)
 }
-
-func actionReferenceStrings(actions []AiAgentActionModel) []string {
-	result := make([]string, len(actions))
-	for index, action := range actions {
-		result[index] = action.ReferenceID.ValueString()
-	}
-	return result
-}
@@ -0,0 +1,243 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/client"
+)
+
+const (
+	inputModeFillWithAI = "fill_with_ai"
+	inputModeFixedValue = "fixed_value"
+	inputModeCopyFrom   = "copy_from"
+)
+
+// readOnlyFieldTypes cannot be written by any action; aiUnfillableFieldTypes
+// hold references or files the model cannot produce as text.
+var (
+	readOnlyFieldTypes     = map[string]bool{"statement": true, "id": true}
+	aiUnfillableFieldTypes = map[string]bool{"attachment": true, "connector": true, "assignee_select": true}
+)
+
+// aiAgentReferenceChecker validates the phase and field IDs of a planned
+// agent against the pipes and tables they point at, loading each one once.
+type aiAgentReferenceChecker struct {
+	api    *client.ApiClient
+	loaded map[string]*repoFields
+	failed map[string]bool
+	diags  *diag.Diagnostics
+}
+
+func newAiAgentReferenceChecker(api *client.ApiClient, diags *diag.Diagnostics) *aiAgentReferenceChecker {
+	return &aiAgentReferenceChecker{
+		api: api, loaded: map[string]*repoFields{}, failed: map[string]bool{}, diags: diags,
+	}
+}
+
+// pipe returns the references of pipeID, or nil after warning once that the
+// pipe could not be loaded.
+func (c *aiAgentReferenceChecker) pipe(ctx context.Context, pipeID string) *repoFields {
+	return c.load(ctx, "pipe", pipeID, fetchPipeFields)
+}
+
+// table is pipe for database tables.
+func (c *aiAgentReferenceChecker) table(ctx context.Context, tableID string) *repoFields {
+	return c.load(ctx, "table", tableID, fetchTableFields)
+}
+
+func (c *aiAgentReferenceChecker) load(
+	ctx context.Context,
+	kind, id string,
+	fetch func(context.Context, *client.ApiClient, string) (*repoFields, error),
+) *repoFields {
+	key := kind + " " + id
+	if refs, ok := c.loaded[key]; ok || c.failed[key] {
+		return refs
+	}
+	refs, err := fetch(ctx, c.api, id)
+	if err != nil {
+		c.failed[key] = true
+		c.diags.AddWarning("AI agent references not validated",
+			fmt.Sprintf("could not load %s: %s", key, err.Error()))
+		return nil
+	}
+	c.loaded[key] = refs
+	return refs
+}
+
+func (c *aiAgentReferenceChecker) checkBehaviors(
+	ctx context.Context,
+	pipeID string,
+	behaviors map[string]AiAgentBehaviorModel,
+) {
+	for _, name := range sortedKeys(behaviors) {
+		behavior := behaviors[name]
+		behaviorPath := path.Root("behaviors").AtMapKey(name)
+		if params := behavior.EventParams; params != nil {
+			paramsPath := behaviorPath.AtName("event_params")
+			c.checkPhase(ctx, pipeID, params.ToPhaseID, paramsPath.AtName("to_phase_id"))
+			c.checkFieldSet(ctx, pipeID, params.TriggerFieldIDs, paramsPath.AtName("trigger_field_ids"))
+		}
+		for _, actionName := range sortedKeys(behavior.Actions) {
+			action := behavior.Actions[actionName]
+			actionPath := behaviorPath.AtName("actions").AtMapKey(actionName)
+			c.checkPhase(ctx, pipeID, action.DestinationPhaseID, actionPath.AtName("destination_phase_id"))
+			c.checkConnectorField(ctx, pipeID, action.ConnectorFieldID, actionPath.AtName("connector_field_id"))
+			switch {
+			case hasString(action.TableID):
+				target := c.table(ctx, action.TableID.ValueString())
+				c.checkFields(ctx, pipeID, target, "table "+action.TableID.ValueString(),
+					action.Fields, actionPath.AtName("fields"))
+			case hasString(action.PipeID):
+				target := c.pipe(ctx, action.PipeID.ValueString())
+				c.checkFields(ctx, pipeID, target, "pipe "+action.PipeID.ValueString(),
+					action.Fields, actionPath.AtName("fields"))
+			}
+		}
+	}
+}
+
+func (c *aiAgentReferenceChecker) checkPhase(ctx context.Context, pipeID string, phaseID types.String, at path.Path) {
+	if !hasString(phaseID) {
+		return
+	}
+	refs := c.pipe(ctx, pipeID)
+	if refs != nil && !refs.phases[phaseID.ValueString()] {
+		c.diags.AddAttributeError(at, "Unknown AI agent phase",
+			fmt.Sprintf("phase %s does not belong to pipe %s", phaseID.ValueString(), pipeID))
+	}
+}
+
+func (c *aiAgentReferenceChecker) checkFieldSet(ctx context.Context, pipeID string, fieldIDs types.Set, at path.Path) {
+	if fieldIDs.IsUnknown() || setHasUnknownElements(fieldIDs) {
+		return
+	}
+	ids := stringSetValues(fieldIDs)
+	if len(ids) == 0 {
+		return
+	}
+	refs := c.pipe(ctx, pipeID)
+	if refs == nil {
+		return
+	}
+	var missing []string
+	for _, id := range ids {
+		if !refs.hasField(id) {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		c.diags.AddAttributeError(at, "Unknown AI agent field",
+			fmt.Sprintf("field IDs %s do not exist in pipe %s", strings.Join(missing, ", "), pipeID))
+	}
+}
+
+// checkConnectorField validates that the connection field of a table record
+// action is a connector field of the agent's pipe.
+func (c *aiAgentReferenceChecker) checkConnectorField(
+	ctx context.Context,
+	pipeID string,
+	fieldID types.String,
+	at path.Path,
+) {
+	if !hasString(fieldID) {
+		return
+	}
+	refs := c.pipe(ctx, pipeID)
+	if refs == nil {
+		return
+	}
+	id := fieldID.ValueString()
+	switch fieldType, ok := refs.fieldTypes[id]; {
+	case !ok:
+		c.diags.AddAttributeError(at, "Unknown AI agent field",
+			fmt.Sprintf("field %s does not exist in pipe %s", id, pipeID))
+	case fieldType != "connector":
+		c.diags.AddAttributeError(at, "Incompatible AI agent field",
+			fmt.Sprintf("field %s is a %s field, not a connector field", id, fieldType))
+	}
+}
+
+// checkFields validates the target fields of an action against its target
+// pipe or table, and copy_from sources against the agent's pipe. target is
+// nil when it could not be loaded.
+func (c *aiAgentReferenceChecker) checkFields(
+	ctx context.Context,
+	agentPipeID string,
+	target *repoFields,
+	targetLabel string,
+	fields []AiAgentFieldModel,
+	at path.Path,
+) {
+	for index, field := range fields {
+		fieldPath := at.AtListIndex(index)
+		if target != nil && hasString(field.FieldID) {
+			c.checkTargetField(target, targetLabel, field, fieldPath)
+		}
+		copyFrom := hasString(field.InputMode) && field.InputMode.ValueString() == inputModeCopyFrom
+		if !copyFrom || !hasString(field.Value) {
+			continue
+		}
+		if refs := c.pipe(ctx, agentPipeID); refs != nil {
+			source := field.Value.ValueString()
+			if !refs.hasField(source) {
+				c.diags.AddAttributeError(fieldPath.AtName("value"), "Unknown AI agent field",
+					fmt.Sprintf("source field %s does not exist in pipe %s", source, agentPipeID))
+			}
+		}
+	}
+}
+
+func (c *aiAgentReferenceChecker) checkTargetField(
+	refs *repoFields,
+	targetLabel string,
+	field AiAgentFieldModel,
+	fieldPath path.Path,
+) {
+	fieldID := field.FieldID.ValueString()
+	fieldType, ok := refs.fieldTypes[fieldID]
+	fillWithAI := hasString(field.InputMode) && field.InputMode.ValueString() == inputModeFillWithAI
+	switch {
+	case !ok:
+		c.diags.AddAttributeError(fieldPath.AtName("field_id"), "Unknown AI agent field",
+			fmt.Sprintf("field %s does not exist in %s", fieldID, targetLabel))
+	case readOnlyFieldTypes[fieldType]:
+		c.diags.AddAttributeError(fieldPath.AtName("field_id"), "Incompatible AI agent field",
+			fmt.Sprintf("field %s is a %s field, which actions cannot write", fieldID, fieldType))
+	case fillWithAI && aiUnfillableFieldTypes[fieldType]:
+		c.diags.AddAttributeError(fieldPath.AtName("input_mode"), "Incompatible AI agent field",
+			fmt.Sprintf("field %s is a %s field, which cannot be filled with AI; use %s or %s",
+				fieldID, fieldType, inputModeFixedValue, inputModeCopyFrom))
+	}
+}
+
+// fieldValueError reports a value that does not fit the field's input_mode:
+// fill_with_ai takes no value, while fixed_value and copy_from need one. Other
+// modes are left to Pipefy.
+func fieldValueError(field AiAgentFieldModel) string {
+	if !hasString(field.InputMode) || field.Value.IsUnknown() {
+		return ""
+	}
+	mode := field.InputMode.ValueString()
+	switch mode {
+	case inputModeFillWithAI:
+		if hasString(field.Value) {
+			return fmt.Sprintf("input_mode %q does not take a value", mode)
+		}
+	case inputModeFixedValue, inputModeCopyFrom:
+		if !hasString(field.Value) {
+			return fmt.Sprintf("input_mode %q requires a non-empty value", mode)
+		}
+	}
+	return ""
+}
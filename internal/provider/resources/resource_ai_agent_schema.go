@@ -6,10 +6,13 @@ package resources
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -22,22 +25,24 @@ import (
 )
 
 type AiAgentModel struct {
-	ID            types.String           `tfsdk:"id"`
-	PipeID        types.String           `tfsdk:"pipe_id"`
-	Name          types.String           `tfsdk:"name"`
-	Instruction   types.String           `tfsdk:"instruction"`
-	Active        types.Bool             `tfsdk:"active"`
-	DataSourceIDs types.Set              `tfsdk:"data_source_ids"`
-	Behaviors     []AiAgentBehaviorModel `tfsdk:"behaviors"`
+	ID            types.String                    `tfsdk:"id"`
+	PipeID        types.String                    `tfsdk:"pipe_id"`
+	Name          types.String                    `tfsdk:"name"`
+	Instruction   types.String                    `tfsdk:"instruction"`
+	Active        types.Bool                      `tfsdk:"active"`
+	DataSourceIDs types.Set                       `tfsdk:"data_source_ids"`
+	Behaviors     map[string]AiAgentBehaviorModel `tfsdk:"behaviors"`
 }
 
+// AiAgentBehaviorModel is one behavior; its name is the key in behaviors.
 type AiAgentBehaviorModel struct {
-	ID          types.String             `tfsdk:"id"`
-	Name        types.String             `tfsdk:"name"`
-	EventID     types.String             `tfsdk:"event_id"`
-	Instruction types.String             `tfsdk:"instruction"`
-	EventParams *AiAgentEventParamsModel `tfsdk:"event_params"`
-	Actions     []AiAgentActionModel     `tfsdk:"actions"`
+	ID              types.String                  `tfsdk:"id"`
+	EventID         types.String                  `tfsdk:"event_id"`
+	Instruction     types.String                  `tfsdk:"instruction"`
+	InstructionFile types.String                  `tfsdk:"instruction_file"`
+	InstructionHash types.String                  `tfsdk:"instruction_hash"`
+	EventParams     *AiAgentEventParamsModel      `tfsdk:"event_params"`
+	Actions         map[string]AiAgentActionModel `tfsdk:"actions"`
 }
 
 type AiAgentEventParamsModel struct {
@@ -45,13 +50,16 @@ type AiAgentEventParamsModel struct {
 	TriggerFieldIDs types.Set    `tfsdk:"trigger_field_ids"`
 }
 
+// AiAgentActionModel is one behavior action; its name is the key in actions.
 type AiAgentActionModel struct {
 	ID                 types.String        `tfsdk:"id"`
 	ReferenceID        types.String        `tfsdk:"reference_id"`
-	Name               types.String        `tfsdk:"name"`
 	ActionType         types.String        `tfsdk:"action_type"`
 	DestinationPhaseID types.String        `tfsdk:"destination_phase_id"`
 	PipeID             types.String        `tfsdk:"pipe_id"`
+	EmailTemplateID    types.String        `tfsdk:"email_template_id"`
+	TableID            types.String        `tfsdk:"table_id"`
+	ConnectorFieldID   types.String        `tfsdk:"connector_field_id"`
 	Fields             []AiAgentFieldModel `tfsdk:"fields"`
 }
 
@@ -63,10 +71,14 @@ type AiAgentFieldModel struct {
 
 func (r *AiAgentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manages an AI agent and its ordered behaviors for a Pipefy pipe. " +
+		MarkdownDescription: "Manages an AI agent and its behaviors for a Pipefy pipe. " +
+			"Behaviors and their actions are keyed by name, so renaming one replaces it while " +
+			"adding or removing one leaves the others unchanged. Omit `behaviors` to manage them " +
+			"with `pipefy_ai_agent_behavior` resources instead; do not combine both for one agent. " +
 			"Behavior configuration is replaced in full on each update. When `active` is set, " +
 			"status is applied with a separate API call after that update; if the status call fails, " +
 			"the configuration change has already been applied.",
+		Version:    1,
 		Attributes: aiAgentAttributes(),
 	}
 }
@@ -90,26 +102,51 @@ func aiAgentAttributes() map[string]schema.Attribute {
 			PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
 		},
 		"data_source_ids": stringSetWithEmptyDefault(
-			"Knowledge-source IDs managed as the complete unordered agent-level set.",
+			"Knowledge-source IDs, such as `pipefy_ai_data_source` IDs, managed as the complete " +
+				"unordered agent-level set.",
 		),
-		"behaviors": behaviorListAttribute(),
+		"behaviors": behaviorMapAttribute(),
 	}
 }
 
-func behaviorListAttribute() schema.ListNestedAttribute {
-	return schema.ListNestedAttribute{
-		Required: true, Description: "Ordered AI-agent behaviors, managed as a complete list.",
-		Validators: []validator.List{listvalidator.SizeAtLeast(1)},
-		NestedObject: schema.NestedAttributeObject{Attributes: map[string]schema.Attribute{
-			"id":   computedStableString("The API identifier of the behavior."),
-			"name": requiredNonEmptyString("The behavior name."),
-			"event_id": requiredNonEmptyString(
-				"The Pipefy event ID. Current values are documented by the Pipefy API.",
-			),
-			"instruction":  requiredNonEmptyString("The instruction evaluated by this behavior."),
-			"event_params": eventParamsAttribute(),
-			"actions":      actionListAttribute(),
-		}},
+func behaviorMapAttribute() schema.MapNestedAttribute {
+	return schema.MapNestedAttribute{
+		Optional: true, Description: "AI-agent behaviors keyed by behavior name, managed as a complete map. " +
+			"When omitted, the agent's behaviors are left as they are and not tracked.",
+		Validators: []validator.Map{
+			mapvalidator.SizeAtLeast(1), mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
+		},
+		NestedObject: schema.NestedAttributeObject{Attributes: behaviorAttributes()},
+	}
+}
+
+func behaviorAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": computedStableString("The API identifier of the behavior."),
+		"event_id": requiredNonEmptyString(
+			"The Pipefy event ID. Current values are documented by the Pipefy API.",
+		),
+		"instruction": schema.StringAttribute{
+			Optional: true,
+			Description: "The instruction template evaluated by this behavior. `{{action:NAME}}` " +
+				"refers to the action with that name; actions it does not mention are referenced " +
+				"at the end. Exactly one of instruction and instruction_file must be set.",
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+				stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("instruction_file")),
+			},
+		},
+		"instruction_file": schema.StringAttribute{
+			Optional:    true,
+			Description: "Path of a file holding the instruction template, read at plan and apply time.",
+			Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+		},
+		"instruction_hash": schema.StringAttribute{
+			Computed:    true,
+			Description: "SHA-256 of the instruction template, so edits to it show in the plan.",
+		},
+		"event_params": eventParamsAttribute(),
+		"actions":      actionMapAttribute(),
 	}
 }
 
@@ -130,33 +167,50 @@ func eventParamsAttribute() schema.SingleNestedAttribute {
 	}
 }
 
-func actionListAttribute() schema.ListNestedAttribute {
-	return schema.ListNestedAttribute{
-		Required: true, Description: "Ordered actions available to the behavior.",
-		Validators: []validator.List{listvalidator.SizeAtLeast(1)},
-		NestedObject: schema.NestedAttributeObject{Attributes: map[string]schema.Attribute{
-			"id":           computedStableString("The API identifier of the action."),
-			"reference_id": computedStableString("The stable reference UUID sent to Pipefy."),
-			"name":         requiredNonEmptyString("The action name."),
-			"action_type": requiredNonEmptyString(
-				"The AI behavior action type. Supported values are defined by Pipefy; " +
-					"this provider currently manages a subset of those values. " +
-					"See the Pipefy API reference (https://developers.pipefy.com/reference).",
-			),
-			"destination_phase_id": schema.StringAttribute{
-				Optional: true, Description: "Destination phase required by move_card.",
-			},
-			"pipe_id": schema.StringAttribute{
-				Optional: true, Description: "Target pipe required by update_card and create_card.",
-			},
-			"fields": fieldListAttribute(),
-		}},
+func actionMapAttribute() schema.MapNestedAttribute {
+	return schema.MapNestedAttribute{
+		Required: true, Description: "Actions available to the behavior, keyed by action name.",
+		Validators: []validator.Map{
+			mapvalidator.SizeAtLeast(1), mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
+		},
+		NestedObject: schema.NestedAttributeObject{Attributes: actionAttributes()},
+	}
+}
+
+func actionAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id":           computedStableString("The API identifier of the action."),
+		"reference_id": computedStableString("The stable reference UUID sent to Pipefy."),
+		"action_type": requiredNonEmptyString(
+			"The AI behavior action type: move_card, update_card, create_card, " +
+				"create_connected_card, send_email_template or update_table_record. " +
+				"See the Pipefy API reference (https://developers.pipefy.com/reference).",
+		),
+		"destination_phase_id": schema.StringAttribute{
+			Optional: true, Description: "Destination phase required by move_card.",
+		},
+		"pipe_id": schema.StringAttribute{
+			Optional:    true,
+			Description: "Target pipe required by update_card, create_card and create_connected_card.",
+		},
+		"email_template_id": schema.StringAttribute{
+			Optional: true, Description: "Email template required by send_email_template.",
+		},
+		"table_id": schema.StringAttribute{
+			Optional: true, Description: "Database table required by update_table_record.",
+		},
+		"connector_field_id": schema.StringAttribute{
+			Optional: true,
+			Description: "Connection field of the card that holds the record to update; " +
+				"required by update_table_record.",
+		},
+		"fields": fieldListAttribute(),
 	}
 }
 
 func fieldListAttribute() schema.ListNestedAttribute {
 	return schema.ListNestedAttribute{
-		Optional: true, Description: "Ordered target field metadata for card actions.",
+		Optional: true, Description: "Ordered target field metadata for card and table record actions.",
 		NestedObject: schema.NestedAttributeObject{Attributes: map[string]schema.Attribute{
 			"field_id": requiredNonEmptyString("The target field ID."),
 			"input_mode": requiredNonEmptyString(
@@ -164,7 +218,7 @@ func fieldListAttribute() schema.ListNestedAttribute {
 					"see the Pipefy API reference (https://developers.pipefy.com/reference).",
 			),
 			"value": schema.StringAttribute{
-				Optional: true, Description: "Optional fixed value or source-field reference.",
+				Optional: true, Description: "The fixed value for `fixed_value` or the source field ID for `copy_from`; not used with `fill_with_ai`.",
 			},
 		}},
 	}
@@ -202,19 +256,18 @@ func (r *AiAgentResource) ValidateConfig(
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	for behaviorIndex, behavior := range model.Behaviors {
-		for actionIndex, action := range behavior.Actions {
-			err := actionShapeError(behaviorIndex, actionIndex, action)
-			if err == nil {
-				continue
-			}
-			actionPath := path.Root("behaviors").AtListIndex(behaviorIndex).
-				AtName("actions").AtListIndex(actionIndex)
-			resp.Diagnostics.AddAttributeError(actionPath, "Invalid AI agent action", err.Error())
+	for behaviorName, behavior := range model.Behaviors {
+		for actionName, action := range behavior.Actions {
+			actionPath := path.Root("behaviors").AtMapKey(behaviorName).
+				AtName("actions").AtMapKey(actionName)
+			location := fmt.Sprintf("behaviors[%q].actions[%q]", behaviorName, actionName)
+			validateAction(location, actionPath, action, &resp.Diagnostics)
 		}
 	}
 }
 
+// ModifyPlan checks the phases and fields a changed plan references against
+// their pipes, then carries nested identities over from state.
 func (r *AiAgentResource) ModifyPlan(
 	ctx context.Context,
 	req resource.ModifyPlanRequest,
@@ -228,6 +281,20 @@ func (r *AiAgentResource) ModifyPlan(
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if r.api != nil && hasString(plan.PipeID) && (req.State.Raw.IsNull() || !req.Plan.Raw.Equal(req.State.Raw)) {
+		checker := newAiAgentReferenceChecker(r.api, &resp.Diagnostics)
+		checker.checkBehaviors(ctx, plan.PipeID.ValueString(), plan.Behaviors)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	for name, behavior := range plan.Behaviors {
+		planInstruction(&behavior, path.Root("behaviors").AtMapKey(name), &resp.Diagnostics)
+		plan.Behaviors[name] = behavior
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	normalizeEmptyEventParams(&plan)
 	ensureComputedUnknowns(&plan)
 	if !req.State.Raw.IsNull() {
@@ -236,73 +303,122 @@ func (r *AiAgentResource) ModifyPlan(
 		if resp.Diagnostics.HasError() {
 			return
 		}
-		rematchNestedIdentities(&plan, state)
+		keepNestedIdentities(&plan, state)
 	}
 	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
 }
 
 func ensureComputedUnknowns(model *AiAgentModel) {
-	for behaviorIndex := range model.Behaviors {
-		behavior := &model.Behaviors[behaviorIndex]
+	for behaviorName, behavior := range model.Behaviors {
 		if behavior.ID.IsNull() {
 			behavior.ID = types.StringUnknown()
 		}
-		for actionIndex := range behavior.Actions {
-			action := &behavior.Actions[actionIndex]
+		for actionName, action := range behavior.Actions {
 			if action.ID.IsNull() {
 				action.ID = types.StringUnknown()
 			}
 			if action.ReferenceID.IsNull() {
 				action.ReferenceID = types.StringUnknown()
 			}
+			behavior.Actions[actionName] = action
 		}
+		model.Behaviors[behaviorName] = behavior
 	}
 }
 
-func actionShapeError(behaviorIndex, actionIndex int, action AiAgentActionModel) error {
-	if action.ActionType.IsUnknown() || action.ActionType.IsNull() {
-		return nil
+// validateAction reports an action whose metadata does not fit its type and,
+// on each field, a value that does not fit its input_mode.
+func validateAction(location string, actionPath path.Path, action AiAgentActionModel, diags *diag.Diagnostics) {
+	if err := actionShapeError(location, action); err != nil {
+		diags.AddAttributeError(actionPath, "Invalid AI agent action", err.Error())
 	}
-	prefix := fmt.Sprintf(
-		"behavior[%d].actions[%d] action_type %q",
-		behaviorIndex, actionIndex, action.ActionType.ValueString(),
-	)
-	switch action.ActionType.ValueString() {
-	case "move_card":
-		return validateMoveCardShape(prefix, action)
-	case "update_card", "create_card":
-		return validateCardFieldsShape(prefix, action)
-	default:
-		return fmt.Errorf(
-			"%s; expected one of move_card, update_card, create_card", prefix,
-		)
+	for index, field := range action.Fields {
+		if message := fieldValueError(field); message != "" {
+			diags.AddAttributeError(actionPath.AtName("fields").AtListIndex(index).AtName("value"),
+				"Invalid AI agent field value", message)
+		}
 	}
 }
 
-func validateMoveCardShape(prefix string, action AiAgentActionModel) error {
-	if action.DestinationPhaseID.IsUnknown() {
+// actionMetadata lists, per action type, the metadata attributes the action
+// requires. Every other metadata attribute must be left unset.
+var actionMetadata = map[string][]string{
+	"move_card":             {"destination_phase_id"},
+	"update_card":           {"pipe_id", "fields"},
+	"create_card":           {"pipe_id", "fields"},
+	"create_connected_card": {"pipe_id", "fields"},
+	"send_email_template":   {"email_template_id"},
+	"update_table_record":   {"table_id", "connector_field_id", "fields"},
+}
+
+var actionMetadataOrder = []string{
+	"move_card", "update_card", "create_card",
+	"create_connected_card", "send_email_template", "update_table_record",
+}
+
+// actionShapeError checks the metadata of one action; location names the
+// action in the message, such as behaviors["B"].actions["A"].
+func actionShapeError(location string, action AiAgentActionModel) error {
+	if action.ActionType.IsUnknown() || action.ActionType.IsNull() {
 		return nil
 	}
-	if !hasString(action.DestinationPhaseID) {
-		return fmt.Errorf("%s requires destination_phase_id", prefix)
+	prefix := fmt.Sprintf("%s action_type %q", location, action.ActionType.ValueString())
+	required, ok := actionMetadata[action.ActionType.ValueString()]
+	if !ok {
+		return fmt.Errorf("%s; expected one of %s", prefix, strings.Join(actionMetadataOrder, ", "))
+	}
+	values := map[string]types.String{
+		"destination_phase_id": action.DestinationPhaseID,
+		"pipe_id":              action.PipeID,
+		"email_template_id":    action.EmailTemplateID,
+		"table_id":             action.TableID,
+		"connector_field_id":   action.ConnectorFieldID,
+	}
+	for _, value := range values {
+		if value.IsUnknown() {
+			return nil
+		}
+	}
+	set := func(name string) bool {
+		if name == "fields" {
+			return len(action.Fields) > 0
+		}
+		return hasString(values[name])
+	}
+	labels := make([]string, len(required))
+	complete := true
+	for index, name := range required {
+		labels[index] = metadataLabel(name)
+		complete = complete && set(name)
 	}
-	if hasString(action.PipeID) || len(action.Fields) > 0 {
-		return fmt.Errorf("%s accepts destination_phase_id but not pipe_id or fields", prefix)
+	if !complete {
+		return fmt.Errorf("%s requires %s", prefix, joinWords(labels, "and"))
+	}
+	var extra []string
+	for _, name := range append(sortedKeys(values), "fields") {
+		if set(name) && !slices.Contains(required, name) {
+			extra = append(extra, name)
+		}
+	}
+	if len(extra) > 0 {
+		return fmt.Errorf("%s does not accept %s", prefix, joinWords(extra, "or"))
 	}
 	return nil
 }
 
-func validateCardFieldsShape(prefix string, action AiAgentActionModel) error {
-	if action.PipeID.IsUnknown() {
-		return nil
-	}
-	if !hasString(action.PipeID) || len(action.Fields) == 0 {
-		return fmt.Errorf("%s requires pipe_id and at least one field", prefix)
+func metadataLabel(name string) string {
+	if name == "fields" {
+		return "at least one field"
 	}
-	if hasString(action.DestinationPhaseID) {
-		return fmt.Errorf("%s does not accept destination_phase_id", prefix)
+	return name
+}
+
+// joinWords joins words as an English list, such as "a, b and c".
+func joinWords(words []string, conjunction string) string {
+	if len(words) == 1 {
+		return words[0]
 	}
-	return nil
+	return strings.Join(words[:len(words)-1], ", ") + " " + conjunction + " " + words[len(words)-1]
 }
 
 func hasString(value types.String) bool {
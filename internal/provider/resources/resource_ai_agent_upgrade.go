@@ -0,0 +1,201 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithUpgradeState = &AiAgentResource{}
+
+// aiAgentModelV0 is the state written while behaviors and their actions were
+// positional lists carrying their names as attributes.
+type aiAgentModelV0 struct {
+	ID            types.String             `tfsdk:"id"`
+	PipeID        types.String             `tfsdk:"pipe_id"`
+	Name          types.String             `tfsdk:"name"`
+	Instruction   types.String             `tfsdk:"instruction"`
+	Active        types.Bool               `tfsdk:"active"`
+	DataSourceIDs types.Set                `tfsdk:"data_source_ids"`
+	Behaviors     []aiAgentBehaviorModelV0 `tfsdk:"behaviors"`
+}
+
+type aiAgentBehaviorModelV0 struct {
+	ID          types.String               `tfsdk:"id"`
+	Name        types.String               `tfsdk:"name"`
+	EventID     types.String               `tfsdk:"event_id"`
+	Instruction types.String               `tfsdk:"instruction"`
+	EventParams *aiAgentEventParamsModelV0 `tfsdk:"event_params"`
+	Actions     []aiAgentActionModelV0     `tfsdk:"actions"`
+}
+
+type aiAgentEventParamsModelV0 struct {
+	ToPhaseID       types.String `tfsdk:"to_phase_id"`
+	TriggerFieldIDs types.Set    `tfsdk:"trigger_field_ids"`
+}
+
+type aiAgentActionModelV0 struct {
+	ID                 types.String          `tfsdk:"id"`
+	ReferenceID        types.String          `tfsdk:"reference_id"`
+	Name               types.String          `tfsdk:"name"`
+	ActionType         types.String          `tfsdk:"action_type"`
+	DestinationPhaseID types.String          `tfsdk:"destination_phase_id"`
+	PipeID             types.String          `tfsdk:"pipe_id"`
+	Fields             []aiAgentFieldModelV0 `tfsdk:"fields"`
+}
+
+type aiAgentFieldModelV0 struct {
+	FieldID   types.String `tfsdk:"field_id"`
+	InputMode types.String `tfsdk:"input_mode"`
+	Value     types.String `tfsdk:"value"`
+}
+
+// aiAgentAttributesV0 is the version 0 schema, frozen as written: it only
+// decodes old state, so it must not follow changes to the current schema.
+// Validators, defaults and plan modifiers play no part in decoding and are
+// left out.
+func aiAgentAttributesV0() map[string]schema.Attribute {
+	fields := map[string]schema.Attribute{
+		"field_id":   schema.StringAttribute{Required: true},
+		"input_mode": schema.StringAttribute{Required: true},
+		"value":      schema.StringAttribute{Optional: true},
+	}
+	actions := map[string]schema.Attribute{
+		"id":                   schema.StringAttribute{Computed: true},
+		"reference_id":         schema.StringAttribute{Computed: true},
+		"name":                 schema.StringAttribute{Required: true},
+		"action_type":          schema.StringAttribute{Required: true},
+		"destination_phase_id": schema.StringAttribute{Optional: true},
+		"pipe_id":              schema.StringAttribute{Optional: true},
+		"fields": schema.ListNestedAttribute{
+			Optional: true, NestedObject: schema.NestedAttributeObject{Attributes: fields},
+		},
+	}
+	behaviors := map[string]schema.Attribute{
+		"id":          schema.StringAttribute{Computed: true},
+		"name":        schema.StringAttribute{Required: true},
+		"event_id":    schema.StringAttribute{Required: true},
+		"instruction": schema.StringAttribute{Required: true},
+		"event_params": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{
+				"to_phase_id":       schema.StringAttribute{Optional: true},
+				"trigger_field_ids": schema.SetAttribute{Optional: true, ElementType: types.StringType},
+			},
+		},
+		"actions": schema.ListNestedAttribute{
+			Required: true, NestedObject: schema.NestedAttributeObject{Attributes: actions},
+		},
+	}
+	return map[string]schema.Attribute{
+		"id":              schema.StringAttribute{Computed: true},
+		"pipe_id":         schema.StringAttribute{Required: true},
+		"name":            schema.StringAttribute{Required: true},
+		"instruction":     schema.StringAttribute{Required: true},
+		"active":          schema.BoolAttribute{Optional: true, Computed: true},
+		"data_source_ids": schema.SetAttribute{Optional: true, Computed: true, ElementType: types.StringType},
+		"behaviors": schema.ListNestedAttribute{
+			Required: true, NestedObject: schema.NestedAttributeObject{Attributes: behaviors},
+		},
+	}
+}
+
+// UpgradeState keys the behaviors and actions of version 0 state by their
+// names, keeping the ids and reference_ids Pipefy already knows.
+func (r *AiAgentResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	prior := schema.Schema{Attributes: aiAgentAttributesV0()}
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &prior,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var old aiAgentModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &old)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				model := AiAgentModel{
+					ID:            old.ID,
+					PipeID:        old.PipeID,
+					Name:          old.Name,
+					Instruction:   old.Instruction,
+					Active:        old.Active,
+					DataSourceIDs: old.DataSourceIDs,
+					Behaviors:     behaviorsFromV0(old.Behaviors, &resp.Diagnostics),
+				}
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+			},
+		},
+	}
+}
+
+func behaviorsFromV0(old []aiAgentBehaviorModelV0, diags *diag.Diagnostics) map[string]AiAgentBehaviorModel {
+	behaviors := make(map[string]AiAgentBehaviorModel, len(old))
+	for _, behavior := range old {
+		name := behavior.Name.ValueString()
+		if _, taken := behaviors[name]; taken {
+			diags.AddError("Duplicate AI agent behavior name",
+				fmt.Sprintf("behaviors are now keyed by name and %q is used more than once; "+
+					"rename one with the previous provider version before upgrading", name))
+			continue
+		}
+		actions := make(map[string]AiAgentActionModel, len(behavior.Actions))
+		for _, action := range behavior.Actions {
+			actionName := action.Name.ValueString()
+			if _, taken := actions[actionName]; taken {
+				diags.AddError("Duplicate AI agent action name",
+					fmt.Sprintf("actions are now keyed by name and %q is used more than once in behavior %q; "+
+						"rename one with the previous provider version before upgrading", actionName, name))
+				continue
+			}
+			actions[actionName] = AiAgentActionModel{
+				ID:                 action.ID,
+				ReferenceID:        action.ReferenceID,
+				ActionType:         action.ActionType,
+				DestinationPhaseID: action.DestinationPhaseID,
+				PipeID:             action.PipeID,
+				EmailTemplateID:    types.StringNull(),
+				TableID:            types.StringNull(),
+				ConnectorFieldID:   types.StringNull(),
+				Fields:             fieldsFromV0(action.Fields),
+			}
+		}
+		behaviors[name] = AiAgentBehaviorModel{
+			ID:              behavior.ID,
+			EventID:         behavior.EventID,
+			Instruction:     behavior.Instruction,
+			InstructionFile: types.StringNull(),
+			InstructionHash: types.StringValue(contentHash([]byte(behavior.Instruction.ValueString()))),
+			EventParams:     eventParamsFromV0(behavior.EventParams),
+			Actions:         actions,
+		}
+	}
+	return behaviors
+}
+
+func eventParamsFromV0(old *aiAgentEventParamsModelV0) *AiAgentEventParamsModel {
+	if old == nil {
+		return nil
+	}
+	return &AiAgentEventParamsModel{ToPhaseID: old.ToPhaseID, TriggerFieldIDs: old.TriggerFieldIDs}
+}
+
+func fieldsFromV0(old []aiAgentFieldModelV0) []AiAgentFieldModel {
+	if old == nil {
+		return nil
+	}
+	fields := make([]AiAgentFieldModel, len(old))
+	for i, field := range old {
+		fields[i] = AiAgentFieldModel{FieldID: field.FieldID, InputMode: field.InputMode, Value: field.Value}
+	}
+	return fields
+}
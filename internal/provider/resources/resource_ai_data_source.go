@@ -0,0 +1,443 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/client"
+)
+
+const createPresignedURLMutation = "mutation CreatePresignedUrl_tf($input:CreatePresignedUrlInput!){ " +
+	"createPresignedUrl(input:$input){ url downloadUrl } }"
+const createAIDataSourceMutation = "mutation CreateAiDataSource_tf($input:CreateAiDataSourceInput!){ " +
+	"createAiDataSource(input:$input){ dataSource{ id } } }"
+const updateAIDataSourceMutation = "mutation UpdateAiDataSource_tf($input:UpdateAiDataSourceInput!){ " +
+	"updateAiDataSource(input:$input){ dataSource{ id } } }"
+const getAIDataSourceQuery = "query GetAiDataSource_tf($id:ID!){ aiDataSource(id:$id){ id name sourceType } }"
+const deleteAIDataSourceMutation = "mutation DeleteAiDataSource_tf($input:DeleteAiDataSourceInput!){ " +
+	"deleteAiDataSource(input:$input){ success } }"
+
+const (
+	aiDataSourceFile = "file"
+	aiDataSourceURL  = "url"
+	aiDataSourceText = "text"
+)
+
+var _ resource.Resource = &AiDataSourceResource{}
+var _ resource.ResourceWithImportState = &AiDataSourceResource{}
+var _ resource.ResourceWithModifyPlan = &AiDataSourceResource{}
+
+// AiDataSourceResource manages one knowledge source of AI agents: an uploaded
+// document, a URL or a text snippet. Pipefy does not return the content, so
+// changes are detected through content_hash, and a changed content replaces
+// the data source.
+type AiDataSourceResource struct {
+	api *client.ApiClient
+}
+
+func NewAiDataSourceResource() resource.Resource {
+	return &AiDataSourceResource{}
+}
+
+type AiDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+	Name           types.String `tfsdk:"name"`
+	File           types.String `tfsdk:"file"`
+	ContentType    types.String `tfsdk:"content_type"`
+	URL            types.String `tfsdk:"url"`
+	Text           types.String `tfsdk:"text"`
+	ContentHash    types.String `tfsdk:"content_hash"`
+}
+
+// source returns the configured source type and value, with known false while
+// any of them is unknown.
+func (m AiDataSourceModel) source() (sourceType string, value types.String, known bool) {
+	if m.File.IsUnknown() || m.URL.IsUnknown() || m.Text.IsUnknown() {
+		return "", types.StringUnknown(), false
+	}
+	switch {
+	case !m.File.IsNull():
+		return aiDataSourceFile, m.File, true
+	case !m.URL.IsNull():
+		return aiDataSourceURL, m.URL, true
+	default:
+		return aiDataSourceText, m.Text, true
+	}
+}
+
+// content returns the bytes the data source is built from: the file content,
+// or the URL or text itself. Pipefy fetches URLs on its side, so a page that
+// changes behind the same URL is not detected.
+func (m AiDataSourceModel) content() ([]byte, error) {
+	sourceType, value, _ := m.source()
+	if sourceType == aiDataSourceFile {
+		return os.ReadFile(value.ValueString())
+	}
+	return []byte(value.ValueString()), nil
+}
+
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// hash returns content_hash: the SHA-256 of the source type, the content type
+// of a file and the content. A URL turned into text, or a file uploaded under
+// another content type, is a new data source even when the bytes match.
+func (m AiDataSourceModel) hash() (string, error) {
+	content, err := m.content()
+	if err != nil {
+		return "", err
+	}
+	sourceType, _, _ := m.source()
+	header := sourceType
+	if sourceType == aiDataSourceFile {
+		header += " " + m.fileContentType()
+	}
+	return contentHash(append([]byte(header+"\n"), content...)), nil
+}
+
+func (m AiDataSourceModel) fileContentType() string {
+	if hasString(m.ContentType) {
+		return m.ContentType.ValueString()
+	}
+	if contentType := mime.TypeByExtension(filepath.Ext(m.File.ValueString())); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
+
+func (r *AiDataSourceResource) Metadata(
+	ctx context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_ai_data_source"
+}
+
+func (r *AiDataSourceResource) Schema(
+	ctx context.Context,
+	req resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a knowledge source for AI agents: an uploaded document, a URL or a " +
+			"text snippet. Reference its `id` in `pipefy_ai_agent.data_source_ids`. A change to the " +
+			"content replaces the data source; set `create_before_destroy` so agents switch to the " +
+			"new one before the old one is deleted.",
+		Attributes: map[string]schema.Attribute{
+			"id": computedStableString("The ID of the data source."),
+			"organization_id": schema.StringAttribute{
+				Required: true, Description: "The ID of the organization that owns the data source.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"name": requiredNonEmptyString("The data source name shown to agent builders."),
+			"file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path of a local document to upload. Exactly one of file, url and text must be set.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("url"), path.MatchRoot("text")),
+				},
+			},
+			"content_type": schema.StringAttribute{
+				Optional: true,
+				Description: "MIME type of the uploaded file. Defaults to the type of the file extension, " +
+					"or application/octet-stream.",
+				Validators: []validator.String{stringvalidator.AlsoRequires(path.MatchRoot("file"))},
+			},
+			"url": schema.StringAttribute{
+				Optional: true, Description: "A URL Pipefy fetches as agent knowledge.",
+				Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"text": schema.StringAttribute{
+				Optional: true, Description: "A text snippet used as agent knowledge.",
+				Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"content_hash": schema.StringAttribute{
+				Computed: true,
+				Description: "SHA-256 of the source type, the file content type and the file content, URL " +
+					"or text. A new hash replaces the data source. It is empty after import until the next apply.",
+			},
+		},
+	}
+}
+
+func (r *AiDataSourceResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+	api, ok := req.ProviderData.(*client.ApiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data", fmt.Sprintf("expected *ApiClient, got %T", req.ProviderData),
+		)
+		return
+	}
+	r.api = api
+}
+
+// ModifyPlan hashes the configured content so content edits show in the plan.
+// An imported data source has no hash yet and adopts the configured content.
+func (r *AiDataSourceResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan AiDataSourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state *AiDataSourceModel
+	if !req.State.Raw.IsNull() {
+		state = &AiDataSourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	plan.ContentHash = types.StringUnknown()
+	if _, _, known := plan.source(); known && !plan.ContentType.IsUnknown() {
+		hash, err := plan.hash()
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("file"), "Unreadable AI data source file", err.Error())
+			return
+		}
+		plan.ContentHash = types.StringValue(hash)
+	}
+	if state != nil && !state.ContentHash.IsNull() && !plan.ContentHash.Equal(state.ContentHash) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("content_hash"))
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *AiDataSourceResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var model AiDataSourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := model.resolveContentHash(); err != nil {
+		resp.Diagnostics.AddError("create AI data source failed", err.Error())
+		return
+	}
+	input, err := r.createInput(ctx, model)
+	if err != nil {
+		resp.Diagnostics.AddError("create AI data source failed", err.Error())
+		return
+	}
+	var output struct {
+		CreateAIDataSource struct {
+			DataSource struct {
+				ID string `json:"id"`
+			} `json:"dataSource"`
+		} `json:"createAiDataSource"`
+	}
+	if err := r.api.DoGraphQL(ctx, createAIDataSourceMutation, map[string]any{"input": input}, &output); err != nil {
+		resp.Diagnostics.AddError("create AI data source failed", err.Error())
+		return
+	}
+	if output.CreateAIDataSource.DataSource.ID == "" {
+		resp.Diagnostics.AddError("create AI data source failed", "the API returned no data source ID")
+		return
+	}
+	model.ID = types.StringValue(output.CreateAIDataSource.DataSource.ID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// resolveContentHash hashes the content as it is now. The hash must match the
+// planned one, or the file changed between plan and apply.
+func (m *AiDataSourceModel) resolveContentHash() error {
+	hash, err := m.hash()
+	if err != nil {
+		return err
+	}
+	if hasString(m.ContentHash) && m.ContentHash.ValueString() != hash {
+		return fmt.Errorf("the content of %s changed after the plan was made; plan again", m.File.ValueString())
+	}
+	m.ContentHash = types.StringValue(hash)
+	return nil
+}
+
+// createInput builds the createAiDataSource input, uploading the file first
+// when the source is a document.
+func (r *AiDataSourceResource) createInput(ctx context.Context, model AiDataSourceModel) (map[string]any, error) {
+	sourceType, value, _ := model.source()
+	input := map[string]any{
+		"organizationId": model.OrganizationID.ValueString(),
+		"name":           model.Name.ValueString(), "sourceType": sourceType,
+	}
+	switch sourceType {
+	case aiDataSourceFile:
+		fileURL, err := r.uploadFile(ctx, model)
+		if err != nil {
+			return nil, err
+		}
+		input["fileUrl"] = fileURL
+	case aiDataSourceURL:
+		input["url"] = value.ValueString()
+	default:
+		input["text"] = value.ValueString()
+	}
+	return input, nil
+}
+
+func (r *AiDataSourceResource) uploadFile(ctx context.Context, model AiDataSourceModel) (string, error) {
+	content, err := model.content()
+	if err != nil {
+		return "", err
+	}
+	var output struct {
+		CreatePresignedURL struct {
+			URL         string `json:"url"`
+			DownloadURL string `json:"downloadUrl"`
+		} `json:"createPresignedUrl"`
+	}
+	input := map[string]any{
+		"organizationId": model.OrganizationID.ValueString(),
+		"fileName":       filepath.Base(model.File.ValueString()),
+		"contentType":    model.fileContentType(),
+	}
+	if err := r.api.DoGraphQL(ctx, createPresignedURLMutation, map[string]any{"input": input}, &output); err != nil {
+		return "", fmt.Errorf("request upload URL: %w", err)
+	}
+	presigned := output.CreatePresignedURL
+	if presigned.URL == "" || presigned.DownloadURL == "" {
+		return "", fmt.Errorf("request upload URL: the API returned no URL")
+	}
+	if err := r.api.Upload(ctx, presigned.URL, model.fileContentType(), content); err != nil {
+		return "", fmt.Errorf("upload %s: %w", model.File.ValueString(), err)
+	}
+	return presigned.DownloadURL, nil
+}
+
+func (r *AiDataSourceResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var model AiDataSourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var output struct {
+		AIDataSource *struct {
+			ID         string `json:"id"`
+			Name       string `json:"name"`
+			SourceType string `json:"sourceType"`
+		} `json:"aiDataSource"`
+	}
+	variables := map[string]any{"id": model.ID.ValueString()}
+	if err := r.api.DoGraphQL(ctx, getAIDataSourceQuery, variables, &output); err != nil {
+		resp.Diagnostics.AddError("read AI data source failed", err.Error())
+		return
+	}
+	if output.AIDataSource == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	model.ID = types.StringValue(output.AIDataSource.ID)
+	model.Name = types.StringValue(output.AIDataSource.Name)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// Update renames the data source. Content changes are replacements, except
+// after import, where the configured content is adopted as it is.
+func (r *AiDataSourceResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan, state AiDataSourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.ContentHash.IsUnknown() {
+		if err := plan.resolveContentHash(); err != nil {
+			resp.Diagnostics.AddError("update AI data source failed", err.Error())
+			return
+		}
+	}
+	if !plan.Name.Equal(state.Name) {
+		input := map[string]any{"id": plan.ID.ValueString(), "name": plan.Name.ValueString()}
+		if err := r.api.DoGraphQL(ctx, updateAIDataSourceMutation, map[string]any{"input": input}, nil); err != nil {
+			resp.Diagnostics.AddError("update AI data source failed", err.Error())
+			return
+		}
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *AiDataSourceResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var model AiDataSourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var output struct {
+		DeleteAIDataSource struct {
+			Success bool `json:"success"`
+		} `json:"deleteAiDataSource"`
+	}
+	input := map[string]any{"id": model.ID.ValueString()}
+	if err := r.api.DoGraphQL(ctx, deleteAIDataSourceMutation, map[string]any{"input": input}, &output); err != nil {
+		resp.Diagnostics.AddError("delete AI data source failed", err.Error())
+		return
+	}
+	if !output.DeleteAIDataSource.Success {
+		resp.Diagnostics.AddError("delete AI data source failed", "the API did not confirm the deletion")
+	}
+}
+
+func (r *AiDataSourceResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	parts, ok := splitImportID(req.ID)
+	if !ok || len(parts) != 2 {
+		resp.Diagnostics.AddError(
+			"invalid import ID",
+			fmt.Sprintf("got %q; expected organization_id/data_source_id", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+}
@@ -7,35 +7,61 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/automationgql"
 	"github.com/pipefy/terraform-provider-pipefy/internal/provider/client"
 )
 
 var _ resource.Resource = &AutomationResource{}
 var _ resource.ResourceWithImportState = &AutomationResource{}
+var _ resource.ResourceWithValidateConfig = &AutomationResource{}
+var _ resource.ResourceWithUpgradeState = &AutomationResource{}
 
 func NewAutomationResource() resource.Resource { return &AutomationResource{} }
 
 type AutomationResource struct{ api *client.ApiClient }
 
 type AutomationModel struct {
-	Id           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	EventId      types.String `tfsdk:"event_id"`
-	ActionId     types.String `tfsdk:"action_id"`
-	EventRepoId  types.String `tfsdk:"event_repo_id"`
-	ActionRepoId types.String `tfsdk:"action_repo_id"`
-	EventParams  types.String `tfsdk:"event_params"`
-	ActionParams types.String `tfsdk:"action_params"`
-	Condition    types.String `tfsdk:"condition"`
-	Active       types.Bool   `tfsdk:"active"`
+	Id           types.String         `tfsdk:"id"`
+	Name         types.String         `tfsdk:"name"`
+	EventId      types.String         `tfsdk:"event_id"`
+	ActionId     types.String         `tfsdk:"action_id"`
+	EventRepoId  types.String         `tfsdk:"event_repo_id"`
+	ActionRepoId types.String         `tfsdk:"action_repo_id"`
+	EventParams  jsontypes.Normalized `tfsdk:"event_params"`
+	ActionParams jsontypes.Normalized `tfsdk:"action_params"`
+	Condition    jsontypes.Normalized `tfsdk:"condition"`
+	Active       types.Bool           `tfsdk:"active"`
+
+	Event               *AutomationEventModel     `tfsdk:"event"`
+	Action              *AutomationActionModel    `tfsdk:"action"`
+	ConditionExpression *AutomationConditionModel `tfsdk:"condition_expression"`
+}
+
+// automationModelV0 is the state written before the typed event, action and
+// condition_expression attributes existed.
+type automationModelV0 struct {
+	Id           types.String         `tfsdk:"id"`
+	Name         types.String         `tfsdk:"name"`
+	EventId      types.String         `tfsdk:"event_id"`
+	ActionId     types.String         `tfsdk:"action_id"`
+	EventRepoId  types.String         `tfsdk:"event_repo_id"`
+	ActionRepoId types.String         `tfsdk:"action_repo_id"`
+	EventParams  jsontypes.Normalized `tfsdk:"event_params"`
+	ActionParams jsontypes.Normalized `tfsdk:"action_params"`
+	Condition    jsontypes.Normalized `tfsdk:"condition"`
+	Active       types.Bool           `tfsdk:"active"`
 }
 
 type automationErrorDetail struct {
@@ -81,25 +107,93 @@ func (r *AutomationResource) Metadata(ctx context.Context, req resource.Metadata
 }
 
 func (r *AutomationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := automationAttributesV0()
+	attributes["event"] = automationEventAttribute()
+	attributes["action"] = automationActionAttribute()
+	attributes["condition_expression"] = automationConditionExpressionAttribute()
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Automation resource",
-		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{
-				Computed: true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
-				},
+		Version:             1,
+		Attributes:          attributes,
+	}
+}
+
+// automationAttributesV0 returns the attributes of schema version 0, which
+// the current schema extends.
+func automationAttributesV0() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"name":           schema.StringAttribute{Required: true, Description: "Name of the automation"},
+		"event_id":       schema.StringAttribute{Required: true, Description: "The type of the event that the automation listens to"},
+		"action_id":      schema.StringAttribute{Required: true, Description: "The type of the action that the automation performs"},
+		"event_repo_id":  schema.StringAttribute{Required: true, Description: "The ID of the pipe that the automation listens to", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+		"action_repo_id": schema.StringAttribute{Required: true, Description: "The ID of the pipe that the automation performs actions on"},
+		// JSON strings for complex structures to avoid over-modeling in Terraform schema.
+		// They are refreshed from the API and compared semantically, so key order
+		// and formatting do not cause a diff.
+		"event_params": schema.StringAttribute{
+			Optional:    true,
+			CustomType:  jsontypes.NormalizedType{},
+			Description: "The parameters of the event for the automation, as a JSON object string",
+		},
+		"action_params": schema.StringAttribute{
+			Optional:    true,
+			CustomType:  jsontypes.NormalizedType{},
+			Description: "The parameters of the action for the automation, as a JSON object string",
+		},
+		"condition": schema.StringAttribute{
+			Optional:    true,
+			CustomType:  jsontypes.NormalizedType{},
+			Description: "The condition for the automation to be executed, as a JSON object string",
+		},
+		"active": schema.BoolAttribute{
+			Optional:      true,
+			Computed:      true,
+			Description:   "Whether the automation is active or not. Defaults to the API's choice when unset.",
+			PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+		},
+	}
+}
+
+func (r *AutomationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data AutomationModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	validateAutomationTyped(data, &resp.Diagnostics)
+}
+
+func (r *AutomationResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	prior := schema.Schema{Attributes: automationAttributesV0()}
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &prior,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var old automationModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &old)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				data := AutomationModel{
+					Id:           old.Id,
+					Name:         old.Name,
+					EventId:      old.EventId,
+					ActionId:     old.ActionId,
+					EventRepoId:  old.EventRepoId,
+					ActionRepoId: old.ActionRepoId,
+					EventParams:  old.EventParams,
+					ActionParams: old.ActionParams,
+					Condition:    old.Condition,
+					Active:       old.Active,
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 			},
-			"name":           schema.StringAttribute{Required: true, Description: "Name of the automation"},
-			"event_id":       schema.StringAttribute{Required: true, Description: "The type of the event that the automation listens to"},
-			"action_id":      schema.StringAttribute{Required: true, Description: "The type of the action that the automation performs"},
-			"event_repo_id":  schema.StringAttribute{Required: true, Description: "The ID of the pipe that the automation listens to", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-			"action_repo_id": schema.StringAttribute{Required: true, Description: "The ID of the pipe that the automation performs actions on"},
-			// JSON strings for complex structures to avoid over-modeling in Terraform schema
-			"event_params":  schema.StringAttribute{Optional: true, Description: "The parameters of the event for the automation"},
-			"action_params": schema.StringAttribute{Optional: true, Description: "The parameters of the action for the automation"},
-			"condition":     schema.StringAttribute{Optional: true, Description: "The condition for the automation to be executed"},
-			"active":        schema.BoolAttribute{Optional: true, Description: "Whether the automation is active or not"},
 		},
 	}
 }
@@ -131,33 +225,10 @@ func (r *AutomationResource) Create(ctx context.Context, req resource.CreateRequ
 		"event_repo_id":  data.EventRepoId.ValueString(),
 		"action_repo_id": data.ActionRepoId.ValueString(),
 	}
-	if !data.EventParams.IsNull() && data.EventParams.ValueString() != "" {
-		var ep any
-		// Accept raw JSON string for event_params
-		if err := json.Unmarshal([]byte(data.EventParams.ValueString()), &ep); err != nil {
-			resp.Diagnostics.AddError("invalid event_params JSON", err.Error())
-			return
-		}
-		input["event_params"] = ep
-	}
-	if !data.ActionParams.IsNull() && data.ActionParams.ValueString() != "" {
-		var ap any
-		// Accept raw JSON string for action_params
-		if err := json.Unmarshal([]byte(data.ActionParams.ValueString()), &ap); err != nil {
-			resp.Diagnostics.AddError("invalid action_params JSON", err.Error())
-			return
-		}
-		input["action_params"] = ap
-	}
-	if !data.Condition.IsNull() && data.Condition.ValueString() != "" {
-		var cond any
-		if err := json.Unmarshal([]byte(data.Condition.ValueString()), &cond); err != nil {
-			resp.Diagnostics.AddError("invalid condition JSON", err.Error())
-			return
-		}
-		input["condition"] = cond
+	if !addAutomationJSONParams(ctx, data, input, &resp.Diagnostics) {
+		return
 	}
-	if !data.Active.IsNull() {
+	if hasValue(data.Active) {
 		input["active"] = data.Active.ValueBool()
 	}
 	vars := map[string]any{"input": input}
@@ -180,6 +251,9 @@ func (r *AutomationResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 	data.Id = types.StringValue(out.CreateAutomation.Automation.Id)
+	if data.Active.IsUnknown() {
+		data.Active = types.BoolValue(out.CreateAutomation.Automation.Active)
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -193,42 +267,171 @@ func (r *AutomationResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	query := "query GetAutomation_tf($id:ID!){ automation(id:$id){ id name action_id event_id active event_repo{ id } action_repo_v2{ ... on Pipe{ id } ... on Table{ id } } } }"
-	vars := map[string]any{"id": data.Id.ValueString()}
-	var out struct {
-		Automation *struct {
-			Id        string `json:"id"`
-			Name      string `json:"name"`
-			ActionId  string `json:"action_id"`
-			EventId   string `json:"event_id"`
-			Active    bool   `json:"active"`
-			EventRepo *struct {
-				Id string `json:"id"`
-			} `json:"event_repo"`
-			ActionRepoV2 *struct {
-				Id string `json:"id"`
-			} `json:"action_repo_v2"`
-		} `json:"automation"`
-	}
-	if err := r.api.DoGraphQL(ctx, query, vars, &out); err != nil {
+	a, err := r.fetchAutomation(ctx, data.Id.ValueString(), &resp.Diagnostics)
+	if err != nil {
 		resp.Diagnostics.AddError("read automation failed", err.Error())
 		return
 	}
-	if out.Automation == nil {
+	if a == nil {
 		resp.State.RemoveResource(ctx)
 		return
 	}
+	applyAutomationToModel(ctx, &data, *a, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// fetchAutomation returns the automation with the given ID, or nil when it no
+// longer exists. The members of automationgql.DetailSelection are added when
+// they can be read; otherwise a warning is added and they are left as they
+// were.
+func (r *AutomationResource) fetchAutomation(ctx context.Context, id string, diags *diag.Diagnostics) (*automationgql.Automation, error) {
+	query := "query GetAutomation_tf($id:ID!){ automation(id:$id){ " + automationgql.Selection + " } }"
+	var out struct {
+		Automation *automationgql.Automation `json:"automation"`
+	}
+	if err := r.api.DoGraphQL(ctx, query, map[string]any{"id": id}, &out); err != nil {
+		return nil, err
+	}
+	if out.Automation == nil {
+		return nil, nil
+	}
+
+	detailQuery := "query GetAutomationDetail_tf($id:ID!){ automation(id:$id){ " + automationgql.DetailSelection + " } }"
+	var detail struct {
+		Automation *automationgql.Automation `json:"automation"`
+	}
+	err := r.api.DoGraphQL(ctx, detailQuery, map[string]any{"id": id}, &detail)
+	switch {
+	case err != nil:
+	case detail.Automation == nil:
+		err = fmt.Errorf("automation %s was not returned", id)
+	default:
+		err = out.Automation.AddDetail(*detail.Automation)
+	}
+	if err != nil {
+		keys := append(slices.Clone(automationgql.EventParamDetailKeys), automationgql.ActionParamDetailKeys...)
+		diags.AddWarning("automation params not fully refreshed",
+			fmt.Sprintf("could not read %s, so they are kept as they were: %s", strings.Join(keys, ", "), err.Error()))
+	}
+	return out.Automation, nil
+}
+
+// applyAutomationToModel refreshes the model from a fetched automation so edits
+// made in the UI show up as drift. Params configured through a typed attribute
+// are mapped back into it; the rest are refreshed as JSON, which is also how an
+// import sees them.
+func applyAutomationToModel(ctx context.Context, data *AutomationModel, a automationgql.Automation, diags *diag.Diagnostics) {
+	data.Name = types.StringValue(a.Name)
+	data.EventId = types.StringValue(a.EventId)
+	data.ActionId = types.StringValue(a.ActionId)
+	data.Active = types.BoolValue(a.Active)
+	if a.EventRepo != nil && a.EventRepo.Id != "" {
+		data.EventRepoId = types.StringValue(a.EventRepo.Id)
+	}
+	if a.ActionRepoV2 != nil && a.ActionRepoV2.Id != "" {
+		data.ActionRepoId = types.StringValue(a.ActionRepoV2.Id)
+	}
+	// Typed blocks are mapped as a whole, so without the detail members they
+	// are left as they were rather than refreshed in part.
+	if data.Event != nil {
+		if params := automationObject(a.EventParams, "event", diags); params != nil && a.Detailed {
+			data.Event.refresh(ctx, params, diags)
+		}
+	} else {
+		data.EventParams = refreshAutomationJSON(data.EventParams, a.EventParams, a.EventKeys(), "event_params", diags)
+	}
+	if data.Action != nil {
+		if params := automationObject(a.ActionParams, "action", diags); params != nil && a.Detailed {
+			data.Action.refresh(params)
+		}
+	} else {
+		data.ActionParams = refreshAutomationJSON(data.ActionParams, a.ActionParams, a.ActionKeys(), "action_params", diags)
+	}
+	if data.ConditionExpression != nil {
+		if params := automationObject(a.Condition, "condition_expression", diags); params != nil {
+			data.ConditionExpression.refresh(params)
+		}
+	} else {
+		data.Condition = refreshAutomationJSON(data.Condition, a.Condition, automationgql.ConditionKeys, "condition", diags)
+	}
+}
+
+func automationObject(fetched json.RawMessage, name string, diags *diag.Diagnostics) map[string]any {
+	obj, err := automationgql.Object(fetched)
+	if err != nil {
+		diags.AddAttributeError(path.Root(name), "read automation failed", fmt.Sprintf("could not map %s: %s", name, err.Error()))
+		return nil
+	}
+	return obj
+}
+
+func refreshAutomationJSON(prior jsontypes.Normalized, fetched json.RawMessage, selected []string, name string, diags *diag.Diagnostics) jsontypes.Normalized {
+	var priorJSON string
+	if hasValue(prior) {
+		priorJSON = prior.ValueString()
+	}
+	refreshed, err := automationgql.Refresh(priorJSON, fetched, selected)
+	if err != nil {
+		diags.AddAttributeError(path.Root(name), "read automation failed", fmt.Sprintf("could not map %s: %s", name, err.Error()))
+		return prior
+	}
+	if refreshed == "" {
+		return jsontypes.NewNormalizedNull()
+	}
+	return jsontypes.NewNormalizedValue(refreshed)
+}
+
+// addAutomationJSONParams decodes the configured JSON attributes, or builds the
+// typed ones, into the mutation input. It reports false when one of them is not
+// valid.
+func addAutomationJSONParams(ctx context.Context, data AutomationModel, input map[string]any, diags *diag.Diagnostics) bool {
+	params := []struct {
+		key   string
+		value jsontypes.Normalized
+	}{
+		{"event_params", data.EventParams},
+		{"action_params", data.ActionParams},
+		{"condition", data.Condition},
+	}
+	for _, p := range params {
+		if !hasValue(p.value) || p.value.ValueString() == "" {
+			continue
+		}
+		var v any
+		if err := json.Unmarshal([]byte(p.value.ValueString()), &v); err != nil {
+			diags.AddError("invalid "+p.key+" JSON", err.Error())
+			return false
+		}
+		input[p.key] = v
+	}
+	if data.Event != nil {
+		if params := data.Event.eventParams(ctx, diags); params != nil {
+			input["event_params"] = params
+		}
+	}
+	if data.Action != nil {
+		if params := data.Action.actionParams(); params != nil {
+			input["action_params"] = params
+		}
+	}
+	if data.ConditionExpression != nil {
+		input["condition"] = data.ConditionExpression.conditionParams()
+	}
+	return !diags.HasError()
+}
+
 func (r *AutomationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data AutomationModel
+	var data, state AutomationModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	mutation := "mutation UpdateAutomation_tf($input:UpdateAutomationInput!){ updateAutomation(input:$input){ automation{ id } error_details{ object_name object_key messages } } }"
+	mutation := "mutation UpdateAutomation_tf($input:UpdateAutomationInput!){ updateAutomation(input:$input){ automation{ id active } error_details{ object_name object_key messages } } }"
 	input := map[string]any{
 		"id": data.Id.ValueString(),
 	}
@@ -247,31 +450,10 @@ func (r *AutomationResource) Update(ctx context.Context, req resource.UpdateRequ
 	if !data.ActionRepoId.IsNull() {
 		input["action_repo_id"] = data.ActionRepoId.ValueString()
 	}
-	if !data.EventParams.IsNull() && data.EventParams.ValueString() != "" {
-		var ep any
-		if err := json.Unmarshal([]byte(data.EventParams.ValueString()), &ep); err != nil {
-			resp.Diagnostics.AddError("invalid event_params JSON", err.Error())
-			return
-		}
-		input["event_params"] = ep
-	}
-	if !data.ActionParams.IsNull() && data.ActionParams.ValueString() != "" {
-		var ap any
-		if err := json.Unmarshal([]byte(data.ActionParams.ValueString()), &ap); err != nil {
-			resp.Diagnostics.AddError("invalid action_params JSON", err.Error())
-			return
-		}
-		input["action_params"] = ap
-	}
-	if !data.Condition.IsNull() && data.Condition.ValueString() != "" {
-		var cond any
-		if err := json.Unmarshal([]byte(data.Condition.ValueString()), &cond); err != nil {
-			resp.Diagnostics.AddError("invalid condition JSON", err.Error())
-			return
-		}
-		input["condition"] = cond
+	if !addAutomationParamsDelta(ctx, state, data, input, &resp.Diagnostics) {
+		return
 	}
-	if !data.Active.IsNull() {
+	if hasValue(data.Active) {
 		input["active"] = data.Active.ValueBool()
 	}
 
@@ -279,7 +461,8 @@ func (r *AutomationResource) Update(ctx context.Context, req resource.UpdateRequ
 	var out struct {
 		UpdateAutomation struct {
 			Automation *struct {
-				Id string `json:"id"`
+				Id     string `json:"id"`
+				Active bool   `json:"active"`
 			} `json:"automation"`
 			ErrorDetails []automationErrorDetail `json:"error_details"`
 		} `json:"updateAutomation"`
@@ -289,9 +472,80 @@ func (r *AutomationResource) Update(ctx context.Context, req resource.UpdateRequ
 		resp.Diagnostics.AddError("update automation failed", detail)
 		return
 	}
+	if data.Active.IsUnknown() {
+		data.Active = types.BoolValue(out.UpdateAutomation.Automation.Active)
+	}
+
+	// The API keeps what an update omits and may ignore a null, so read the
+	// automation back and fail on a clear that did not take. State otherwise
+	// keeps the planned values.
+	a, err := r.fetchAutomation(ctx, data.Id.ValueString(), &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddError("read automation failed", err.Error())
+		return
+	}
+	if a == nil {
+		resp.Diagnostics.AddError("update automation failed", fmt.Sprintf("automation %s no longer exists", data.Id.ValueString()))
+		return
+	}
+	checkAutomationClears(input, *a, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// addAutomationParamsDelta adds event_params, action_params and condition to
+// an update input, sending null for structures and top-level keys that the
+// plan removes. See automationgql.Delta.
+func addAutomationParamsDelta(ctx context.Context, state, plan AutomationModel, input map[string]any, diags *diag.Diagnostics) bool {
+	planned := map[string]any{}
+	if !addAutomationJSONParams(ctx, plan, planned, diags) {
+		return false
+	}
+	// The prior values were accepted when applied, so errors here are not
+	// the user's to fix; a structure that cannot be rebuilt is just not
+	// cleared key by key.
+	var priorDiags diag.Diagnostics
+	prior := map[string]any{}
+	addAutomationJSONParams(ctx, state, prior, &priorDiags)
+	for _, key := range []string{"event_params", "action_params", "condition"} {
+		if v, send := automationgql.Delta(prior[key], planned[key]); send {
+			input[key] = v
+		}
+	}
+	return true
+}
+
+// checkAutomationClears reports the params an update cleared that the fetched
+// automation still holds.
+func checkAutomationClears(input map[string]any, a automationgql.Automation, diags *diag.Diagnostics) {
+	params := []struct {
+		key      string
+		fetched  json.RawMessage
+		selected []string
+	}{
+		{"event_params", a.EventParams, a.EventKeys()},
+		{"action_params", a.ActionParams, a.ActionKeys()},
+		{"condition", a.Condition, automationgql.ConditionKeys},
+	}
+	for _, p := range params {
+		sent, ok := input[p.key]
+		if !ok {
+			continue
+		}
+		kept, err := automationgql.Kept(sent, p.fetched, p.selected)
+		if err != nil {
+			diags.AddAttributeError(path.Root(p.key), "read automation failed", fmt.Sprintf("could not map %s: %s", p.key, err.Error()))
+			continue
+		}
+		if len(kept) > 0 {
+			diags.AddAttributeError(path.Root(p.key), "update automation failed",
+				fmt.Sprintf("the API did not clear %s %s; remove them in the Pipefy UI or configure them again", p.key, strings.Join(kept, ", ")))
+		}
+	}
+}
+
 func (r *AutomationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data AutomationModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
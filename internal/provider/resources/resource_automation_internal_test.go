@@ -0,0 +1,241 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/automationgql"
+)
+
+func decodeParams(t *testing.T, raw string) map[string]any {
+	t.Helper()
+	obj, err := automationgql.Object(json.RawMessage(raw))
+	if err != nil {
+		t.Fatalf("decode %s: %v", raw, err)
+	}
+	return obj
+}
+
+func TestAutomationConditionParams(t *testing.T) {
+	c := AutomationConditionModel{
+		Match: types.StringValue(automationMatchAny),
+		Expressions: []AutomationExpressionModel{
+			{FieldId: types.StringValue("11"), Operation: types.StringValue("present"), Value: types.StringNull()},
+			{FieldId: types.StringValue("12"), Operation: types.StringValue("equals"), Value: types.StringValue("yes")},
+		},
+	}
+	b, _ := json.Marshal(c.conditionParams())
+	want := `{"expressions":[{"field_address":"11","operation":"present","structure_id":0,"value":""},` +
+		`{"field_address":"12","operation":"equals","structure_id":1,"value":"yes"}],"expressions_structure":[[0],[1]]}`
+	if string(b) != want {
+		t.Fatalf("conditionParams = %s, want %s", b, want)
+	}
+
+	c.Match = types.StringValue(automationMatchAll)
+	b, _ = json.Marshal(c.conditionParams())
+	if got := decodeParams(t, string(b))["expressions_structure"]; !reflect.DeepEqual(got, []any{[]any{json.Number("0"), json.Number("1")}}) {
+		t.Fatalf("all structure = %v", got)
+	}
+}
+
+func TestAutomationConditionRefresh(t *testing.T) {
+	c := AutomationConditionModel{Match: types.StringValue(automationMatchAll)}
+	c.refresh(decodeParams(t, `{"expressions":[{"structure_id":0,"field_address":"11","operation":"present","value":""},`+
+		`{"structure_id":1,"field_address":"12","operation":"equals","value":"yes"}],"expressions_structure":[[0],[1]]}`))
+	if c.Match.ValueString() != automationMatchAny {
+		t.Fatalf("match = %s, want any", c.Match)
+	}
+	want := []AutomationExpressionModel{
+		{FieldId: types.StringValue("11"), Operation: types.StringValue("present"), Value: types.StringNull()},
+		{FieldId: types.StringValue("12"), Operation: types.StringValue("equals"), Value: types.StringValue("yes")},
+	}
+	if !reflect.DeepEqual(c.Expressions, want) {
+		t.Fatalf("expressions = %#v", c.Expressions)
+	}
+
+	// A single expression fits both; the configured match is kept.
+	c.refresh(decodeParams(t, `{"expressions":[{"structure_id":0,"field_address":"11","operation":"present"}],"expressions_structure":[[0]]}`))
+	if c.Match.ValueString() != automationMatchAny {
+		t.Fatalf("match = %s, want the prior any", c.Match)
+	}
+}
+
+func TestAutomationTypedParamsRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	var diags diag.Diagnostics
+
+	fieldIDs, _ := types.SetValueFrom(ctx, types.StringType, []string{"11"})
+	event := AutomationEventModel{FieldUpdated: &AutomationFieldUpdatedModel{FieldIds: fieldIDs}}
+	b, _ := json.Marshal(event.eventParams(ctx, &diags))
+	if string(b) != `{"triggerFieldIds":["11"]}` {
+		t.Fatalf("eventParams = %s", b)
+	}
+	event.refresh(ctx, decodeParams(t, `{"triggerFieldIds":[12,"13"]}`), &diags)
+	if got := stringSetValues(event.FieldUpdated.FieldIds); !reflect.DeepEqual(got, []string{"12", "13"}) {
+		t.Fatalf("refreshed field_ids = %v", got)
+	}
+	if (&AutomationEventModel{CardCreated: &AutomationCardCreatedModel{}}).eventParams(ctx, &diags) != nil {
+		t.Fatal("card_created should send no event_params")
+	}
+
+	action := AutomationActionModel{UpdateField: &AutomationFieldMapModel{Fields: []AutomationFieldValueModel{
+		{FieldId: types.StringValue("11"), InputMode: types.StringValue("fixed_value"), Value: types.StringValue("done")},
+		{FieldId: types.StringValue("12"), InputMode: types.StringValue("fixed_value"), Value: types.StringNull()},
+	}}}
+	b, _ = json.Marshal(action.actionParams())
+	want := `{"field_map":[{"fieldId":"11","inputMode":"fixed_value","value":"done"},{"fieldId":"12","inputMode":"fixed_value"}]}`
+	if string(b) != want {
+		t.Fatalf("actionParams = %s, want %s", b, want)
+	}
+	before := action.UpdateField.Fields
+	action.refresh(decodeParams(t, string(b)))
+	if !reflect.DeepEqual(action.UpdateField.Fields, before) {
+		t.Fatalf("refreshed fields = %#v", action.UpdateField.Fields)
+	}
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestValidateAutomationTyped(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   AutomationModel
+		errors int
+	}{
+		{
+			name: "matching variants",
+			data: AutomationModel{
+				EventId:  types.StringValue("card_moved"),
+				ActionId: types.StringValue("send_email_template"),
+				Event:    &AutomationEventModel{CardMoved: &AutomationCardMovedModel{}},
+				Action:   &AutomationActionModel{SendEmailTemplate: &AutomationSendEmailTemplateModel{}},
+			},
+		},
+		{
+			name: "unknown ids defer",
+			data: AutomationModel{
+				EventId: types.StringUnknown(),
+				Event:   &AutomationEventModel{CardCreated: &AutomationCardCreatedModel{}},
+			},
+		},
+		{
+			name: "variant for another event",
+			data: AutomationModel{
+				EventId: types.StringValue("card_created"),
+				Event:   &AutomationEventModel{Scheduled: &AutomationScheduledModel{}},
+			},
+			errors: 1,
+		},
+		{
+			name: "no variant and two variants",
+			data: AutomationModel{
+				EventId:  types.StringValue("card_created"),
+				ActionId: types.StringValue("move_single_card"),
+				Event:    &AutomationEventModel{},
+				Action: &AutomationActionModel{
+					MoveCard:    &AutomationMoveCardModel{},
+					UpdateField: &AutomationFieldMapModel{},
+				},
+			},
+			errors: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateAutomationTyped(tt.data, &diags)
+			if diags.ErrorsCount() != tt.errors {
+				t.Fatalf("got %d errors, want %d: %v", diags.ErrorsCount(), tt.errors, diags)
+			}
+		})
+	}
+}
+
+func TestAutomationUpgradeStateFromV0(t *testing.T) {
+	ctx := context.Background()
+	r := &AutomationResource{}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	upgrader := r.UpgradeState(ctx)[0]
+
+	prior := upgrader.PriorSchema
+	values := map[string]tftypes.Value{}
+	for name, a := range prior.Attributes {
+		values[name] = tftypes.NewValue(a.GetType().TerraformType(ctx), nil)
+	}
+	values["id"] = tftypes.NewValue(tftypes.String, "auto_1")
+	values["event_params"] = tftypes.NewValue(tftypes.String, `{"triggerFieldIds":["11"]}`)
+	values["active"] = tftypes.NewValue(tftypes.Bool, true)
+	priorState := tfsdk.State{Schema: *prior, Raw: tftypes.NewValue(prior.Type().TerraformType(ctx), values)}
+
+	resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &priorState}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("upgrade failed: %v", resp.Diagnostics)
+	}
+	var got AutomationModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &got)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read upgraded state: %v", resp.Diagnostics)
+	}
+	if got.Id.ValueString() != "auto_1" || got.EventParams.ValueString() != `{"triggerFieldIds":["11"]}` || !got.Active.ValueBool() {
+		t.Fatalf("upgraded state lost values: %#v", got)
+	}
+	if got.Event != nil || got.Action != nil || got.ConditionExpression != nil {
+		t.Fatalf("typed attributes should be null after upgrade: %#v", got)
+	}
+}
+
+func TestAutomationScheduledRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	var diags diag.Diagnostics
+	event := AutomationEventModel{Scheduled: &AutomationScheduledModel{
+		Cron:      types.StringValue("0 9 * * MON"),
+		TimeZone:  types.StringValue("America/Sao_Paulo"),
+		StartDate: types.StringValue("2025-01-31"),
+	}}
+	b, _ := json.Marshal(event.eventParams(ctx, &diags))
+	want := `{"scheduler_cron":"0 9 * * MON","scheduler_start_date":"2025-01-31","scheduler_timezone":"America/Sao_Paulo"}`
+	if string(b) != want {
+		t.Fatalf("eventParams = %s, want %s", b, want)
+	}
+
+	event.refresh(ctx, decodeParams(t, `{"scheduler_cron":"0 10 * * MON","scheduler_timezone":"America/Sao_Paulo","scheduler_start_date":"2025-01-31T00:00:00-03:00"}`), &diags)
+	got := event.Scheduled
+	if got.Cron.ValueString() != "0 10 * * MON" || !got.Frequency.IsNull() {
+		t.Fatalf("cron drift not refreshed: %#v", got)
+	}
+	if got.StartDate.ValueString() != "2025-01-31" {
+		t.Fatalf("start_date on the configured day should keep its format, got %s", got.StartDate)
+	}
+}
+
+func TestSameScheduleStart(t *testing.T) {
+	cases := []struct {
+		prior, fetched string
+		want           bool
+	}{
+		{"2025-01-31", "2025-01-31", true},
+		{"2025-01-31", "2025-01-31T09:00:00-03:00", true},
+		{"2025-01-31", "2025-02-01T00:00:00Z", false},
+		{"2025-01-31T12:00:00Z", "2025-01-31T09:00:00-03:00", true},
+		{"2025-01-31T12:00:00Z", "2025-01-31T12:00:01Z", false},
+		{"2025-01-31", "2025-02-01", false},
+	}
+	for _, tc := range cases {
+		if got := sameScheduleStart(types.StringValue(tc.prior), types.StringValue(tc.fetched)); got != tc.want {
+			t.Errorf("sameScheduleStart(%q, %q) = %v, want %v", tc.prior, tc.fetched, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/automationgql"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/client"
+)
+
+var _ resource.ResourceWithModifyPlan = &AutomationResource{}
+
+// plannedAutomationParams is one params structure of a planned automation,
+// decoded the way it is sent, with the attribute it was configured through.
+type plannedAutomationParams struct {
+	attr   path.Path
+	params map[string]any
+}
+
+// automationFieldRefs are the field IDs one attribute references in a repo.
+type automationFieldRefs struct {
+	attr path.Path
+	ids  []string
+}
+
+// ModifyPlan checks the planned event, action and params against the
+// automation catalog and the fields of the event and action repos, so mistakes
+// surface before anything is applied. Values that are not yet known are not
+// checked, and a catalog or repo that cannot be loaded only skips its checks.
+func (r *AutomationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.api == nil {
+		return
+	}
+	if !req.State.Raw.IsNull() && req.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+	var plan AutomationModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !hasString(plan.EventId) || !hasString(plan.ActionId) || !hasString(plan.EventRepoId) || !hasString(plan.ActionRepoId) {
+		return
+	}
+
+	event, action, ok := r.catalogEntries(ctx, plan, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	eventParams, actionParams, condition := knownAutomationParams(ctx, req.Plan, plan)
+
+	if event != nil && eventParams != nil {
+		checkAutomationKeys(*eventParams, *event, "event", &resp.Diagnostics)
+	}
+	if action != nil && actionParams != nil {
+		checkAutomationKeys(*actionParams, *action, "action", &resp.Diagnostics)
+	}
+
+	eventRepo := plan.EventRepoId.ValueString()
+	actionRepo := plan.ActionRepoId.ValueString()
+	refs := map[string][]automationFieldRefs{}
+	addRefs := func(repo string, p *plannedAutomationParams, ids func(map[string]any) []string) {
+		if p == nil {
+			return
+		}
+		if found := ids(p.params); len(found) > 0 {
+			refs[repo] = append(refs[repo], automationFieldRefs{attr: p.attr, ids: found})
+		}
+	}
+	addRefs(eventRepo, eventParams, automationgql.EventFieldIDs)
+	addRefs(eventRepo, condition, automationgql.ConditionFieldIDs)
+	addRefs(actionRepo, actionParams, automationgql.ActionFieldIDs)
+
+	for repo, repoRefs := range refs {
+		fields, err := fetchRepoFields(ctx, r.api, repo)
+		if err != nil {
+			resp.Diagnostics.AddWarning("automation fields not validated",
+				fmt.Sprintf("could not load the fields of repo %s: %s", repo, err.Error()))
+			continue
+		}
+		for _, ref := range repoRefs {
+			var missing []string
+			for _, id := range ref.ids {
+				if !fields.hasField(id) {
+					missing = append(missing, id)
+				}
+			}
+			if len(missing) > 0 {
+				resp.Diagnostics.AddAttributeError(ref.attr, "Unknown automation field",
+					fmt.Sprintf("field IDs %s do not exist in repo %s", strings.Join(missing, ", "), repo))
+			}
+		}
+	}
+}
+
+// catalogEntries returns the planned event and action from the catalogs of
+// their repos, reporting IDs the catalog does not list and combinations it
+// forbids. An entry is nil when its catalog could not be loaded; ok is false
+// once an error has been reported.
+func (r *AutomationResource) catalogEntries(ctx context.Context, plan AutomationModel, diags *diag.Diagnostics) (event, action *automationgql.CatalogEntry, ok bool) {
+	lookup := func(query, field, repo, id, kind string) *automationgql.CatalogEntry {
+		entries, err := fetchAutomationCatalog(ctx, r.api, query, field, repo)
+		if err != nil {
+			diags.AddWarning("automation "+kind+" not validated",
+				fmt.Sprintf("could not load the automation %ss of repo %s: %s", kind, repo, err.Error()))
+			return nil
+		}
+		// An empty catalog carries nothing to check against.
+		if len(entries) == 0 {
+			return nil
+		}
+		entry, found := automationgql.FindEntry(entries, id)
+		if !found {
+			diags.AddAttributeError(path.Root(kind+"_id"), "Unsupported automation "+kind,
+				fmt.Sprintf("%s %q is not available for repo %s; see the pipefy_automation_%ss data source", kind, id, repo, kind))
+			return nil
+		}
+		return &entry
+	}
+	event = lookup(automationgql.EventsQuery, "automationEvents", plan.EventRepoId.ValueString(), plan.EventId.ValueString(), "event")
+	action = lookup(automationgql.ActionsQuery, "automationActions", plan.ActionRepoId.ValueString(), plan.ActionId.ValueString(), "action")
+	if event != nil && action != nil && !automationgql.Compatible(*event, *action) {
+		diags.AddAttributeError(path.Root("action_id"), "Unsupported automation combination",
+			fmt.Sprintf("action %q cannot be combined with event %q", action.Id, event.Id))
+	}
+	return event, action, !diags.HasError()
+}
+
+func checkAutomationKeys(p plannedAutomationParams, entry automationgql.CatalogEntry, kind string, diags *diag.Diagnostics) {
+	if unknown := automationgql.UnknownKeys(p.params, entry); len(unknown) > 0 {
+		diags.AddAttributeError(p.attr, "Unknown automation parameter",
+			fmt.Sprintf("%s %q does not accept %s; accepted keys are %s", kind, entry.Id,
+				strings.Join(unknown, ", "), strings.Join(entry.ParamKeys(), ", ")))
+	}
+}
+
+// knownAutomationParams returns the planned event params, action params and
+// condition as they would be sent, taking each from its typed or JSON
+// attribute. A structure is nil when it is unset or not fully known yet.
+func knownAutomationParams(ctx context.Context, planned tfsdk.Plan, plan AutomationModel) (eventParams, actionParams, condition *plannedAutomationParams) {
+	pick := func(typedSet bool, typed, raw, key string) *plannedAutomationParams {
+		name := raw
+		if typedSet {
+			name = typed
+		}
+		if !planAttributeKnown(planned, name) {
+			return nil
+		}
+		var known AutomationModel
+		switch key {
+		case "event_params":
+			known.EventParams, known.Event = plan.EventParams, plan.Event
+		case "action_params":
+			known.ActionParams, known.Action = plan.ActionParams, plan.Action
+		case "condition":
+			known.Condition, known.ConditionExpression = plan.Condition, plan.ConditionExpression
+		}
+		var diags diag.Diagnostics
+		input := map[string]any{}
+		if !addAutomationJSONParams(ctx, known, input, &diags) || input[key] == nil {
+			return nil
+		}
+		// Round-trip through JSON so IDs decode as json.Number, as on read.
+		b, err := json.Marshal(input[key])
+		if err != nil {
+			return nil
+		}
+		params, err := automationgql.Object(b)
+		if err != nil || len(params) == 0 {
+			return nil
+		}
+		return &plannedAutomationParams{attr: path.Root(name), params: params}
+	}
+	eventParams = pick(plan.Event != nil, "event", "event_params", "event_params")
+	actionParams = pick(plan.Action != nil, "action", "action_params", "action_params")
+	condition = pick(plan.ConditionExpression != nil, "condition_expression", "condition", "condition")
+	return eventParams, actionParams, condition
+}
+
+func planAttributeKnown(plan tfsdk.Plan, name string) bool {
+	v, _, err := tftypes.WalkAttributePath(plan.Raw, tftypes.NewAttributePath().WithAttributeName(name))
+	if err != nil {
+		return false
+	}
+	value, ok := v.(tftypes.Value)
+	return ok && value.IsFullyKnown()
+}
+
+func fetchAutomationCatalog(ctx context.Context, api *client.ApiClient, query, field, repoID string) ([]automationgql.CatalogEntry, error) {
+	var out map[string][]automationgql.CatalogEntry
+	if err := api.DoGraphQL(ctx, query, map[string]any{"repoId": repoID}, &out); err != nil {
+		return nil, err
+	}
+	return out[field], nil
+}
@@ -0,0 +1,492 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/validators"
+)
+
+var automationSchedulerFrequencies = []string{"hourly", "daily", "weekly", "monthly", "yearly"}
+
+// Event and action IDs covered by the typed event and action attributes.
+const (
+	automationEventCardCreated  = "card_created"
+	automationEventCardMoved    = "card_moved"
+	automationEventFieldUpdated = "field_updated"
+	automationEventScheduler    = "scheduler"
+
+	automationActionMoveCard            = "move_single_card"
+	automationActionUpdateField         = "update_card_field"
+	automationActionCreateConnectedCard = "create_connected_card"
+	automationActionSendEmailTemplate   = "send_email_template"
+
+	automationSchedulerFrequencyKey = "scheduler_frequency"
+	automationSchedulerCronKey      = "scheduler_cron"
+	automationSchedulerTimeZoneKey  = "scheduler_timezone"
+	automationSchedulerStartDateKey = "scheduler_start_date"
+
+	automationMatchAll = "all"
+	automationMatchAny = "any"
+)
+
+type AutomationEventModel struct {
+	CardCreated  *AutomationCardCreatedModel  `tfsdk:"card_created"`
+	CardMoved    *AutomationCardMovedModel    `tfsdk:"card_moved"`
+	FieldUpdated *AutomationFieldUpdatedModel `tfsdk:"field_updated"`
+	Scheduled    *AutomationScheduledModel    `tfsdk:"scheduled"`
+}
+
+type AutomationCardCreatedModel struct{}
+
+type AutomationCardMovedModel struct {
+	ToPhaseId types.String `tfsdk:"to_phase_id"`
+}
+
+type AutomationFieldUpdatedModel struct {
+	FieldIds types.Set `tfsdk:"field_ids"`
+}
+
+type AutomationScheduledModel struct {
+	Frequency types.String `tfsdk:"frequency"`
+	Cron      types.String `tfsdk:"cron"`
+	TimeZone  types.String `tfsdk:"timezone"`
+	StartDate types.String `tfsdk:"start_date"`
+}
+
+type AutomationActionModel struct {
+	MoveCard            *AutomationMoveCardModel          `tfsdk:"move_card"`
+	UpdateField         *AutomationFieldMapModel          `tfsdk:"update_field"`
+	CreateConnectedCard *AutomationFieldMapModel          `tfsdk:"create_connected_card"`
+	SendEmailTemplate   *AutomationSendEmailTemplateModel `tfsdk:"send_email_template"`
+}
+
+type AutomationMoveCardModel struct {
+	ToPhaseId types.String `tfsdk:"to_phase_id"`
+}
+
+type AutomationFieldMapModel struct {
+	Fields []AutomationFieldValueModel `tfsdk:"fields"`
+}
+
+type AutomationFieldValueModel struct {
+	FieldId   types.String `tfsdk:"field_id"`
+	InputMode types.String `tfsdk:"input_mode"`
+	Value     types.String `tfsdk:"value"`
+}
+
+type AutomationSendEmailTemplateModel struct {
+	EmailTemplateId types.String `tfsdk:"email_template_id"`
+}
+
+type AutomationConditionModel struct {
+	Match       types.String                `tfsdk:"match"`
+	Expressions []AutomationExpressionModel `tfsdk:"expressions"`
+}
+
+type AutomationExpressionModel struct {
+	FieldId   types.String `tfsdk:"field_id"`
+	Operation types.String `tfsdk:"operation"`
+	Value     types.String `tfsdk:"value"`
+}
+
+func automationEventAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:   true,
+		Validators: []validator.Object{objectvalidator.ConflictsWith(path.MatchRoot("event_params"))},
+		Description: "Typed parameters for the most common events, as an alternative to `event_params`. Set exactly one of the nested attributes; " +
+			"it must match `event_id`.",
+		Attributes: map[string]schema.Attribute{
+			"card_created": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "For event_id `card_created`. The event takes no parameters; set it to `{}`.",
+				Attributes:  map[string]schema.Attribute{},
+			},
+			"card_moved": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "For event_id `card_moved`.",
+				Attributes: map[string]schema.Attribute{
+					"to_phase_id": requiredNonEmptyString("The phase a card must be moved to for the automation to run."),
+				},
+			},
+			"field_updated": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "For event_id `field_updated`.",
+				Attributes: map[string]schema.Attribute{
+					"field_ids": schema.SetAttribute{
+						Required:    true,
+						ElementType: types.StringType,
+						Description: "The internal IDs of the fields whose update runs the automation.",
+					},
+				},
+			},
+			"scheduled": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "For event_id `scheduler`. Set one of `frequency` or `cron`.",
+				Attributes: map[string]schema.Attribute{
+					"frequency": schema.StringAttribute{
+						Optional:    true,
+						Description: "How often the automation runs: " + strings.Join(automationSchedulerFrequencies, ", ") + ".",
+						Validators: []validator.String{
+							stringvalidator.OneOf(automationSchedulerFrequencies...),
+							stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("cron")),
+						},
+					},
+					"cron": schema.StringAttribute{
+						Optional:    true,
+						Description: "A five-field cron expression (minute hour day-of-month month day-of-week), e.g. `0 9 * * MON`, for schedules `frequency` cannot express.",
+						Validators:  []validator.String{validators.Cron()},
+					},
+					"timezone": schema.StringAttribute{
+						Optional:    true,
+						Description: "The IANA time zone the schedule is evaluated in, e.g. `America/Sao_Paulo`. Defaults to the organization's time zone.",
+						Validators:  []validator.String{validators.TimeZone()},
+					},
+					"start_date": schema.StringAttribute{
+						Optional:    true,
+						Description: "When the schedule starts, as a date (`2025-01-31`) or an RFC 3339 timestamp.",
+						Validators:  []validator.String{validators.Date()},
+					},
+				},
+			},
+		},
+	}
+}
+
+func automationActionAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:   true,
+		Validators: []validator.Object{objectvalidator.ConflictsWith(path.MatchRoot("action_params"))},
+		Description: "Typed parameters for the most common actions, as an alternative to `action_params`. Set exactly one of the nested attributes; " +
+			"it must match `action_id`.",
+		Attributes: map[string]schema.Attribute{
+			"move_card": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "For action_id `move_single_card`.",
+				Attributes: map[string]schema.Attribute{
+					"to_phase_id": requiredNonEmptyString("The phase the card is moved to."),
+				},
+			},
+			"update_field": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "For action_id `update_card_field`.",
+				Attributes:  map[string]schema.Attribute{"fields": automationFieldValuesAttribute("The fields to update on the card.")},
+			},
+			"create_connected_card": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "For action_id `create_connected_card`. The card is created in `action_repo_id`.",
+				Attributes:  map[string]schema.Attribute{"fields": automationFieldValuesAttribute("The fields to fill on the new card.")},
+			},
+			"send_email_template": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "For action_id `send_email_template`.",
+				Attributes: map[string]schema.Attribute{
+					"email_template_id": requiredNonEmptyString("The ID of the email template to send."),
+				},
+			},
+		},
+	}
+}
+
+func automationFieldValuesAttribute(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Required:    true,
+		Description: description,
+		Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+		NestedObject: schema.NestedAttributeObject{Attributes: map[string]schema.Attribute{
+			"field_id": requiredNonEmptyString("The internal ID of the target field."),
+			"input_mode": requiredNonEmptyString(
+				"How Pipefy supplies the field value, e.g. `fixed_value` or `copy_from`. " +
+					"See the Pipefy API reference (https://developers.pipefy.com/reference).",
+			),
+			"value": schema.StringAttribute{
+				Optional:    true,
+				Description: "The fixed value or source-field reference.",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+		}},
+	}
+}
+
+func automationConditionExpressionAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:    true,
+		Validators:  []validator.Object{objectvalidator.ConflictsWith(path.MatchRoot("condition"))},
+		Description: "A typed condition, as an alternative to `condition`.",
+		Attributes: map[string]schema.Attribute{
+			"match": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(automationMatchAll),
+				Description: "Whether `all` expressions or `any` of them must hold. Defaults to `all`.",
+				Validators:  []validator.String{stringvalidator.OneOf(automationMatchAll, automationMatchAny)},
+			},
+			"expressions": schema.ListNestedAttribute{
+				Required:    true,
+				Description: "The field comparisons evaluated for the condition.",
+				Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+				NestedObject: schema.NestedAttributeObject{Attributes: map[string]schema.Attribute{
+					"field_id": requiredNonEmptyString("The internal ID of the field compared."),
+					"operation": requiredNonEmptyString(
+						"The comparison, e.g. `equals` or `present`. See the Pipefy API reference (https://developers.pipefy.com/reference).",
+					),
+					"value": schema.StringAttribute{
+						Optional:    true,
+						Description: "The value compared against. Leave unset for operations that take no value.",
+						Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+					},
+				}},
+			},
+		},
+	}
+}
+
+// validateAutomationTyped checks that each typed attribute sets exactly one
+// variant and that the variant matches event_id or action_id.
+func validateAutomationTyped(data AutomationModel, diags *diag.Diagnostics) {
+	if data.Event != nil {
+		variants := map[string]bool{
+			automationEventCardCreated:  data.Event.CardCreated != nil,
+			automationEventCardMoved:    data.Event.CardMoved != nil,
+			automationEventFieldUpdated: data.Event.FieldUpdated != nil,
+			automationEventScheduler:    data.Event.Scheduled != nil,
+		}
+		checkAutomationVariant(path.Root("event"), "event_id", data.EventId, variants, diags)
+	}
+	if data.Action != nil {
+		variants := map[string]bool{
+			automationActionMoveCard:            data.Action.MoveCard != nil,
+			automationActionUpdateField:         data.Action.UpdateField != nil,
+			automationActionCreateConnectedCard: data.Action.CreateConnectedCard != nil,
+			automationActionSendEmailTemplate:   data.Action.SendEmailTemplate != nil,
+		}
+		checkAutomationVariant(path.Root("action"), "action_id", data.ActionId, variants, diags)
+	}
+}
+
+func checkAutomationVariant(p path.Path, idAttr string, id types.String, variants map[string]bool, diags *diag.Diagnostics) {
+	var set []string
+	for variantID, ok := range variants {
+		if ok {
+			set = append(set, variantID)
+		}
+	}
+	switch {
+	case len(set) != 1:
+		diags.AddAttributeError(p, "Invalid typed automation parameters", fmt.Sprintf("exactly one nested attribute must be set, got %d", len(set)))
+	case hasString(id) && id.ValueString() != set[0]:
+		diags.AddAttributeError(p, "Invalid typed automation parameters",
+			fmt.Sprintf("the nested attribute set is for %s %q, but %s is %q", idAttr, set[0], idAttr, id.ValueString()))
+	}
+}
+
+// eventParams maps the typed event to the event_params input object. A nil
+// map means the event takes no parameters.
+func (e *AutomationEventModel) eventParams(ctx context.Context, diags *diag.Diagnostics) map[string]any {
+	switch {
+	case e.CardMoved != nil:
+		return map[string]any{"to_phase_id": e.CardMoved.ToPhaseId.ValueString()}
+	case e.FieldUpdated != nil:
+		var ids []string
+		diags.Append(e.FieldUpdated.FieldIds.ElementsAs(ctx, &ids, false)...)
+		return map[string]any{"triggerFieldIds": ids}
+	case e.Scheduled != nil:
+		params := map[string]any{}
+		for key, v := range e.Scheduled.params() {
+			if hasString(*v) {
+				params[key] = v.ValueString()
+			}
+		}
+		return params
+	}
+	return nil
+}
+
+// params maps the scheduler event_params keys to the attributes holding them.
+func (s *AutomationScheduledModel) params() map[string]*types.String {
+	return map[string]*types.String{
+		automationSchedulerFrequencyKey: &s.Frequency,
+		automationSchedulerCronKey:      &s.Cron,
+		automationSchedulerTimeZoneKey:  &s.TimeZone,
+		automationSchedulerStartDateKey: &s.StartDate,
+	}
+}
+
+func (a *AutomationActionModel) actionParams() map[string]any {
+	switch {
+	case a.MoveCard != nil:
+		return map[string]any{"to_phase_id": a.MoveCard.ToPhaseId.ValueString()}
+	case a.UpdateField != nil:
+		return map[string]any{"field_map": fieldMapParams(a.UpdateField.Fields)}
+	case a.CreateConnectedCard != nil:
+		return map[string]any{"field_map": fieldMapParams(a.CreateConnectedCard.Fields)}
+	case a.SendEmailTemplate != nil:
+		return map[string]any{"email_template_id": a.SendEmailTemplate.EmailTemplateId.ValueString()}
+	}
+	return nil
+}
+
+func fieldMapParams(fields []AutomationFieldValueModel) []map[string]any {
+	out := make([]map[string]any, len(fields))
+	for i, f := range fields {
+		m := map[string]any{"fieldId": f.FieldId.ValueString(), "inputMode": f.InputMode.ValueString()}
+		if hasString(f.Value) {
+			m["value"] = f.Value.ValueString()
+		}
+		out[i] = m
+	}
+	return out
+}
+
+// conditionParams builds the condition input. Pipefy evaluates
+// expressions_structure as OR-ed groups of AND-ed expression indexes, so
+// "all" is one group holding every index and "any" is one group per index.
+func (c *AutomationConditionModel) conditionParams() map[string]any {
+	expressions := make([]map[string]any, len(c.Expressions))
+	indexes := make([]int, len(c.Expressions))
+	for i, e := range c.Expressions {
+		expressions[i] = map[string]any{
+			"structure_id":  i,
+			"field_address": e.FieldId.ValueString(),
+			"operation":     e.Operation.ValueString(),
+			"value":         e.Value.ValueString(),
+		}
+		indexes[i] = i
+	}
+	structure := [][]int{indexes}
+	if c.Match.ValueString() == automationMatchAny {
+		structure = make([][]int, len(indexes))
+		for i := range indexes {
+			structure[i] = []int{i}
+		}
+	}
+	return map[string]any{"expressions": expressions, "expressions_structure": structure}
+}
+
+// refresh updates the variant already in state from fetched event_params.
+func (e *AutomationEventModel) refresh(ctx context.Context, params map[string]any, diags *diag.Diagnostics) {
+	switch {
+	case e.CardMoved != nil:
+		e.CardMoved.ToPhaseId = jsonStringValue(params["to_phase_id"])
+	case e.FieldUpdated != nil:
+		set, d := types.SetValueFrom(ctx, types.StringType, jsonStrings(params["triggerFieldIds"]))
+		diags.Append(d...)
+		e.FieldUpdated.FieldIds = set
+	case e.Scheduled != nil:
+		prior := e.Scheduled.StartDate
+		for key, v := range e.Scheduled.params() {
+			*v = jsonStringValue(params[key])
+		}
+		if sameScheduleStart(prior, e.Scheduled.StartDate) {
+			e.Scheduled.StartDate = prior
+		}
+	}
+}
+
+// sameScheduleStart reports whether a fetched start_date is the configured one
+// in another format: the same instant, or a timestamp on the configured date.
+func sameScheduleStart(prior, fetched types.String) bool {
+	if !hasString(prior) || !hasString(fetched) {
+		return false
+	}
+	f, err := time.Parse(time.RFC3339, fetched.ValueString())
+	if err != nil {
+		return prior.Equal(fetched)
+	}
+	if p, err := time.Parse(time.RFC3339, prior.ValueString()); err == nil {
+		return p.Equal(f)
+	}
+	return f.Format(time.DateOnly) == prior.ValueString()
+}
+
+func (a *AutomationActionModel) refresh(params map[string]any) {
+	switch {
+	case a.MoveCard != nil:
+		a.MoveCard.ToPhaseId = jsonStringValue(params["to_phase_id"])
+	case a.UpdateField != nil:
+		a.UpdateField.Fields = fieldMapFromParams(params["field_map"])
+	case a.CreateConnectedCard != nil:
+		a.CreateConnectedCard.Fields = fieldMapFromParams(params["field_map"])
+	case a.SendEmailTemplate != nil:
+		a.SendEmailTemplate.EmailTemplateId = jsonStringValue(params["email_template_id"])
+	}
+}
+
+func fieldMapFromParams(v any) []AutomationFieldValueModel {
+	items, _ := v.([]any)
+	out := make([]AutomationFieldValueModel, 0, len(items))
+	for _, item := range items {
+		m, _ := item.(map[string]any)
+		out = append(out, AutomationFieldValueModel{
+			FieldId:   jsonStringValue(m["fieldId"]),
+			InputMode: jsonStringValue(m["inputMode"]),
+			Value:     jsonStringValue(m["value"]),
+		})
+	}
+	return out
+}
+
+// refresh maps a fetched condition back. A structure that is neither "all"
+// nor "any" is left as it was, so it shows up through the expressions only.
+func (c *AutomationConditionModel) refresh(params map[string]any) {
+	items, _ := params["expressions"].([]any)
+	c.Expressions = make([]AutomationExpressionModel, 0, len(items))
+	for _, item := range items {
+		m, _ := item.(map[string]any)
+		c.Expressions = append(c.Expressions, AutomationExpressionModel{
+			FieldId:   jsonStringValue(m["field_address"]),
+			Operation: jsonStringValue(m["operation"]),
+			Value:     jsonStringValue(m["value"]),
+		})
+	}
+	groups, _ := params["expressions_structure"].([]any)
+	switch {
+	case len(groups) == 1 && len(c.Expressions) > 1:
+		c.Match = types.StringValue(automationMatchAll)
+	case len(groups) > 1:
+		c.Match = types.StringValue(automationMatchAny)
+	}
+}
+
+// jsonStringValue maps a decoded JSON scalar to a string attribute. Numbers
+// keep their literal form; null and "" map to null.
+func jsonStringValue(v any) types.String {
+	switch t := v.(type) {
+	case string:
+		if t == "" {
+			return types.StringNull()
+		}
+		return types.StringValue(t)
+	case json.Number:
+		return types.StringValue(t.String())
+	case bool:
+		return types.StringValue(fmt.Sprint(t))
+	}
+	return types.StringNull()
+}
+
+func jsonStrings(v any) []string {
+	items, _ := v.([]any)
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		if s := jsonStringValue(item); !s.IsNull() {
+			out = append(out, s.ValueString())
+		}
+	}
+	return out
+}
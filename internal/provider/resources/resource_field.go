@@ -25,6 +25,7 @@ import (
 
 var _ resource.Resource = &FieldResource{}
 var _ resource.ResourceWithImportState = &FieldResource{}
+var _ resource.ResourceWithValidateConfig = &FieldResource{}
 
 func NewFieldResource() resource.Resource { return &FieldResource{} }
 
@@ -46,6 +47,48 @@ type FieldModel struct {
 	MinimalView      types.Bool    `tfsdk:"minimal_view"`
 	CustomValidation types.String  `tfsdk:"custom_validation"`
 	Index            types.Float64 `tfsdk:"index"`
+
+	Connector *fieldConnectorModel `tfsdk:"connector"`
+}
+
+const createPhaseFieldMutation = "mutation CreatePhaseField_tf($phaseId:ID!,$type:ID!,$label:String!,$required:Boolean,$options:[String],$description:String,$help:String,$editable:Boolean,$minimalView:Boolean,$customValidation:String,$index:Float,$connectedRepoId:ID,$canCreateNewConnected:Boolean,$canConnectExisting:Boolean,$canConnectMultiples:Boolean){ createPhaseField(input:{ phase_id:$phaseId, type:$type, label:$label, required:$required, options:$options, description:$description, help:$help, editable:$editable, minimal_view:$minimalView, custom_validation:$customValidation, index:$index, connectedRepoId:$connectedRepoId, canCreateNewConnected:$canCreateNewConnected, canConnectExisting:$canConnectExisting, canConnectMultiples:$canConnectMultiples }){ phase_field{ " + fieldgql.Selection + " } } }"
+const updatePhaseFieldMutation = "mutation UpdatePhaseField_tf($id:ID!,$uuid:ID!,$label:String!,$required:Boolean,$options:[String],$description:String,$help:String,$editable:Boolean,$minimalView:Boolean,$customValidation:String,$index:Float,$canCreateNewConnected:Boolean,$canConnectExisting:Boolean,$canConnectMultiples:Boolean){ updatePhaseField(input:{ id:$id, uuid:$uuid, label:$label, required:$required, options:$options, description:$description, help:$help, editable:$editable, minimal_view:$minimalView, custom_validation:$customValidation, index:$index, canCreateNewConnected:$canCreateNewConnected, canConnectExisting:$canConnectExisting, canConnectMultiples:$canConnectMultiples }){ phase_field{ " + fieldgql.Selection + " } } }"
+const deletePhaseFieldMutation = "mutation DeletePhaseField_tf($id:ID!,$pipeUuid:ID!){ deletePhaseField(input:{ id:$id, pipeUuid:$pipeUuid }){ success } }"
+const getPhaseFieldsQuery = "query GetPhaseFields_tf($phaseId:ID!){ phase(id:$phaseId){ fields{ " + fieldgql.Selection + " } } }"
+const getPhaseRepoIDQuery = "query GetPhaseRepoId_tf($id:ID!){ phase(id:$id){ repo_id } }"
+
+// resolvePhaseRepoID returns the ID of the pipe that owns a phase, which is the
+// key phase-field writes lock on.
+func resolvePhaseRepoID(ctx context.Context, api *client.ApiClient, phaseID string) (string, error) {
+	var out struct {
+		Phase *struct {
+			RepoId int `json:"repo_id"`
+		} `json:"phase"`
+	}
+	if err := api.DoGraphQL(ctx, getPhaseRepoIDQuery, map[string]any{"id": phaseID}, &out); err != nil {
+		return "", fmt.Errorf("failed to fetch phase repo_id: %w", err)
+	}
+	if out.Phase == nil || out.Phase.RepoId == 0 {
+		return "", fmt.Errorf("could not resolve valid phase repo_id from phase query")
+	}
+	return strconv.FormatInt(int64(out.Phase.RepoId), 10), nil
+}
+
+// fetchPhaseFields lists the fields of a phase. found is false when the phase
+// no longer exists.
+func fetchPhaseFields(ctx context.Context, api *client.ApiClient, phaseID string) (fields []fieldgql.Field, found bool, err error) {
+	var out struct {
+		Phase *struct {
+			Fields []fieldgql.Field `json:"fields"`
+		} `json:"phase"`
+	}
+	if err := api.DoGraphQL(ctx, getPhaseFieldsQuery, map[string]any{"phaseId": phaseID}, &out); err != nil {
+		return nil, false, err
+	}
+	if out.Phase == nil {
+		return nil, false, nil
+	}
+	return out.Phase.Fields, true, nil
 }
 
 func (r *FieldResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -55,66 +98,77 @@ func (r *FieldResource) Metadata(ctx context.Context, req resource.MetadataReque
 func (r *FieldResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Phase field resource",
-		Attributes: map[string]schema.Attribute{
-			"id":          schema.StringAttribute{Computed: true, Description: "The slug of the field", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
-			"internal_id": schema.StringAttribute{Computed: true, Description: "The unique internal ID of the field", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
-			"uuid":        schema.StringAttribute{Computed: true, Description: "The field's UUID. A stable identifier that does not change when the label changes.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
-			"phase_id":    schema.StringAttribute{Required: true, Description: "The ID of the phase that the field belongs to", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-			"type":        schema.StringAttribute{Required: true, Description: "The field type. See https://developers.pipefy.com/reference for the current list of supported types.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-			"label":       schema.StringAttribute{Required: true, Description: "The displayed name of the field"},
-			"required": schema.BoolAttribute{
-				Optional:      true,
-				Computed:      true,
-				Description:   "Whether the field is required or not",
-				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
-			},
-			"options": schema.ListAttribute{
-				ElementType:   types.StringType,
-				Optional:      true,
-				Computed:      true,
-				Description:   "Choices for option-based field types (checklist_vertical, checklist_horizontal, radio_vertical, radio_horizontal, select, label_select). Order is preserved and user-visible.",
-				PlanModifiers: []planmodifier.List{listplanmodifier.UseStateForUnknown()},
-			},
-			"description": schema.StringAttribute{
-				Optional:      true,
-				Computed:      true,
-				Description:   "Helper description shown under the field",
-				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
-			},
-			"help": schema.StringAttribute{
-				Optional:      true,
-				Computed:      true,
-				Description:   "Help text shown for the field",
-				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
-			},
-			"editable": schema.BoolAttribute{
-				Optional:      true,
-				Computed:      true,
-				Description:   "Whether the field value can be edited after creation",
-				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
-			},
-			"minimal_view": schema.BoolAttribute{
-				Optional:      true,
-				Computed:      true,
-				Description:   "Whether the field is shown in the card's minimal (summary) view",
-				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
-			},
-			"custom_validation": schema.StringAttribute{
-				Optional:      true,
-				Computed:      true,
-				Description:   "Custom validation rule applied to the field value",
-				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
-			},
-			"index": schema.Float64Attribute{
-				Optional:      true,
-				Computed:      true,
-				Description:   "Position of the field within the phase form",
-				PlanModifiers: []planmodifier.Float64{float64planmodifier.UseStateForUnknown()},
-			},
+		Attributes:          fieldAttributes(),
+	}
+}
+
+// fieldAttributes returns the attributes of pipefy_field, which
+// pipefy_start_form_field shares.
+func fieldAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id":          schema.StringAttribute{Computed: true, Description: "The slug of the field", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+		"internal_id": schema.StringAttribute{Computed: true, Description: "The unique internal ID of the field", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+		"uuid":        schema.StringAttribute{Computed: true, Description: "The field's UUID. A stable identifier that does not change when the label changes.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+		"phase_id":    schema.StringAttribute{Required: true, Description: "The ID of the phase that the field belongs to. Use `pipefy_start_form_field` for start form fields instead of passing a pipe's `start_form_phase_id`.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+		"type":        schema.StringAttribute{Required: true, Description: "The field type. See https://developers.pipefy.com/reference for the current list of supported types.", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+		"label":       schema.StringAttribute{Required: true, Description: "The displayed name of the field"},
+		"required": schema.BoolAttribute{
+			Optional:      true,
+			Computed:      true,
+			Description:   "Whether the field is required or not",
+			PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+		},
+		"options": schema.ListAttribute{
+			ElementType:   types.StringType,
+			Optional:      true,
+			Computed:      true,
+			Description:   "Choices for option-based field types (checklist_vertical, checklist_horizontal, radio_vertical, radio_horizontal, select, label_select). Order is preserved and user-visible.",
+			PlanModifiers: []planmodifier.List{listplanmodifier.UseStateForUnknown()},
+		},
+		"description": schema.StringAttribute{
+			Optional:      true,
+			Computed:      true,
+			Description:   "Helper description shown under the field",
+			PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+		},
+		"help": schema.StringAttribute{
+			Optional:      true,
+			Computed:      true,
+			Description:   "Help text shown for the field",
+			PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 		},
+		"editable": schema.BoolAttribute{
+			Optional:      true,
+			Computed:      true,
+			Description:   "Whether the field value can be edited after creation",
+			PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+		},
+		"minimal_view": schema.BoolAttribute{
+			Optional:      true,
+			Computed:      true,
+			Description:   "Whether the field is shown in the card's minimal (summary) view",
+			PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+		},
+		"custom_validation": schema.StringAttribute{
+			Optional:      true,
+			Computed:      true,
+			Description:   "Custom validation rule applied to the field value",
+			PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+		},
+		"index": schema.Float64Attribute{
+			Optional:      true,
+			Computed:      true,
+			Description:   "Position of the field within the phase form. Use `pipefy_field_order` to order the fields of a phase declaratively and leave index unset.",
+			PlanModifiers: []planmodifier.Float64{float64planmodifier.UseStateForUnknown()},
+		},
+		"connector": fieldConnectorAttribute(),
 	}
 }
 
+func (r *FieldResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateFieldConnector(ctx, req.Config, &resp.Diagnostics)
+}
+
 func (r *FieldResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -136,46 +190,13 @@ func (r *FieldResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Resolve repo_id from the phase to lock per repo
 	// pipefy api does not allow multiple field creations at the same time for the same repo
-	phaseQuery := "query GetPhaseRepoId_tf($id:ID!){ phase(id:$id){ repo_id } }"
-	phaseVars := map[string]any{"id": data.PhaseId.ValueString()}
-	var phaseOut struct {
-		Phase *struct {
-			RepoId int `json:"repo_id"`
-		} `json:"phase"`
-	}
-	if err := r.api.DoGraphQL(ctx, phaseQuery, phaseVars, &phaseOut); err != nil {
-		resp.Diagnostics.AddError("create field failed", fmt.Sprintf("failed to fetch phase repo_id: %s", err.Error()))
-		return
-	}
-	if phaseOut.Phase == nil || phaseOut.Phase.RepoId == 0 {
-		resp.Diagnostics.AddError("create field failed", "could not resolve valid phase repo_id from phase query")
-		return
-	}
-	repoIDStr := strconv.FormatInt(int64(phaseOut.Phase.RepoId), 10)
-
-	unlock := locks.LockRepo(repoIDStr)
-	defer unlock()
-
-	mutation := "mutation CreatePhaseField_tf($phaseId:ID!,$type:ID!,$label:String!,$required:Boolean,$options:[String],$description:String,$help:String,$editable:Boolean,$minimalView:Boolean,$customValidation:String,$index:Float){ createPhaseField(input:{ phase_id:$phaseId, type:$type, label:$label, required:$required, options:$options, description:$description, help:$help, editable:$editable, minimal_view:$minimalView, custom_validation:$customValidation, index:$index }){ phase_field{ " + fieldgql.Selection + " } } }"
-	vars := map[string]any{
-		"phaseId": data.PhaseId.ValueString(),
-		"type":    data.Type.ValueString(),
-		"label":   data.Label.ValueString(),
-	}
-	addFieldWriteVars(ctx, data, vars, &resp.Diagnostics)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	var out struct {
-		CreatePhaseField struct {
-			PhaseField fieldgql.Field `json:"phase_field"`
-		} `json:"createPhaseField"`
-	}
-	if err := r.api.DoGraphQL(ctx, mutation, vars, &out); err != nil {
+	repoIDStr, err := resolvePhaseRepoID(ctx, r.api, data.PhaseId.ValueString())
+	if err != nil {
 		resp.Diagnostics.AddError("create field failed", err.Error())
 		return
 	}
-	applyFieldToModel(ctx, &data, out.CreatePhaseField.PhaseField, &resp.Diagnostics)
+
+	createField(ctx, r.api, repoIDStr, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -193,115 +214,129 @@ func (r *FieldResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// Query the phase to get the field information
-	query := "query GetPhaseFields_tf($phaseId:ID!){ phase(id:$phaseId){ fields{ " + fieldgql.Selection + " } } }"
-	vars := map[string]any{"phaseId": data.PhaseId.ValueString()}
-	var out struct {
-		Phase *struct {
-			Fields []fieldgql.Field `json:"fields"`
-		} `json:"phase"`
-	}
-	if err := r.api.DoGraphQL(ctx, query, vars, &out); err != nil {
-		resp.Diagnostics.AddError("read field failed", err.Error())
+	found := readField(ctx, r.api, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	if out.Phase == nil {
+	if !found {
 		resp.State.RemoveResource(ctx)
 		return
 	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
 
-	found, ok := fieldgql.FindByUUID(out.Phase.Fields, data.Uuid.ValueString())
-	if !ok {
-		resp.State.RemoveResource(ctx)
+func (r *FieldResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data FieldModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	applyFieldToModel(ctx, &data, found, &resp.Diagnostics)
+	updateField(ctx, r.api, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func (r *FieldResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+func (r *FieldResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data FieldModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	mutation := "mutation UpdatePhaseField_tf($id:ID!,$uuid:ID!,$label:String!,$required:Boolean,$options:[String],$description:String,$help:String,$editable:Boolean,$minimalView:Boolean,$customValidation:String,$index:Float){ updatePhaseField(input:{ id:$id, uuid:$uuid, label:$label, required:$required, options:$options, description:$description, help:$help, editable:$editable, minimal_view:$minimalView, custom_validation:$customValidation, index:$index }){ phase_field{ " + fieldgql.Selection + " } } }"
+
+	// Fetch repo_id from the phase
+	repoIDStr, err := resolvePhaseRepoID(ctx, r.api, data.PhaseId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("delete field failed", err.Error())
+		return
+	}
+	deleteField(ctx, r.api, repoIDStr, data, &resp.Diagnostics)
+}
+
+// createField creates data's field in data.PhaseId while holding the lock of
+// repoID, the pipe that owns the phase.
+func createField(ctx context.Context, api *client.ApiClient, repoID string, data *FieldModel, diags *diag.Diagnostics) {
+	unlock := locks.LockRepo(repoID)
+	defer unlock()
+
 	vars := map[string]any{
-		"id":   data.Id.ValueString(),
-		"uuid": data.Uuid.ValueString(),
+		"phaseId": data.PhaseId.ValueString(),
+		"type":    data.Type.ValueString(),
+		"label":   data.Label.ValueString(),
 	}
-	if !data.Label.IsNull() {
-		vars["label"] = data.Label.ValueString()
+	if data.Connector != nil {
+		vars["connectedRepoId"] = data.Connector.RepoId.ValueString()
 	}
-	addFieldWriteVars(ctx, data, vars, &resp.Diagnostics)
-	if resp.Diagnostics.HasError() {
+	addFieldWriteVars(ctx, *data, vars, diags)
+	if diags.HasError() {
 		return
 	}
 	var out struct {
-		UpdatePhaseField struct {
+		CreatePhaseField struct {
 			PhaseField fieldgql.Field `json:"phase_field"`
-		} `json:"updatePhaseField"`
-	}
-	if err := r.api.DoGraphQL(ctx, mutation, vars, &out); err != nil {
-		resp.Diagnostics.AddError("update field failed", err.Error())
-		return
+		} `json:"createPhaseField"`
 	}
-	applyFieldToModel(ctx, &data, out.UpdatePhaseField.PhaseField, &resp.Diagnostics)
-	if resp.Diagnostics.HasError() {
+	if err := api.DoGraphQL(ctx, createPhaseFieldMutation, vars, &out); err != nil {
+		diags.AddError("create field failed", err.Error())
 		return
 	}
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	applyFieldToModel(ctx, data, out.CreatePhaseField.PhaseField, diags)
 }
 
-func (r *FieldResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data FieldModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
+// readField refreshes data from its phase, looked up by uuid. It returns false
+// when the field or the phase no longer exists.
+func readField(ctx context.Context, api *client.ApiClient, data *FieldModel, diags *diag.Diagnostics) bool {
+	fields, phaseFound, err := fetchPhaseFields(ctx, api, data.PhaseId.ValueString())
+	if err != nil {
+		diags.AddError("read field failed", err.Error())
+		return false
+	}
+	if !phaseFound {
+		return false
 	}
 
-	// Fetch repo_id from the phase
-	phaseQuery := "query GetPhaseRepoId_tf($id:ID!){ phase(id:$id){ repo_id } }"
-	phaseVars := map[string]any{"id": data.PhaseId.ValueString()}
-	var phaseOut struct {
-		Phase *struct {
-			RepoId int `json:"repo_id"`
-		} `json:"phase"`
+	found, ok := fieldgql.FindByUUID(fields, data.Uuid.ValueString())
+	if !ok {
+		return false
 	}
-	if err := r.api.DoGraphQL(ctx, phaseQuery, phaseVars, &phaseOut); err != nil {
-		resp.Diagnostics.AddError("delete field failed", fmt.Sprintf("failed to fetch phase repo_id: %s", err.Error()))
-		return
+	applyFieldToModel(ctx, data, found, diags)
+	return true
+}
+
+func updateField(ctx context.Context, api *client.ApiClient, data *FieldModel, diags *diag.Diagnostics) {
+	vars := map[string]any{
+		"id":   data.Id.ValueString(),
+		"uuid": data.Uuid.ValueString(),
+	}
+	if !data.Label.IsNull() {
+		vars["label"] = data.Label.ValueString()
 	}
-	if phaseOut.Phase == nil {
-		resp.Diagnostics.AddError("delete field failed", "could not resolve phase from phase query")
+	addFieldWriteVars(ctx, *data, vars, diags)
+	if diags.HasError() {
 		return
 	}
-	repoIDStr := strconv.FormatInt(int64(phaseOut.Phase.RepoId), 10)
-	if repoIDStr == "0" {
-		resp.Diagnostics.AddError("delete field failed", "could not resolve valid phase repo_id from phase query")
+	var out struct {
+		UpdatePhaseField struct {
+			PhaseField fieldgql.Field `json:"phase_field"`
+		} `json:"updatePhaseField"`
+	}
+	if err := api.DoGraphQL(ctx, updatePhaseFieldMutation, vars, &out); err != nil {
+		diags.AddError("update field failed", err.Error())
 		return
 	}
+	applyFieldToModel(ctx, data, out.UpdatePhaseField.PhaseField, diags)
+}
 
-	pipeUUID, err := resolvePipeUUID(ctx, r.api, repoIDStr)
+// deleteField deletes data's field; repoID is the pipe that owns its phase.
+func deleteField(ctx context.Context, api *client.ApiClient, repoID string, data FieldModel, diags *diag.Diagnostics) {
+	pipeUUID, err := api.PipeUUID(ctx, repoID)
 	if err != nil {
-		resp.Diagnostics.AddError("delete field failed", err.Error())
+		diags.AddError("delete field failed", err.Error())
 		return
 	}
-
-	mutation := "mutation DeletePhaseField_tf($id:ID!,$pipeUuid:ID!){ deletePhaseField(input:{ id:$id, pipeUuid:$pipeUuid }){ success } }"
-	vars := map[string]any{"id": data.Id.ValueString(), "pipeUuid": pipeUUID}
-	var out struct {
-		DeletePhaseField struct {
-			Success bool `json:"success"`
-		} `json:"deletePhaseField"`
-	}
-	if err := r.api.DoGraphQL(ctx, mutation, vars, &out); err != nil {
-		resp.Diagnostics.AddError("delete field failed", err.Error())
-		return
+	if err := deletePhaseField(ctx, api, data.Id.ValueString(), pipeUUID); err != nil {
+		diags.AddError("delete field failed", err.Error())
 	}
 }
 
@@ -367,6 +402,7 @@ func addFieldWriteVars(ctx context.Context, data FieldModel, vars map[string]any
 	if !data.Index.IsNull() && !data.Index.IsUnknown() {
 		vars["index"] = data.Index.ValueFloat64()
 	}
+	addConnectorWriteVars(data.Connector, vars)
 }
 
 // applyFieldToModel maps a fetched field onto the model. phase_id is not in the
@@ -389,4 +425,5 @@ func applyFieldToModel(ctx context.Context, data *FieldModel, f fieldgql.Field,
 		data.Index = types.Float64Value(*f.Index)
 	}
 	data.Options = optionsToList(ctx, f.Options, diags)
+	data.Connector = connectorFromAPI(f.Type, f.ConnectedRepoID(), f.CanCreateNewConnected, f.CanConnectExisting, f.CanConnectMultiples)
 }
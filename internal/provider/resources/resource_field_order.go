@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/client"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/fieldgql"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/locks"
+)
+
+var _ resource.Resource = &FieldOrderResource{}
+var _ resource.ResourceWithImportState = &FieldOrderResource{}
+
+func NewFieldOrderResource() resource.Resource { return &FieldOrderResource{} }
+
+type FieldOrderResource struct{ api *client.ApiClient }
+
+type FieldOrderModel struct {
+	Id         types.String `tfsdk:"id"`
+	PhaseId    types.String `tfsdk:"phase_id"`
+	FieldUuids types.List   `tfsdk:"field_uuids"`
+}
+
+func (r *FieldOrderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_field_order"
+}
+
+func (r *FieldOrderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the order of the fields of a phase or start form. Fields are positioned as listed in `field_uuids`; fields of the phase that are not listed keep their relative order after them. " +
+			"Reordering fields in the Pipefy UI is reported as drift. Leave `index` unset on the `pipefy_field` resources it orders.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: "The ID of the phase, used as the ID of the field order", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"phase_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the phase whose fields are ordered. Use a pipe's `start_form_phase_id` to order its start form.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"field_uuids": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "The UUIDs of the phase's fields, in form order. Every UUID must belong to a field of the phase.",
+				Validators:  []validator.List{listvalidator.SizeAtLeast(1), listvalidator.UniqueValues()},
+			},
+		},
+	}
+}
+
+func (r *FieldOrderResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	api, ok := req.ProviderData.(*client.ApiClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *ApiClient, got %T", req.ProviderData))
+		return
+	}
+	r.api = api
+}
+
+func (r *FieldOrderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FieldOrderModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = data.PhaseId
+	r.apply(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FieldOrderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FieldOrderModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.PhaseId.IsNull() || data.PhaseId.ValueString() == "" {
+		return
+	}
+
+	fields, found, err := fetchPhaseFields(ctx, r.api, data.PhaseId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("read field order failed", err.Error())
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// As with pipefy_phase_order, an import lists every field; otherwise the
+	// tracked fields are read back in their current form order.
+	var tracked []string
+	if !data.FieldUuids.IsNull() {
+		resp.Diagnostics.Append(data.FieldUuids.ElementsAs(ctx, &tracked, false)...)
+	}
+	sortFieldsByIndex(fields)
+	uuids := fieldUUIDs(fields)
+	if len(tracked) > 0 {
+		uuids = keepListed(uuids, tracked)
+	}
+	data.Id = data.PhaseId
+	list, d := types.ListValueFrom(ctx, types.StringType, uuids)
+	resp.Diagnostics.Append(d...)
+	data.FieldUuids = list
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FieldOrderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data FieldOrderModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = data.PhaseId
+	r.apply(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete only forgets the order; the fields stay where they are.
+func (r *FieldOrderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (r *FieldOrderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("phase_id"), req, resp)
+}
+
+// apply computes every field's index from the desired order and writes the
+// ones that moved. The fields are read and written under the repo lock, so
+// pipefy_field writes to the same pipe cannot interleave with the reorder.
+func (r *FieldOrderResource) apply(ctx context.Context, data FieldOrderModel, diags *diag.Diagnostics) {
+	var desired []string
+	diags.Append(data.FieldUuids.ElementsAs(ctx, &desired, false)...)
+	if diags.HasError() {
+		return
+	}
+	phaseID := data.PhaseId.ValueString()
+	repoID, err := resolvePhaseRepoID(ctx, r.api, phaseID)
+	if err != nil {
+		diags.AddError("apply field order failed", err.Error())
+		return
+	}
+
+	unlock := locks.LockRepo(repoID)
+	defer unlock()
+
+	fields, found, err := fetchPhaseFields(ctx, r.api, phaseID)
+	if err != nil {
+		diags.AddError("apply field order failed", err.Error())
+		return
+	}
+	if !found {
+		diags.AddError("apply field order failed", fmt.Sprintf("phase %s not found", phaseID))
+		return
+	}
+	sortFieldsByIndex(fields)
+	order, missing := reorder(fieldUUIDs(fields), desired)
+	if len(missing) > 0 {
+		diags.AddAttributeError(path.Root("field_uuids"), "apply field order failed", fmt.Sprintf("fields %v do not belong to phase %s", missing, phaseID))
+		return
+	}
+
+	for i, uuid := range order {
+		f, _ := fieldgql.FindByUUID(fields, uuid)
+		index := float64(i + 1)
+		if f.Index != nil && *f.Index == index {
+			continue
+		}
+		var out struct {
+			UpdatePhaseField struct {
+				PhaseField fieldgql.Field `json:"phase_field"`
+			} `json:"updatePhaseField"`
+		}
+		vars := map[string]any{"id": f.Id, "uuid": f.Uuid, "label": f.Label, "index": index}
+		if err := r.api.DoGraphQL(ctx, updatePhaseFieldMutation, vars, &out); err != nil {
+			diags.AddError("apply field order failed", fmt.Sprintf("field %q: %s", f.Label, err.Error()))
+			return
+		}
+	}
+}
+
+func fieldUUIDs(fields []fieldgql.Field) []string {
+	uuids := make([]string, len(fields))
+	for i, f := range fields {
+		uuids[i] = f.Uuid
+	}
+	return uuids
+}
@@ -34,6 +34,28 @@ type LabelModel struct {
 	Color  types.String `tfsdk:"color"`
 }
 
+const createLabelMutation = "mutation CreateLabel_tf($pipeId:ID!,$name:String!,$color:String!){ createLabel(input:{ pipe_id:$pipeId, name:$name, color:$color }){ label{ " + labelgql.Selection + " } } }"
+const updateLabelMutation = "mutation UpdateLabel_tf($id:ID!,$name:String!,$color:String!){ updateLabel(input:{ id:$id, name:$name, color:$color }){ label{ " + labelgql.Selection + " } } }"
+const deleteLabelMutation = "mutation DeleteLabel_tf($id:ID!){ deleteLabel(input:{ id:$id }){ success } }"
+const getPipeLabelsQuery = "query GetPipeLabels_tf($pipeId:ID!){ pipe(id:$pipeId){ labels{ " + labelgql.Selection + " } } }"
+
+// fetchPipeLabels lists the labels of a pipe. found is false when the pipe no
+// longer exists.
+func fetchPipeLabels(ctx context.Context, api *client.ApiClient, pipeID string) (labels []labelgql.Label, found bool, err error) {
+	var out struct {
+		Pipe *struct {
+			Labels []labelgql.Label `json:"labels"`
+		} `json:"pipe"`
+	}
+	if err := api.DoGraphQL(ctx, getPipeLabelsQuery, map[string]any{"pipeId": pipeID}, &out); err != nil {
+		return nil, false, err
+	}
+	if out.Pipe == nil {
+		return nil, false, nil
+	}
+	return out.Pipe.Labels, true, nil
+}
+
 func (r *LabelResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_label"
 }
@@ -73,7 +95,6 @@ func (r *LabelResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	mutation := "mutation CreateLabel_tf($pipeId:ID!,$name:String!,$color:String!){ createLabel(input:{ pipe_id:$pipeId, name:$name, color:$color }){ label{ " + labelgql.Selection + " } } }"
 	vars := map[string]any{
 		"pipeId": data.PipeId.ValueString(),
 		"name":   data.Name.ValueString(),
@@ -84,7 +105,7 @@ func (r *LabelResource) Create(ctx context.Context, req resource.CreateRequest,
 			Label labelgql.Label `json:"label"`
 		} `json:"createLabel"`
 	}
-	if err := r.api.DoGraphQL(ctx, mutation, vars, &out); err != nil {
+	if err := r.api.DoGraphQL(ctx, createLabelMutation, vars, &out); err != nil {
 		resp.Diagnostics.AddError("create label failed", err.Error())
 		return
 	}
@@ -104,23 +125,17 @@ func (r *LabelResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	query := "query GetPipeLabels_tf($pipeId:ID!){ pipe(id:$pipeId){ labels{ " + labelgql.Selection + " } } }"
-	vars := map[string]any{"pipeId": data.PipeId.ValueString()}
-	var out struct {
-		Pipe *struct {
-			Labels []labelgql.Label `json:"labels"`
-		} `json:"pipe"`
-	}
-	if err := r.api.DoGraphQL(ctx, query, vars, &out); err != nil {
+	labels, found, err := fetchPipeLabels(ctx, r.api, data.PipeId.ValueString())
+	if err != nil {
 		resp.Diagnostics.AddError("read label failed", err.Error())
 		return
 	}
-	if out.Pipe == nil {
+	if !found {
 		resp.State.RemoveResource(ctx)
 		return
 	}
 
-	l, ok := labelgql.FindByID(out.Pipe.Labels, data.Id.ValueString())
+	l, ok := labelgql.FindByID(labels, data.Id.ValueString())
 	if !ok {
 		resp.State.RemoveResource(ctx)
 		return
@@ -137,7 +152,6 @@ func (r *LabelResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	mutation := "mutation UpdateLabel_tf($id:ID!,$name:String!,$color:String!){ updateLabel(input:{ id:$id, name:$name, color:$color }){ label{ " + labelgql.Selection + " } } }"
 	vars := map[string]any{
 		"id":    data.Id.ValueString(),
 		"name":  data.Name.ValueString(),
@@ -148,7 +162,7 @@ func (r *LabelResource) Update(ctx context.Context, req resource.UpdateRequest,
 			Label labelgql.Label `json:"label"`
 		} `json:"updateLabel"`
 	}
-	if err := r.api.DoGraphQL(ctx, mutation, vars, &out); err != nil {
+	if err := r.api.DoGraphQL(ctx, updateLabelMutation, vars, &out); err != nil {
 		resp.Diagnostics.AddError("update label failed", err.Error())
 		return
 	}
@@ -163,14 +177,13 @@ func (r *LabelResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	mutation := "mutation DeleteLabel_tf($id:ID!){ deleteLabel(input:{ id:$id }){ success } }"
 	vars := map[string]any{"id": data.Id.ValueString()}
 	var out struct {
 		DeleteLabel struct {
 			Success bool `json:"success"`
 		} `json:"deleteLabel"`
 	}
-	if err := r.api.DoGraphQL(ctx, mutation, vars, &out); err != nil {
+	if err := r.api.DoGraphQL(ctx, deleteLabelMutation, vars, &out); err != nil {
 		resp.Diagnostics.AddError("delete label failed", err.Error())
 		return
 	}
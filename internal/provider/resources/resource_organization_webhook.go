@@ -0,0 +1,233 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/client"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/webhookgql"
+)
+
+var _ resource.Resource = &OrganizationWebhookResource{}
+var _ resource.ResourceWithImportState = &OrganizationWebhookResource{}
+var _ resource.ResourceWithModifyPlan = &OrganizationWebhookResource{}
+
+func NewOrganizationWebhookResource() resource.Resource { return &OrganizationWebhookResource{} }
+
+type OrganizationWebhookResource struct{ api *client.ApiClient }
+
+type OrganizationWebhookModel struct {
+	Id             types.String         `tfsdk:"id"`
+	OrganizationId types.String         `tfsdk:"organization_id"`
+	Url            types.String         `tfsdk:"url"`
+	Actions        types.List           `tfsdk:"actions"`
+	Name           types.String         `tfsdk:"name"`
+	Headers        jsontypes.Normalized `tfsdk:"headers"`
+	Filters        jsontypes.Normalized `tfsdk:"filters"`
+
+	HeadersWo        jsontypes.Normalized `tfsdk:"headers_wo"`
+	HeadersWoVersion types.Int64          `tfsdk:"headers_wo_version"`
+}
+
+func (r *OrganizationWebhookResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_webhook"
+}
+
+func (r *OrganizationWebhookResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := webhookAttributes("The organization events that trigger the webhook (e.g. user.invitation_sent, user.removal_from_org). The supported values are defined by the Pipefy API; see https://developers.pipefy.com/reference for the current list. They are not checked at plan time, so an unsupported action fails at apply.")
+	attributes["id"] = schema.StringAttribute{Computed: true, Description: "The ID of the webhook", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}}
+	attributes["organization_id"] = schema.StringAttribute{Required: true, Description: "The ID of the organization that the webhook belongs to", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sends an HTTP request to a URL when the selected events occur in an organization, such as users being invited or removed. " +
+			"Unlike `pipefy_webhook`, `actions` are not validated when planning; only the url is checked against `webhook_url_policy`, and an unsupported action fails at apply.",
+		Attributes: attributes,
+	}
+}
+
+// ModifyPlan validates the url against the provider's webhook_url_policy,
+// which is only reachable once the provider is configured. actions are left
+// to the API, since there is no known list of organization webhook actions.
+func (r *OrganizationWebhookResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var url types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("url"), &url)...)
+	checkWebhookURL(r.api, url, &resp.Diagnostics)
+}
+
+func (r *OrganizationWebhookResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	api, ok := req.ProviderData.(*client.ApiClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *ApiClient, got %T", req.ProviderData))
+		return
+	}
+	r.api = api
+}
+
+func (r *OrganizationWebhookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data OrganizationWebhookModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	checkWebhookURL(r.api, data.Url, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var actions []string
+	resp.Diagnostics.Append(data.Actions.ElementsAs(ctx, &actions, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	input := map[string]any{
+		"organization_id": data.OrganizationId.ValueString(),
+		"url":             data.Url.ValueString(),
+		"name":            data.Name.ValueString(),
+		"actions":         actions,
+	}
+	addHeadersInput(input, webhookHeaders(ctx, req.Config, data.Headers, &resp.Diagnostics))
+	if resp.Diagnostics.HasError() || !addFiltersInput(input, data.Filters, &resp.Diagnostics) {
+		return
+	}
+
+	mutation := "mutation CreateOrganizationWebhook_tf($input:CreateOrganizationWebhookInput!){ createOrganizationWebhook(input:$input){ webhook{ " + webhookgql.Selection + " } } }"
+	vars := map[string]any{"input": input}
+	var out struct {
+		CreateOrganizationWebhook struct {
+			Webhook webhookgql.Webhook `json:"webhook"`
+		} `json:"createOrganizationWebhook"`
+	}
+	if err := r.api.DoGraphQL(ctx, mutation, vars, &out); err != nil {
+		resp.Diagnostics.AddError("create organization webhook failed", err.Error())
+		return
+	}
+	data.Id = types.StringValue(out.CreateOrganizationWebhook.Webhook.Id)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationWebhookResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data OrganizationWebhookModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.Id.IsNull() || data.Id.ValueString() == "" {
+		return
+	}
+
+	query := "query GetOrganizationWebhooks_tf($organizationId:ID!){ organization(id:$organizationId){ webhooks{ " + webhookgql.Selection + " } } }"
+	vars := map[string]any{"organizationId": data.OrganizationId.ValueString()}
+	var out struct {
+		Organization *struct {
+			Webhooks []webhookgql.Webhook `json:"webhooks"`
+		} `json:"organization"`
+	}
+	if err := r.api.DoGraphQL(ctx, query, vars, &out); err != nil {
+		resp.Diagnostics.AddError("read organization webhook failed", err.Error())
+		return
+	}
+	if out.Organization == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	w, ok := webhookgql.FindByID(out.Organization.Webhooks, data.Id.ValueString())
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.Name = types.StringValue(w.Name)
+	data.Url = types.StringValue(w.Url)
+	actions, d := types.ListValueFrom(ctx, types.StringType, w.Actions)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Actions = actions
+	// As for pipe webhooks, filters is refreshed for drift detection and
+	// headers is left as configured.
+	data.Filters = normalizeFilters(w.Filters)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationWebhookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data OrganizationWebhookModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	checkWebhookURL(r.api, data.Url, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var actions []string
+	resp.Diagnostics.Append(data.Actions.ElementsAs(ctx, &actions, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	input := map[string]any{
+		"id":      data.Id.ValueString(),
+		"name":    data.Name.ValueString(),
+		"url":     data.Url.ValueString(),
+		"actions": actions,
+	}
+	updateHeadersInput(input, webhookHeaders(ctx, req.Config, data.Headers, &resp.Diagnostics))
+	if resp.Diagnostics.HasError() || !updateFiltersInput(input, data.Filters, &resp.Diagnostics) {
+		return
+	}
+
+	mutation := "mutation UpdateOrganizationWebhook_tf($input:UpdateOrganizationWebhookInput!){ updateOrganizationWebhook(input:$input){ webhook{ id } } }"
+	vars := map[string]any{"input": input}
+	var out struct {
+		UpdateOrganizationWebhook struct {
+			Webhook struct {
+				Id string `json:"id"`
+			} `json:"webhook"`
+		} `json:"updateOrganizationWebhook"`
+	}
+	if err := r.api.DoGraphQL(ctx, mutation, vars, &out); err != nil {
+		resp.Diagnostics.AddError("update organization webhook failed", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrganizationWebhookResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data OrganizationWebhookModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	mutation := "mutation DeleteOrganizationWebhook_tf($id:ID!){ deleteOrganizationWebhook(input:{ id:$id }){ success } }"
+	vars := map[string]any{"id": data.Id.ValueString()}
+	var out struct {
+		DeleteOrganizationWebhook struct {
+			Success bool `json:"success"`
+		} `json:"deleteOrganizationWebhook"`
+	}
+	if err := r.api.DoGraphQL(ctx, mutation, vars, &out); err != nil {
+		resp.Diagnostics.AddError("delete organization webhook failed", err.Error())
+		return
+	}
+}
+
+func (r *OrganizationWebhookResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, ok := splitImportID(req.ID)
+	if !ok || len(parts) != 2 {
+		resp.Diagnostics.AddError("invalid import ID", "expected organization_id/webhook_id, got "+req.ID)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+}
@@ -115,7 +115,7 @@ func (r *PhaseResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"index": schema.Float64Attribute{
 				Optional:      true,
 				Computed:      true,
-				Description:   "Position of the phase on the board. The API only accepts index at creation, so changing a configured index forces replacement of the phase (cards in the phase are lost). Reordering phases outside Terraform also changes index, so a configured index can trigger replacement after such drift.",
+				Description:   "Position of the phase on the board. The API only accepts index at creation, so changing a configured index forces replacement of the phase (cards in the phase are lost). Reordering phases outside Terraform also changes index, so a configured index can trigger replacement after such drift. `pipefy_phase_order` reorders phases in place where the API also accepts index on updatePhase; leave index unset on the phases it orders.",
 				PlanModifiers: []planmodifier.Float64{float64planmodifier.RequiresReplaceIfConfigured()},
 			},
 			// color is intentionally not managed: the Pipefy API rejects
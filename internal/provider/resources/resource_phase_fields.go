@@ -0,0 +1,531 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/client"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/fieldgql"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/locks"
+)
+
+var _ resource.Resource = &PhaseFieldsResource{}
+var _ resource.ResourceWithImportState = &PhaseFieldsResource{}
+var _ resource.ResourceWithModifyPlan = &PhaseFieldsResource{}
+
+func NewPhaseFieldsResource() resource.Resource { return &PhaseFieldsResource{} }
+
+type PhaseFieldsResource struct{ api *client.ApiClient }
+
+type PhaseFieldsModel struct {
+	Id      types.String           `tfsdk:"id"`
+	PhaseId types.String           `tfsdk:"phase_id"`
+	Fields  []PhaseFieldsItemModel `tfsdk:"fields"`
+}
+
+type PhaseFieldsItemModel struct {
+	Id               types.String `tfsdk:"id"`
+	InternalId       types.String `tfsdk:"internal_id"`
+	Uuid             types.String `tfsdk:"uuid"`
+	Type             types.String `tfsdk:"type"`
+	Label            types.String `tfsdk:"label"`
+	Required         types.Bool   `tfsdk:"required"`
+	Options          types.List   `tfsdk:"options"`
+	Description      types.String `tfsdk:"description"`
+	Help             types.String `tfsdk:"help"`
+	Editable         types.Bool   `tfsdk:"editable"`
+	MinimalView      types.Bool   `tfsdk:"minimal_view"`
+	CustomValidation types.String `tfsdk:"custom_validation"`
+}
+
+func (r *PhaseFieldsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_phase_fields"
+}
+
+func (r *PhaseFieldsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the complete, ordered list of fields of a phase or start form. Fields that exist in the phase but not in `fields` are reported as drift " +
+			"and deleted on the next apply, together with their card values. On create, fields the phase already has are adopted by label and type; " +
+			"the plan warns about each one it would delete. Do not combine with `pipefy_field` resources for the same phase.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: "The ID of the phase, used as the ID of the field list", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"phase_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the phase whose fields are managed. Use a pipe's `start_form_phase_id` to manage its start form.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"fields": schema.ListNestedAttribute{
+				Required: true,
+				Description: "Every field of the phase, in form order. An existing field is matched by label and type, or by position and type when its label changes, " +
+					"so renaming a field keeps its card values. Changing a field's type replaces the field.",
+				NestedObject: schema.NestedAttributeObject{Attributes: map[string]schema.Attribute{
+					"id":          schema.StringAttribute{Computed: true, Description: "The slug of the field"},
+					"internal_id": schema.StringAttribute{Computed: true, Description: "The unique internal ID of the field"},
+					"uuid":        schema.StringAttribute{Computed: true, Description: "The field's UUID. A stable identifier that does not change when the label changes."},
+					"type":        schema.StringAttribute{Required: true, Description: "The field type. See https://developers.pipefy.com/reference for the current list of supported types."},
+					"label":       schema.StringAttribute{Required: true, Description: "The displayed name of the field"},
+					"required":    schema.BoolAttribute{Optional: true, Computed: true, Description: "Whether the field is required or not"},
+					"options": schema.ListAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Computed:    true,
+						Description: "Choices for option-based field types (checklist_vertical, checklist_horizontal, radio_vertical, radio_horizontal, select, label_select). Order is preserved and user-visible.",
+					},
+					"description":       schema.StringAttribute{Optional: true, Computed: true, Description: "Helper description shown under the field"},
+					"help":              schema.StringAttribute{Optional: true, Computed: true, Description: "Help text shown for the field"},
+					"editable":          schema.BoolAttribute{Optional: true, Computed: true, Description: "Whether the field value can be edited after creation"},
+					"minimal_view":      schema.BoolAttribute{Optional: true, Computed: true, Description: "Whether the field is shown in the card's minimal (summary) view"},
+					"custom_validation": schema.StringAttribute{Optional: true, Computed: true, Description: "Custom validation rule applied to the field value"},
+				}},
+			},
+		},
+	}
+}
+
+func (r *PhaseFieldsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	api, ok := req.ProviderData.(*client.ApiClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *ApiClient, got %T", req.ProviderData))
+		return
+	}
+	r.api = api
+}
+
+// ModifyPlan matches the planned list against the fields the phase already
+// has and warns about the ones applying deletes. On update these are the
+// fields in state; on create they are fetched, so fields made elsewhere are
+// adopted by label and type rather than deleted and recreated.
+func (r *PhaseFieldsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var plan PhaseFieldsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var unclaimed []PhaseFieldsItemModel
+	if req.State.Raw.IsNull() {
+		if r.api == nil || !hasString(plan.PhaseId) {
+			return
+		}
+		fields, found, err := fetchPhaseFields(ctx, r.api, plan.PhaseId.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("plan phase fields failed", err.Error())
+			return
+		}
+		if !found {
+			// A missing phase is reported by apply.
+			return
+		}
+		sortFieldsByIndex(fields)
+		existing := make([]PhaseFieldsItemModel, len(fields))
+		for i, f := range fields {
+			existing[i] = phaseFieldsItemFromAPI(ctx, f, &resp.Diagnostics)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		unclaimed = adoptPhaseFields(plan.Fields, existing)
+	} else {
+		var state PhaseFieldsModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		unclaimed = rematchPhaseFields(plan.Fields, state.Fields)
+	}
+	for _, f := range unclaimed {
+		resp.Diagnostics.AddWarning(
+			"Phase field will be deleted",
+			fmt.Sprintf("field %q (%s) exists in phase %s but not in fields; applying deletes it together with its card values",
+				f.Label.ValueString(), f.Id.ValueString(), plan.PhaseId.ValueString()),
+		)
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *PhaseFieldsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PhaseFieldsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = data.PhaseId
+	r.reconcile(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PhaseFieldsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PhaseFieldsModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.PhaseId.IsNull() || data.PhaseId.ValueString() == "" {
+		return
+	}
+
+	fields, found, err := fetchPhaseFields(ctx, r.api, data.PhaseId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("read phase fields failed", err.Error())
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.Id = data.PhaseId
+	// Every field of the phase is read back in form order, so fields added or
+	// reordered outside Terraform show up as drift.
+	sortFieldsByIndex(fields)
+	data.Fields = make([]PhaseFieldsItemModel, len(fields))
+	for i, f := range fields {
+		data.Fields[i] = phaseFieldsItemFromAPI(ctx, f, &resp.Diagnostics)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PhaseFieldsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data PhaseFieldsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = data.PhaseId
+	r.reconcile(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PhaseFieldsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PhaseFieldsModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(data.Fields) == 0 {
+		return
+	}
+	repoID, err := resolvePhaseRepoID(ctx, r.api, data.PhaseId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("delete phase fields failed", err.Error())
+		return
+	}
+
+	unlock := locks.LockRepo(repoID)
+	defer unlock()
+
+	pipeUUID, err := r.api.PipeUUID(ctx, repoID)
+	if err != nil {
+		resp.Diagnostics.AddError("delete phase fields failed", err.Error())
+		return
+	}
+	for _, f := range data.Fields {
+		if err := deletePhaseField(ctx, r.api, f.Id.ValueString(), pipeUUID); err != nil {
+			resp.Diagnostics.AddError("delete phase fields failed", fmt.Sprintf("field %q: %s", f.Label.ValueString(), err.Error()))
+		}
+	}
+}
+
+func (r *PhaseFieldsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("phase_id"), req, resp)
+}
+
+// reconcile makes the phase's fields match the planned list in one pass under
+// the repo lock: unclaimed fields are deleted, new ones created, and existing
+// ones updated when their settings or position differ. Positions are written
+// as consecutive indexes so the form order follows the list.
+func (r *PhaseFieldsResource) reconcile(ctx context.Context, data *PhaseFieldsModel, diags *diag.Diagnostics) {
+	phaseID := data.PhaseId.ValueString()
+	repoID, err := resolvePhaseRepoID(ctx, r.api, phaseID)
+	if err != nil {
+		diags.AddError("apply phase fields failed", err.Error())
+		return
+	}
+
+	unlock := locks.LockRepo(repoID)
+	defer unlock()
+
+	current, found, err := fetchPhaseFields(ctx, r.api, phaseID)
+	if err != nil {
+		diags.AddError("apply phase fields failed", err.Error())
+		return
+	}
+	if !found {
+		diags.AddError("apply phase fields failed", fmt.Sprintf("phase %s not found", phaseID))
+		return
+	}
+
+	claimed := map[string]bool{}
+	for _, item := range data.Fields {
+		if hasString(item.Uuid) {
+			claimed[item.Uuid.ValueString()] = true
+		}
+	}
+	var pipeUUID string
+	for _, f := range current {
+		if claimed[f.Uuid] {
+			continue
+		}
+		if pipeUUID == "" {
+			if pipeUUID, err = r.api.PipeUUID(ctx, repoID); err != nil {
+				diags.AddError("delete field failed", err.Error())
+				return
+			}
+		}
+		if err := deletePhaseField(ctx, r.api, f.Id, pipeUUID); err != nil {
+			diags.AddError("delete field failed", fmt.Sprintf("field %q: %s", f.Label, err.Error()))
+			return
+		}
+	}
+
+	for i := range data.Fields {
+		item := &data.Fields[i]
+		index := float64(i + 1)
+		existing, ok := fieldgql.FindByUUID(current, item.Uuid.ValueString())
+		var written fieldgql.Field
+		switch {
+		case !ok:
+			written, err = r.createField(ctx, phaseID, *item, index, diags)
+		case phaseFieldChanged(ctx, *item, existing, index, diags):
+			written, err = r.updateField(ctx, existing, *item, index, diags)
+		default:
+			written = existing
+		}
+		if err != nil {
+			diags.AddError("apply phase fields failed", fmt.Sprintf("field %q: %s", item.Label.ValueString(), err.Error()))
+			return
+		}
+		if diags.HasError() {
+			return
+		}
+		item.fillFromAPI(ctx, written, diags)
+	}
+}
+
+func (r *PhaseFieldsResource) createField(ctx context.Context, phaseID string, item PhaseFieldsItemModel, index float64, diags *diag.Diagnostics) (fieldgql.Field, error) {
+	vars := map[string]any{
+		"phaseId": phaseID,
+		"type":    item.Type.ValueString(),
+		"label":   item.Label.ValueString(),
+	}
+	addFieldWriteVars(ctx, item.fieldModel(index), vars, diags)
+	var out struct {
+		CreatePhaseField struct {
+			PhaseField fieldgql.Field `json:"phase_field"`
+		} `json:"createPhaseField"`
+	}
+	err := r.api.DoGraphQL(ctx, createPhaseFieldMutation, vars, &out)
+	return out.CreatePhaseField.PhaseField, err
+}
+
+func (r *PhaseFieldsResource) updateField(ctx context.Context, existing fieldgql.Field, item PhaseFieldsItemModel, index float64, diags *diag.Diagnostics) (fieldgql.Field, error) {
+	vars := map[string]any{
+		"id":    existing.Id,
+		"uuid":  existing.Uuid,
+		"label": item.Label.ValueString(),
+	}
+	addFieldWriteVars(ctx, item.fieldModel(index), vars, diags)
+	var out struct {
+		UpdatePhaseField struct {
+			PhaseField fieldgql.Field `json:"phase_field"`
+		} `json:"updatePhaseField"`
+	}
+	err := r.api.DoGraphQL(ctx, updatePhaseFieldMutation, vars, &out)
+	return out.UpdatePhaseField.PhaseField, err
+}
+
+func deletePhaseField(ctx context.Context, api *client.ApiClient, id, pipeUUID string) error {
+	var out struct {
+		DeletePhaseField struct {
+			Success bool `json:"success"`
+		} `json:"deletePhaseField"`
+	}
+	if err := api.DoGraphQL(ctx, deletePhaseFieldMutation, map[string]any{"id": id, "pipeUuid": pipeUUID}, &out); err != nil {
+		return err
+	}
+	if !out.DeletePhaseField.Success {
+		return fmt.Errorf("deletePhaseField returned success=false for field %q", id)
+	}
+	return nil
+}
+
+// fieldModel adapts a list entry to FieldModel so the pipefy_field write
+// helpers can build its mutation variables.
+func (item PhaseFieldsItemModel) fieldModel(index float64) FieldModel {
+	return FieldModel{
+		Id:               item.Id,
+		InternalId:       item.InternalId,
+		Uuid:             item.Uuid,
+		Type:             item.Type,
+		Label:            item.Label,
+		Required:         item.Required,
+		Options:          item.Options,
+		Description:      item.Description,
+		Help:             item.Help,
+		Editable:         item.Editable,
+		MinimalView:      item.MinimalView,
+		CustomValidation: item.CustomValidation,
+		Index:            types.Float64Value(index),
+	}
+}
+
+func phaseFieldsItemFromAPI(ctx context.Context, f fieldgql.Field, diags *diag.Diagnostics) PhaseFieldsItemModel {
+	var m FieldModel
+	applyFieldToModel(ctx, &m, f, diags)
+	return PhaseFieldsItemModel{
+		Id:               m.Id,
+		InternalId:       m.InternalId,
+		Uuid:             m.Uuid,
+		Type:             m.Type,
+		Label:            m.Label,
+		Required:         m.Required,
+		Options:          m.Options,
+		Description:      m.Description,
+		Help:             m.Help,
+		Editable:         m.Editable,
+		MinimalView:      m.MinimalView,
+		CustomValidation: m.CustomValidation,
+	}
+}
+
+// fillFromAPI sets the identity of a written field and resolves the settings
+// left unknown in the plan; configured values are kept as planned.
+func (item *PhaseFieldsItemModel) fillFromAPI(ctx context.Context, f fieldgql.Field, diags *diag.Diagnostics) {
+	fetched := phaseFieldsItemFromAPI(ctx, f, diags)
+	item.Id = fetched.Id
+	item.InternalId = fetched.InternalId
+	item.Uuid = fetched.Uuid
+	item.inheritUnknowns(fetched)
+}
+
+func (item *PhaseFieldsItemModel) inheritUnknowns(from PhaseFieldsItemModel) {
+	if item.Required.IsUnknown() {
+		item.Required = from.Required
+	}
+	if item.Options.IsUnknown() {
+		item.Options = from.Options
+	}
+	if item.Description.IsUnknown() {
+		item.Description = from.Description
+	}
+	if item.Help.IsUnknown() {
+		item.Help = from.Help
+	}
+	if item.Editable.IsUnknown() {
+		item.Editable = from.Editable
+	}
+	if item.MinimalView.IsUnknown() {
+		item.MinimalView = from.MinimalView
+	}
+	if item.CustomValidation.IsUnknown() {
+		item.CustomValidation = from.CustomValidation
+	}
+}
+
+// phaseFieldChanged reports whether an existing field differs from its list
+// entry in label, position, or any configured setting.
+func phaseFieldChanged(ctx context.Context, item PhaseFieldsItemModel, existing fieldgql.Field, index float64, diags *diag.Diagnostics) bool {
+	have := phaseFieldsItemFromAPI(ctx, existing, diags)
+	if existing.Index == nil || *existing.Index != index || !item.Label.Equal(have.Label) {
+		return true
+	}
+	differs := func(want, got interface {
+		IsNull() bool
+		IsUnknown() bool
+	}, equal bool) bool {
+		return !want.IsNull() && !want.IsUnknown() && !equal
+	}
+	return differs(item.Required, have.Required, item.Required.Equal(have.Required)) ||
+		differs(item.Options, have.Options, item.Options.Equal(have.Options)) ||
+		differs(item.Description, have.Description, item.Description.Equal(have.Description)) ||
+		differs(item.Help, have.Help, item.Help.Equal(have.Help)) ||
+		differs(item.Editable, have.Editable, item.Editable.Equal(have.Editable)) ||
+		differs(item.MinimalView, have.MinimalView, item.MinimalView.Equal(have.MinimalView)) ||
+		differs(item.CustomValidation, have.CustomValidation, item.CustomValidation.Equal(have.CustomValidation))
+}
+
+// rematchPhaseFields carries the identity and unconfigured settings of each
+// field in state over to the plan entry that declares it. Entries are matched
+// by label and type first, then by position and type so a renamed field keeps
+// its identity. It returns the state fields no entry claims; applying deletes
+// them.
+func rematchPhaseFields(plan, state []PhaseFieldsItemModel) []PhaseFieldsItemModel {
+	return matchPhaseFields(plan, state, true)
+}
+
+// adoptPhaseFields is rematchPhaseFields for fields the resource did not
+// create. They are matched by label and type only: a field at the same
+// position with another label is not this list's to rename.
+func adoptPhaseFields(plan, existing []PhaseFieldsItemModel) []PhaseFieldsItemModel {
+	return matchPhaseFields(plan, existing, false)
+}
+
+func matchPhaseFields(plan, state []PhaseFieldsItemModel, byPosition bool) []PhaseFieldsItemModel {
+	used := make([]bool, len(state))
+	matched := make([]bool, len(plan))
+	claim := func(i, j int) {
+		plan[i].Id = state[j].Id
+		plan[i].InternalId = state[j].InternalId
+		plan[i].Uuid = state[j].Uuid
+		plan[i].inheritUnknowns(state[j])
+		used[j] = true
+		matched[i] = true
+	}
+	for i := range plan {
+		for j := range state {
+			if !used[j] && state[j].Label.Equal(plan[i].Label) && state[j].Type.Equal(plan[i].Type) {
+				claim(i, j)
+				break
+			}
+		}
+	}
+	for i := range plan {
+		if byPosition && !matched[i] && i < len(state) && !used[i] && state[i].Type.Equal(plan[i].Type) {
+			claim(i, i)
+		}
+	}
+	var unclaimed []PhaseFieldsItemModel
+	for j := range state {
+		if !used[j] {
+			unclaimed = append(unclaimed, state[j])
+		}
+	}
+	return unclaimed
+}
+
+// sortFieldsByIndex orders fields as the form shows them. Fields without an
+// index go last, keeping the API's order among themselves.
+func sortFieldsByIndex(fields []fieldgql.Field) {
+	index := func(f fieldgql.Field) float64 {
+		if f.Index == nil {
+			return math.Inf(1)
+		}
+		return *f.Index
+	}
+	sort.SliceStable(fields, func(i, j int) bool { return index(fields[i]) < index(fields[j]) })
+}
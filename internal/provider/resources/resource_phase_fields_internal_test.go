@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/fieldgql"
+)
+
+func phaseFieldsItem(uuid, fieldType, label string) PhaseFieldsItemModel {
+	id := types.StringUnknown()
+	u := types.StringUnknown()
+	if uuid != "" {
+		id, u = types.StringValue("id-"+uuid), types.StringValue(uuid)
+	}
+	return PhaseFieldsItemModel{
+		Id:               id,
+		InternalId:       id,
+		Uuid:             u,
+		Type:             types.StringValue(fieldType),
+		Label:            types.StringValue(label),
+		Required:         types.BoolUnknown(),
+		Options:          types.ListUnknown(types.StringType),
+		Description:      types.StringUnknown(),
+		Help:             types.StringUnknown(),
+		Editable:         types.BoolUnknown(),
+		MinimalView:      types.BoolUnknown(),
+		CustomValidation: types.StringUnknown(),
+	}
+}
+
+func TestRematchPhaseFields(t *testing.T) {
+	state := []PhaseFieldsItemModel{
+		phaseFieldsItem("u-name", "short_text", "Name"),
+		phaseFieldsItem("u-email", "email", "Email"),
+		phaseFieldsItem("u-notes", "long_text", "Notes"),
+		phaseFieldsItem("u-due", "date", "Due"),
+	}
+	state[0].Required = types.BoolValue(true)
+
+	plan := []PhaseFieldsItemModel{
+		phaseFieldsItem("", "email", "Email"),          // moved up, matched by label
+		phaseFieldsItem("", "short_text", "Full name"), // new: the field at its position is an email
+		phaseFieldsItem("", "long_text", "Comments"),   // renamed in place, matched by position
+		phaseFieldsItem("", "number", "Amount"),        // new
+	}
+
+	unclaimed := rematchPhaseFields(plan, state)
+
+	// "Full name" sits where state has "Email", a different type, so it is a
+	// new field rather than a rename of "Name".
+	want := []string{"u-email", "", "u-notes", ""}
+	for i, w := range want {
+		got := plan[i].Uuid
+		if w == "" && !got.IsUnknown() {
+			t.Errorf("plan[%d] uuid = %s, want unknown", i, got)
+		}
+		if w != "" && got.ValueString() != w {
+			t.Errorf("plan[%d] uuid = %s, want %s", i, got, w)
+		}
+	}
+	if len(unclaimed) != 2 || unclaimed[0].Uuid.ValueString() != "u-name" || unclaimed[1].Uuid.ValueString() != "u-due" {
+		t.Errorf("unclaimed = %v, want u-name and u-due", unclaimed)
+	}
+}
+
+func TestRematchPhaseFields_RenameKeepsIdentity(t *testing.T) {
+	state := []PhaseFieldsItemModel{phaseFieldsItem("u-name", "short_text", "Name")}
+	state[0].Required = types.BoolValue(true)
+	plan := []PhaseFieldsItemModel{phaseFieldsItem("", "short_text", "Full name")}
+
+	if unclaimed := rematchPhaseFields(plan, state); len(unclaimed) != 0 {
+		t.Fatalf("unclaimed = %v, want none", unclaimed)
+	}
+	if plan[0].Uuid.ValueString() != "u-name" {
+		t.Errorf("uuid = %s, want u-name", plan[0].Uuid)
+	}
+	if !plan[0].Required.ValueBool() {
+		t.Errorf("required = %s, want the state value true", plan[0].Required)
+	}
+	if plan[0].Label.ValueString() != "Full name" {
+		t.Errorf("label = %s, want the planned label", plan[0].Label)
+	}
+}
+
+func TestAdoptPhaseFields_MatchesLabelAndTypeOnly(t *testing.T) {
+	existing := []PhaseFieldsItemModel{
+		phaseFieldsItem("u-owned", "short_text", "Owned elsewhere"),
+		phaseFieldsItem("u-email", "email", "Email"),
+	}
+	plan := []PhaseFieldsItemModel{
+		phaseFieldsItem("", "short_text", "Name"), // same position and type, but not a rename
+		phaseFieldsItem("", "email", "Email"),
+	}
+
+	unclaimed := adoptPhaseFields(plan, existing)
+
+	if !plan[0].Uuid.IsUnknown() {
+		t.Errorf("plan[0] uuid = %s, want unknown", plan[0].Uuid)
+	}
+	if plan[1].Uuid.ValueString() != "u-email" {
+		t.Errorf("plan[1] uuid = %s, want u-email", plan[1].Uuid)
+	}
+	if len(unclaimed) != 1 || unclaimed[0].Uuid.ValueString() != "u-owned" {
+		t.Errorf("unclaimed = %v, want u-owned", unclaimed)
+	}
+}
+
+func TestSortFieldsByIndex(t *testing.T) {
+	idx := func(v float64) *float64 { return &v }
+	fields := []fieldgql.Field{
+		{Id: "c", Index: idx(3)},
+		{Id: "none-1"},
+		{Id: "a", Index: idx(1)},
+		{Id: "none-2"},
+		{Id: "b", Index: idx(2)},
+	}
+	sortFieldsByIndex(fields)
+	want := []string{"a", "b", "c", "none-1", "none-2"}
+	for i, w := range want {
+		if fields[i].Id != w {
+			t.Fatalf("order[%d] = %s, want %s", i, fields[i].Id, w)
+		}
+	}
+}
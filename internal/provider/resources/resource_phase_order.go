@@ -0,0 +1,382 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/client"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/locks"
+)
+
+var _ resource.Resource = &PhaseOrderResource{}
+var _ resource.ResourceWithImportState = &PhaseOrderResource{}
+var _ resource.ResourceWithModifyPlan = &PhaseOrderResource{}
+
+func NewPhaseOrderResource() resource.Resource { return &PhaseOrderResource{} }
+
+type PhaseOrderResource struct{ api *client.ApiClient }
+
+type PhaseOrderModel struct {
+	Id       types.String `tfsdk:"id"`
+	PipeId   types.String `tfsdk:"pipe_id"`
+	PhaseIds types.List   `tfsdk:"phase_ids"`
+}
+
+const getPipePhaseOrderQuery = "query GetPipePhaseOrder_tf($id:ID!){ pipe(id:$id){ phases { id name index } } }"
+const updatePhaseIndexMutation = "mutation UpdatePhaseIndex_tf($id:ID!,$name:String!,$index:Float){ updatePhase(input:{ id:$id, name:$name, index:$index }){ phase{ id index } } }"
+
+// updatePhaseInputFieldsQuery introspects the input of updatePhase. Pipefy has
+// historically only accepted a phase's index on createPhase, so reordering
+// depends on UpdatePhaseInput taking index.
+const updatePhaseInputFieldsQuery = `query UpdatePhaseInputFields_tf{ __type(name:"UpdatePhaseInput"){ inputFields{ name } } }`
+
+type phaseOrderPayload struct {
+	Id    string   `json:"id"`
+	Name  string   `json:"name"`
+	Index *float64 `json:"index"`
+}
+
+func (r *PhaseOrderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_phase_order"
+}
+
+func (r *PhaseOrderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the order of the phases of a pipe. Phases are positioned as listed in `phase_ids`; phases of the pipe that are not listed keep their relative order after them. " +
+			"Reordering phases in the Pipefy UI is reported as drift. Leave `index` unset on the `pipefy_phase` resources it orders. " +
+			"Moving a phase requires the API to accept `index` on `updatePhase`; the provider checks the API schema when planning and fails the plan, rather than applying nothing, when it does not.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: "The ID of the pipe, used as the ID of the phase order", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"pipe_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the pipe whose phases are ordered",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"phase_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "The IDs of the pipe's phases, in board order. Every ID must belong to the pipe.",
+				Validators:  []validator.List{listvalidator.SizeAtLeast(1), listvalidator.UniqueValues()},
+			},
+		},
+	}
+}
+
+func (r *PhaseOrderResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	api, ok := req.ProviderData.(*client.ApiClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *ApiClient, got %T", req.ProviderData))
+		return
+	}
+	r.api = api
+}
+
+// ModifyPlan fails a plan that moves phases when the API cannot set the index
+// of an existing phase. Introspection may be disabled; the check is then
+// skipped, and apply verifies each index it writes instead.
+func (r *PhaseOrderResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.api == nil {
+		return
+	}
+	var data PhaseOrderModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || !hasString(data.PipeId) || data.PhaseIds.IsUnknown() {
+		return
+	}
+	var elements []types.String
+	resp.Diagnostics.Append(data.PhaseIds.ElementsAs(ctx, &elements, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	desired := make([]string, len(elements))
+	for i, e := range elements {
+		if e.IsUnknown() {
+			return
+		}
+		desired[i] = e.ValueString()
+	}
+
+	updatable, known, err := phaseIndexUpdatable(ctx, r.api)
+	if err != nil || !known || updatable {
+		return
+	}
+	phases, found, err := fetchPhaseOrder(ctx, r.api, data.PipeId.ValueString())
+	if err != nil || !found {
+		return
+	}
+	if writes, missing := indexWrites(phases, desired); len(missing) == 0 && len(writes) > 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("phase_ids"), "Phase reordering not supported",
+			fmt.Sprintf("the Pipefy API does not accept index on updatePhase, so the phases of pipe %s cannot be moved; "+
+				"a phase's position can only be set by the index of pipefy_phase when it is created", data.PipeId.ValueString()))
+	}
+}
+
+// phaseIndexUpdatable reports whether UpdatePhaseInput takes index. known is
+// false when the API does not answer introspection.
+func phaseIndexUpdatable(ctx context.Context, api *client.ApiClient) (updatable, known bool, err error) {
+	var out struct {
+		Type *struct {
+			InputFields []struct {
+				Name string `json:"name"`
+			} `json:"inputFields"`
+		} `json:"__type"`
+	}
+	if err := api.DoGraphQL(ctx, updatePhaseInputFieldsQuery, nil, &out); err != nil {
+		return false, false, err
+	}
+	if out.Type == nil {
+		return false, false, nil
+	}
+	for _, f := range out.Type.InputFields {
+		if f.Name == "index" {
+			return true, true, nil
+		}
+	}
+	return false, true, nil
+}
+
+func (r *PhaseOrderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PhaseOrderModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = data.PipeId
+	r.apply(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PhaseOrderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PhaseOrderModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.PipeId.IsNull() || data.PipeId.ValueString() == "" {
+		return
+	}
+
+	phases, found, err := fetchPhaseOrder(ctx, r.api, data.PipeId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("read phase order failed", err.Error())
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// A freshly imported order lists every phase; otherwise only the phases
+	// already tracked are read back, in their current board order, so a
+	// reorder made in the UI shows up as drift.
+	var tracked []string
+	if !data.PhaseIds.IsNull() {
+		resp.Diagnostics.Append(data.PhaseIds.ElementsAs(ctx, &tracked, false)...)
+	}
+	current := phaseOrderIDs(phases)
+	ids := current
+	if len(tracked) > 0 {
+		ids = keepListed(current, tracked)
+	}
+	data.Id = data.PipeId
+	list, d := types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(d...)
+	data.PhaseIds = list
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PhaseOrderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data PhaseOrderModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = data.PipeId
+	r.apply(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete only forgets the order; the phases stay where they are.
+func (r *PhaseOrderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (r *PhaseOrderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("pipe_id"), req, resp)
+}
+
+// apply writes consecutive indexes to the pipe's phases under the repo lock,
+// skipping phases already at their position. See indexWrites.
+func (r *PhaseOrderResource) apply(ctx context.Context, data PhaseOrderModel, diags *diag.Diagnostics) {
+	var desired []string
+	diags.Append(data.PhaseIds.ElementsAs(ctx, &desired, false)...)
+	if diags.HasError() {
+		return
+	}
+	pipeID := data.PipeId.ValueString()
+
+	unlock := locks.LockRepo(pipeID)
+	defer unlock()
+
+	phases, found, err := fetchPhaseOrder(ctx, r.api, pipeID)
+	if err != nil {
+		diags.AddError("apply phase order failed", err.Error())
+		return
+	}
+	if !found {
+		diags.AddError("apply phase order failed", fmt.Sprintf("pipe %s not found", pipeID))
+		return
+	}
+	writes, missing := indexWrites(phases, desired)
+	if len(missing) > 0 {
+		diags.AddAttributeError(path.Root("phase_ids"), "apply phase order failed", fmt.Sprintf("phases %v do not belong to pipe %s", missing, pipeID))
+		return
+	}
+	for _, w := range writes {
+		var out struct {
+			UpdatePhase struct {
+				Phase phaseOrderPayload `json:"phase"`
+			} `json:"updatePhase"`
+		}
+		vars := map[string]any{"id": w.phase.Id, "name": w.phase.Name, "index": w.index}
+		if err := r.api.DoGraphQL(ctx, updatePhaseIndexMutation, vars, &out); err != nil {
+			diags.AddError("apply phase order failed", fmt.Sprintf("phase %s: %s", w.phase.Id, err.Error()))
+			return
+		}
+		// An API that drops the index would otherwise leave the order
+		// unchanged while state records it as applied.
+		if got := out.UpdatePhase.Phase.Index; got == nil || *got != w.index {
+			diags.AddError("apply phase order failed",
+				fmt.Sprintf("phase %s: the API did not set index %v; it may not support reordering existing phases", w.phase.Id, w.index))
+			return
+		}
+	}
+}
+
+type phaseIndexWrite struct {
+	phase phaseOrderPayload
+	index float64
+}
+
+// indexWrites returns the phases whose index must change for the pipe to be in
+// the desired order, with consecutive indexes starting at 1. Phases already at
+// their position are left out. missing holds the desired IDs that are not
+// phases of the pipe.
+func indexWrites(phases []phaseOrderPayload, desired []string) (writes []phaseIndexWrite, missing []string) {
+	order, missing := reorder(phaseOrderIDs(phases), desired)
+	if len(missing) > 0 {
+		return nil, missing
+	}
+	byID := make(map[string]phaseOrderPayload, len(phases))
+	for _, p := range phases {
+		byID[p.Id] = p
+	}
+	for i, id := range order {
+		p := byID[id]
+		index := float64(i + 1)
+		if p.Index != nil && *p.Index == index {
+			continue
+		}
+		writes = append(writes, phaseIndexWrite{phase: p, index: index})
+	}
+	return writes, nil
+}
+
+// fetchPhaseOrder lists a pipe's phases sorted by index. found is false when
+// the pipe no longer exists.
+func fetchPhaseOrder(ctx context.Context, api *client.ApiClient, pipeID string) (phases []phaseOrderPayload, found bool, err error) {
+	var out struct {
+		Pipe *struct {
+			Phases []phaseOrderPayload `json:"phases"`
+		} `json:"pipe"`
+	}
+	if err := api.DoGraphQL(ctx, getPipePhaseOrderQuery, map[string]any{"id": pipeID}, &out); err != nil {
+		return nil, false, err
+	}
+	if out.Pipe == nil {
+		return nil, false, nil
+	}
+	phases = out.Pipe.Phases
+	index := func(p phaseOrderPayload) float64 {
+		if p.Index == nil {
+			return math.Inf(1)
+		}
+		return *p.Index
+	}
+	sort.SliceStable(phases, func(i, j int) bool { return index(phases[i]) < index(phases[j]) })
+	return phases, true, nil
+}
+
+func phaseOrderIDs(phases []phaseOrderPayload) []string {
+	ids := make([]string, len(phases))
+	for i, p := range phases {
+		ids[i] = p.Id
+	}
+	return ids
+}
+
+// reorder returns current rearranged so the desired IDs come first, in the
+// given order, followed by the unlisted ones in their current order. missing
+// holds the desired IDs that are not in current.
+func reorder(current, desired []string) (order, missing []string) {
+	present := make(map[string]bool, len(current))
+	for _, id := range current {
+		present[id] = true
+	}
+	listed := make(map[string]bool, len(desired))
+	for _, id := range desired {
+		if !present[id] {
+			missing = append(missing, id)
+			continue
+		}
+		listed[id] = true
+		order = append(order, id)
+	}
+	for _, id := range current {
+		if !listed[id] {
+			order = append(order, id)
+		}
+	}
+	return order, missing
+}
+
+// keepListed returns the IDs of current that appear in listed, in the order
+// of current.
+func keepListed(current, listed []string) []string {
+	keep := make(map[string]bool, len(listed))
+	for _, id := range listed {
+		keep[id] = true
+	}
+	out := []string{}
+	for _, id := range current {
+		if keep[id] {
+			out = append(out, id)
+		}
+	}
+	return out
+}
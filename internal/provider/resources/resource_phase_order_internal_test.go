@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReorder(t *testing.T) {
+	cases := map[string]struct {
+		current, desired []string
+		wantOrder        []string
+		wantMissing      []string
+	}{
+		"full list":        {current: []string{"a", "b", "c"}, desired: []string{"c", "a", "b"}, wantOrder: []string{"c", "a", "b"}},
+		"unlisted go last": {current: []string{"a", "b", "c", "d"}, desired: []string{"d", "b"}, wantOrder: []string{"d", "b", "a", "c"}},
+		"already in order": {current: []string{"a", "b"}, desired: []string{"a", "b"}, wantOrder: []string{"a", "b"}},
+		"unknown id":       {current: []string{"a", "b"}, desired: []string{"b", "x", "a"}, wantOrder: []string{"b", "a"}, wantMissing: []string{"x"}},
+		"empty current":    {current: nil, desired: []string{"x"}, wantMissing: []string{"x"}},
+	}
+	for name, c := range cases {
+		order, missing := reorder(c.current, c.desired)
+		if !reflect.DeepEqual(order, c.wantOrder) || !reflect.DeepEqual(missing, c.wantMissing) {
+			t.Errorf("%s: reorder = (%v, %v), want (%v, %v)", name, order, missing, c.wantOrder, c.wantMissing)
+		}
+	}
+}
+
+func TestIndexWrites(t *testing.T) {
+	idx := func(v float64) *float64 { return &v }
+	phases := []phaseOrderPayload{
+		{Id: "a", Index: idx(1)},
+		{Id: "b", Index: idx(2)},
+		{Id: "c", Index: idx(3)},
+	}
+	writes, missing := indexWrites(phases, []string{"a", "c", "b"})
+	if len(missing) != 0 || len(writes) != 2 ||
+		writes[0].phase.Id != "c" || writes[0].index != 2 || writes[1].phase.Id != "b" || writes[1].index != 3 {
+		t.Errorf("indexWrites = (%+v, %v), want c at 2 and b at 3", writes, missing)
+	}
+	if writes, _ := indexWrites(phases, []string{"a", "b"}); len(writes) != 0 {
+		t.Errorf("indexWrites for phases in order = %+v, want none", writes)
+	}
+	if writes, missing := indexWrites(phases, []string{"x"}); writes != nil || !reflect.DeepEqual(missing, []string{"x"}) {
+		t.Errorf("indexWrites with unknown phase = (%+v, %v), want (nil, [x])", writes, missing)
+	}
+}
+
+func TestKeepListed(t *testing.T) {
+	got := keepListed([]string{"c", "a", "d", "b"}, []string{"a", "b", "c", "gone"})
+	if want := []string{"c", "a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("keepListed = %v, want %v", got, want)
+	}
+	if got := keepListed([]string{"a"}, []string{"gone"}); got == nil || len(got) != 0 {
+		t.Errorf("keepListed with no overlap = %#v, want an empty slice", got)
+	}
+}
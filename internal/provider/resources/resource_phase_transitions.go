@@ -0,0 +1,289 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/client"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/locks"
+)
+
+var _ resource.Resource = &PhaseTransitionsResource{}
+var _ resource.ResourceWithImportState = &PhaseTransitionsResource{}
+var _ resource.ResourceWithModifyPlan = &PhaseTransitionsResource{}
+
+func NewPhaseTransitionsResource() resource.Resource { return &PhaseTransitionsResource{} }
+
+type PhaseTransitionsResource struct{ api *client.ApiClient }
+
+type PhaseTransitionsModel struct {
+	Id                types.String `tfsdk:"id"`
+	PhaseId           types.String `tfsdk:"phase_id"`
+	CanMoveToPhaseIds types.Set    `tfsdk:"can_move_to_phase_ids"`
+}
+
+const getPhaseTransitionsQuery = "query GetPhaseTransitions_tf($id:ID!){ phase(id:$id){ id name repo_id cards_can_be_moved_to_phases { id } } }"
+const updatePhaseTransitionsMutation = "mutation UpdatePhaseTransitions_tf($id:ID!,$name:String!,$phaseIds:[ID]){ updatePhase(input:{ id:$id, name:$name, cards_can_be_moved_to_phases:$phaseIds }){ phase{ id cards_can_be_moved_to_phases { id } } } }"
+
+type phaseTransitionsPayload struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	RepoId    int64  `json:"repo_id"`
+	CanMoveTo []struct {
+		Id string `json:"id"`
+	} `json:"cards_can_be_moved_to_phases"`
+}
+
+func (p phaseTransitionsPayload) targetIDs() []string {
+	ids := make([]string, len(p.CanMoveTo))
+	for i, t := range p.CanMoveTo {
+		ids[i] = t.Id
+	}
+	return ids
+}
+
+func (r *PhaseTransitionsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_phase_transitions"
+}
+
+func (r *PhaseTransitionsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the phases a card in a phase can be moved to. Kept apart from `pipefy_phase` so that phases can allow moves to each other without a dependency cycle. " +
+			"Destroying the resource leaves the phase's current transitions in place.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: "The ID of the phase, used as the ID of its transitions", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"phase_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the phase cards are moved from",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"can_move_to_phase_ids": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "The IDs of the phases cards in this phase can be moved to. Every target must be another phase of the same pipe; an empty set blocks moves out of the phase.",
+			},
+		},
+	}
+}
+
+func (r *PhaseTransitionsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	api, ok := req.ProviderData.(*client.ApiClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *ApiClient, got %T", req.ProviderData))
+		return
+	}
+	r.api = api
+}
+
+// ModifyPlan checks the targets against the phases of the pipe once phase_id
+// and every target are known, so a phase of another pipe fails the plan. A
+// target still unknown, such as a phase created in the same apply, is checked
+// on apply.
+func (r *PhaseTransitionsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.api == nil {
+		return
+	}
+	var data PhaseTransitionsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || !hasString(data.PhaseId) || data.CanMoveToPhaseIds.IsUnknown() {
+		return
+	}
+	var elements []types.String
+	resp.Diagnostics.Append(data.CanMoveToPhaseIds.ElementsAs(ctx, &elements, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	targets := make([]string, len(elements))
+	for i, e := range elements {
+		if e.IsUnknown() {
+			return
+		}
+		targets[i] = e.ValueString()
+	}
+
+	phaseID := data.PhaseId.ValueString()
+	phase, err := r.fetch(ctx, phaseID)
+	if err == nil && phase == nil {
+		// A missing phase is reported by apply.
+		return
+	}
+	var pipeID string
+	var phases []phaseOrderPayload
+	if err == nil {
+		pipeID = strconv.FormatInt(phase.RepoId, 10)
+		var found bool
+		if phases, found, err = fetchPhaseOrder(ctx, r.api, pipeID); err == nil && !found {
+			err = fmt.Errorf("pipe %s not found", pipeID)
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddWarning("phase transitions not validated",
+			fmt.Sprintf("could not load the phases of the pipe of phase %s: %s", phaseID, err.Error()))
+		return
+	}
+	checkTransitionTargets(phaseID, pipeID, targets, phases, &resp.Diagnostics)
+}
+
+func (r *PhaseTransitionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PhaseTransitionsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = data.PhaseId
+	r.apply(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PhaseTransitionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PhaseTransitionsModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.PhaseId.IsNull() || data.PhaseId.ValueString() == "" {
+		return
+	}
+
+	phase, err := r.fetch(ctx, data.PhaseId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("read phase transitions failed", err.Error())
+		return
+	}
+	if phase == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.Id = data.PhaseId
+	set, d := types.SetValueFrom(ctx, types.StringType, phase.targetIDs())
+	resp.Diagnostics.Append(d...)
+	data.CanMoveToPhaseIds = set
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PhaseTransitionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data PhaseTransitionsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = data.PhaseId
+	r.apply(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete only forgets the transitions; restrictions already in place are kept.
+func (r *PhaseTransitionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (r *PhaseTransitionsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("phase_id"), req, resp)
+}
+
+func (r *PhaseTransitionsResource) fetch(ctx context.Context, phaseID string) (*phaseTransitionsPayload, error) {
+	var out struct {
+		Phase *phaseTransitionsPayload `json:"phase"`
+	}
+	if err := r.api.DoGraphQL(ctx, getPhaseTransitionsQuery, map[string]any{"id": phaseID}, &out); err != nil {
+		return nil, err
+	}
+	return out.Phase, nil
+}
+
+// apply checks that every target is another phase of the same pipe and then
+// writes the set. Phase writes to a pipe are serialized on the repo lock.
+func (r *PhaseTransitionsResource) apply(ctx context.Context, data PhaseTransitionsModel, diags *diag.Diagnostics) {
+	var targets []string
+	diags.Append(data.CanMoveToPhaseIds.ElementsAs(ctx, &targets, false)...)
+	if diags.HasError() {
+		return
+	}
+	phaseID := data.PhaseId.ValueString()
+	phase, err := r.fetch(ctx, phaseID)
+	if err != nil {
+		diags.AddError("apply phase transitions failed", err.Error())
+		return
+	}
+	if phase == nil {
+		diags.AddError("apply phase transitions failed", fmt.Sprintf("phase %s not found", phaseID))
+		return
+	}
+	pipeID := strconv.FormatInt(phase.RepoId, 10)
+
+	unlock := locks.LockRepo(pipeID)
+	defer unlock()
+
+	phases, found, err := fetchPhaseOrder(ctx, r.api, pipeID)
+	if err != nil {
+		diags.AddError("apply phase transitions failed", err.Error())
+		return
+	}
+	if !found {
+		diags.AddError("apply phase transitions failed", fmt.Sprintf("pipe %s of phase %s not found", pipeID, phaseID))
+		return
+	}
+	if checkTransitionTargets(phaseID, pipeID, targets, phases, diags); diags.HasError() {
+		return
+	}
+
+	if targets == nil {
+		targets = []string{}
+	}
+	vars := map[string]any{"id": phaseID, "name": phase.Name, "phaseIds": targets}
+	var out struct {
+		UpdatePhase struct {
+			Phase phaseTransitionsPayload `json:"phase"`
+		} `json:"updatePhase"`
+	}
+	if err := r.api.DoGraphQL(ctx, updatePhaseTransitionsMutation, vars, &out); err != nil {
+		diags.AddError("apply phase transitions failed", err.Error())
+	}
+}
+
+// checkTransitionTargets reports the targets that are not other phases of the
+// pipe the source phase belongs to.
+func checkTransitionTargets(phaseID, pipeID string, targets []string, phases []phaseOrderPayload, diags *diag.Diagnostics) {
+	if invalid := foreignTargets(phaseID, targets, phaseOrderIDs(phases)); len(invalid) > 0 {
+		diags.AddAttributeError(path.Root("can_move_to_phase_ids"), "Invalid phase transition",
+			fmt.Sprintf("phases %v are not other phases of pipe %s, which phase %s belongs to", invalid, pipeID, phaseID))
+	}
+}
+
+// foreignTargets returns the targets that are the source phase itself or are
+// not among the pipe's phases.
+func foreignTargets(source string, targets, pipePhases []string) []string {
+	inPipe := make(map[string]bool, len(pipePhases))
+	for _, id := range pipePhases {
+		inPipe[id] = true
+	}
+	var invalid []string
+	for _, id := range targets {
+		if id == source || !inPipe[id] {
+			invalid = append(invalid, id)
+		}
+	}
+	return invalid
+}
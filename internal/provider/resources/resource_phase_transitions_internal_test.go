@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestForeignTargets(t *testing.T) {
+	pipe := []string{"ph_a", "ph_b", "ph_c"}
+	cases := map[string]struct {
+		targets []string
+		want    []string
+	}{
+		"same pipe":   {targets: []string{"ph_b", "ph_c"}},
+		"none":        {targets: nil},
+		"self":        {targets: []string{"ph_a", "ph_b"}, want: []string{"ph_a"}},
+		"other pipe":  {targets: []string{"ph_x", "ph_c"}, want: []string{"ph_x"}},
+		"all invalid": {targets: []string{"ph_x", "ph_a"}, want: []string{"ph_x", "ph_a"}},
+	}
+	for name, c := range cases {
+		if got := foreignTargets("ph_a", c.targets, pipe); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: foreignTargets = %v, want %v", name, got, c.want)
+		}
+	}
+}
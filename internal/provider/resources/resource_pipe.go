@@ -5,15 +5,20 @@ package resources
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -51,8 +56,24 @@ type PipeModel struct {
 	Preferences               *pipePreferencesModel `tfsdk:"preferences"`
 	SLA                       *pipeSLAModel         `tfsdk:"sla"`
 	StartFormPhaseId          types.String          `tfsdk:"start_form_phase_id"`
+	DefaultPhases             types.String          `tfsdk:"default_phases"`
+	DefaultPhaseIds           types.List            `tfsdk:"default_phase_ids"`
 }
 
+// Modes for the phases createPipe seeds every new pipe with.
+const (
+	defaultPhasesDelete = "delete"
+	defaultPhasesKeep   = "keep"
+	defaultPhasesAdopt  = "adopt"
+)
+
+var defaultPhasesModes = []string{defaultPhasesDelete, defaultPhasesKeep, defaultPhasesAdopt}
+
+const (
+	phaseDeleteAttempts = 3
+	phaseDeleteBackoff  = 500 * time.Millisecond
+)
+
 const updatePipeMutation = "mutation UpdatePipe_tf($id:ID!,$name:String,$public:Boolean,$icon:String,$color:Colors," +
 	"$onlyAdminCanRemoveCards:Boolean,$onlyAssigneesCanEditCards:Boolean," +
 	"$expirationTimeByUnit:Int,$expirationUnit:Int,$preferences:RepoPreferenceInput){ " +
@@ -116,6 +137,21 @@ func (r *PipeResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Description:   "The ID of the start form phase",
 				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
+			"default_phases": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(defaultPhasesDelete),
+				Description: "What to do with the default phases Pipefy creates with every new pipe: " +
+					"`delete` removes them (the default), `keep` leaves them unmanaged, and `adopt` keeps them and exposes their IDs in `default_phase_ids` " +
+					"so they can be imported into `pipefy_phase` resources. Only applies when the pipe is created; changing it later does not add or remove phases.",
+				Validators: []validator.String{stringvalidator.OneOf(defaultPhasesModes...)},
+			},
+			"default_phase_ids": schema.ListAttribute{
+				ElementType:   types.StringType,
+				Computed:      true,
+				Description:   "The IDs of the default phases kept at creation, in board order. Only set when `default_phases` is `adopt`.",
+				PlanModifiers: []planmodifier.List{listplanmodifier.UseStateForUnknown()},
+			},
 		},
 	}
 }
@@ -236,22 +272,48 @@ func (m *PipeModel) addSettingsVars(ctx context.Context, vars map[string]any) di
 	return diags
 }
 
+// deletePhases removes the given phases concurrently, retrying each one so a
+// transient API failure does not leave the new pipe half-configured. The
+// errors of the phases that still failed are joined.
 func (r *PipeResource) deletePhases(ctx context.Context, ids []string) error {
+	errs := make([]error, len(ids))
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = r.deletePhase(ctx, id)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (r *PipeResource) deletePhase(ctx context.Context, id string) error {
 	const mutation = "mutation DeletePhase_tf($id:ID!){ deletePhase(input:{id:$id}){ success } }"
-	for _, id := range ids {
+	var err error
+	for attempt := 0; attempt < phaseDeleteAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("phase %s: %w", id, ctx.Err())
+			case <-time.After(time.Duration(attempt) * phaseDeleteBackoff):
+			}
+		}
 		var del struct {
 			DeletePhase struct {
 				Success bool `json:"success"`
 			} `json:"deletePhase"`
 		}
-		if err := r.api.DoGraphQL(ctx, mutation, map[string]any{"id": id}, &del); err != nil {
-			return fmt.Errorf("phase %s: %w", id, err)
+		err = r.api.DoGraphQL(ctx, mutation, map[string]any{"id": id}, &del)
+		if err == nil && !del.DeletePhase.Success {
+			err = errors.New("success=false")
 		}
-		if !del.DeletePhase.Success {
-			return fmt.Errorf("phase %s: success=false", id)
+		if err == nil {
+			return nil
 		}
 	}
-	return nil
+	return fmt.Errorf("phase %s: %w", id, err)
 }
 
 func (r *PipeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -276,14 +338,17 @@ func (r *PipeResource) Create(ctx context.Context, req resource.CreateRequest, r
 	pipeId := created.CreatePipe.Pipe.Id
 	data.Id = types.StringValue(pipeId)
 
-	seed := PipeModel{Id: data.Id, Name: data.Name, OrganizationId: data.OrganizationId}
+	seed := PipeModel{
+		Id: data.Id, Name: data.Name, OrganizationId: data.OrganizationId,
+		DefaultPhases: data.DefaultPhases, DefaultPhaseIds: types.ListNull(types.StringType),
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &seed)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// createPipe seeds the pipe with three default phases. Fetch them alongside the
-	// current settings so they can be removed and the payload reused below.
+	// current settings so default_phases can be applied and the payload reused below.
 	phasesQuery := "query GetPipePhases_tf($id:ID!){ pipe(id:$id){ " + pipegql.Selection + " phases { id } } }"
 	var phasesOut struct {
 		Pipe *struct {
@@ -307,9 +372,18 @@ func (r *PipeResource) Create(ctx context.Context, req resource.CreateRequest, r
 	for i, phase := range phasesOut.Pipe.Phases {
 		ids[i] = phase.Id
 	}
-	if err := r.deletePhases(ctx, ids); err != nil {
-		resp.Diagnostics.AddError("delete phase failed", err.Error())
-		return
+	data.DefaultPhaseIds = types.ListNull(types.StringType)
+	switch data.DefaultPhases.ValueString() {
+	case defaultPhasesKeep:
+	case defaultPhasesAdopt:
+		list, d := types.ListValueFrom(ctx, types.StringType, ids)
+		resp.Diagnostics.Append(d...)
+		data.DefaultPhaseIds = list
+	default:
+		if err := r.deletePhases(ctx, ids); err != nil {
+			resp.Diagnostics.AddError("delete phase failed", err.Error())
+			return
+		}
 	}
 
 	// createPipe accepts only name and organization. Apply every other setting
@@ -393,6 +467,10 @@ func (r *PipeResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 	resp.Diagnostics.Append(data.apply(ctx, out.UpdatePipe.Pipe, true)...)
+	// default_phase_ids is only captured at creation; an imported pipe has none.
+	if data.DefaultPhaseIds.IsUnknown() {
+		data.DefaultPhaseIds = types.ListNull(types.StringType)
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -416,4 +494,7 @@ func (r *PipeResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 
 func (r *PipeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// default_phases only matters at creation; record the default so an
+	// imported pipe does not plan a change to it.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("default_phases"), defaultPhasesDelete)...)
 }
@@ -0,0 +1,284 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/client"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/labelgql"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/locks"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/validators"
+)
+
+var _ resource.Resource = &PipeLabelsResource{}
+var _ resource.ResourceWithImportState = &PipeLabelsResource{}
+
+func NewPipeLabelsResource() resource.Resource { return &PipeLabelsResource{} }
+
+type PipeLabelsResource struct{ api *client.ApiClient }
+
+type PipeLabelsModel struct {
+	Id       types.String `tfsdk:"id"`
+	PipeId   types.String `tfsdk:"pipe_id"`
+	Labels   types.Map    `tfsdk:"labels"`
+	LabelIds types.Map    `tfsdk:"label_ids"`
+}
+
+func (r *PipeLabelsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_pipe_labels"
+}
+
+func (r *PipeLabelsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the complete set of labels of a pipe. Labels that exist in the pipe but not in `labels` are reported as drift and deleted on the next apply. " +
+			"Do not combine with `pipefy_label` resources for the same pipe.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: "The ID of the pipe, used as the ID of the label set", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"pipe_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "The ID of the pipe whose labels are managed",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"labels": schema.MapAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "Every label of the pipe, as a map of label name to hex color code (e.g. #FF0000 or #FA0). " +
+					"Changing a color updates the label in place; renaming a key deletes the label and creates a new one, which removes it from the cards it was applied to.",
+				Validators: []validator.Map{mapvalidator.ValueStringsAre(validators.HexColor())},
+			},
+			"label_ids": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "The ID of each label, keyed by label name",
+			},
+		},
+	}
+}
+
+func (r *PipeLabelsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	api, ok := req.ProviderData.(*client.ApiClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *ApiClient, got %T", req.ProviderData))
+		return
+	}
+	r.api = api
+}
+
+func (r *PipeLabelsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PipeLabelsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = data.PipeId
+	r.reconcile(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PipeLabelsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PipeLabelsModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.PipeId.IsNull() || data.PipeId.ValueString() == "" {
+		return
+	}
+
+	labels, found, err := fetchPipeLabels(ctx, r.api, data.PipeId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("read pipe labels failed", err.Error())
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.Id = data.PipeId
+	// Every label of the pipe is read back, so one added outside Terraform
+	// shows up as drift and is deleted on the next apply.
+	colors, ids, duplicates := labelMaps(labels)
+	for _, name := range duplicates {
+		resp.Diagnostics.AddWarning(
+			"duplicate label name",
+			fmt.Sprintf("pipe %s has more than one label named %q; only the first is tracked, and the next apply to this resource deletes the others", data.PipeId.ValueString(), name),
+		)
+	}
+	resp.Diagnostics.Append(data.setMaps(ctx, colors, ids)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PipeLabelsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data PipeLabelsModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = data.PipeId
+	r.reconcile(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PipeLabelsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PipeLabelsModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ids := map[string]string{}
+	resp.Diagnostics.Append(data.LabelIds.ElementsAs(ctx, &ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unlock := locks.LockRepo(data.PipeId.ValueString())
+	defer unlock()
+
+	for name, id := range ids {
+		if err := r.deleteLabel(ctx, id); err != nil {
+			resp.Diagnostics.AddError("delete pipe labels failed", fmt.Sprintf("label %q: %s", name, err.Error()))
+		}
+	}
+}
+
+func (r *PipeLabelsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("pipe_id"), req, resp)
+}
+
+// reconcile applies the planned label set against the pipe's current labels
+// and stores the resulting colors and IDs on the model. Deletions run first so
+// a name freed by one label can be reused by another in the same apply.
+func (r *PipeLabelsResource) reconcile(ctx context.Context, data *PipeLabelsModel, diags *diag.Diagnostics) {
+	desired := map[string]string{}
+	diags.Append(data.Labels.ElementsAs(ctx, &desired, false)...)
+	if diags.HasError() {
+		return
+	}
+	pipeID := data.PipeId.ValueString()
+
+	unlock := locks.LockRepo(pipeID)
+	defer unlock()
+
+	current, found, err := fetchPipeLabels(ctx, r.api, pipeID)
+	if err != nil {
+		diags.AddError("read pipe labels failed", err.Error())
+		return
+	}
+	if !found {
+		diags.AddError("read pipe labels failed", fmt.Sprintf("pipe %s not found", pipeID))
+		return
+	}
+
+	changes := labelgql.Diff(current, desired)
+	for _, id := range changes.Delete {
+		if err := r.deleteLabel(ctx, id); err != nil {
+			diags.AddError("delete label failed", fmt.Sprintf("label %s: %s", id, err.Error()))
+			return
+		}
+	}
+	for _, l := range changes.Update {
+		vars := map[string]any{"id": l.Id, "name": l.Name, "color": l.Color}
+		var out struct {
+			UpdateLabel struct {
+				Label labelgql.Label `json:"label"`
+			} `json:"updateLabel"`
+		}
+		if err := r.api.DoGraphQL(ctx, updateLabelMutation, vars, &out); err != nil {
+			diags.AddError("update label failed", fmt.Sprintf("label %q: %s", l.Name, err.Error()))
+			return
+		}
+	}
+	for _, l := range changes.Create {
+		vars := map[string]any{"pipeId": pipeID, "name": l.Name, "color": l.Color}
+		var out struct {
+			CreateLabel struct {
+				Label labelgql.Label `json:"label"`
+			} `json:"createLabel"`
+		}
+		if err := r.api.DoGraphQL(ctx, createLabelMutation, vars, &out); err != nil {
+			diags.AddError("create label failed", fmt.Sprintf("label %q: %s", l.Name, err.Error()))
+			return
+		}
+	}
+
+	// labels keeps the planned value; only the IDs are taken from the
+	// refreshed listing, and Read compares colors to detect later drift.
+	labels, _, err := fetchPipeLabels(ctx, r.api, pipeID)
+	if err != nil {
+		diags.AddError("read pipe labels failed", err.Error())
+		return
+	}
+	_, ids, _ := labelMaps(labels)
+	for name := range desired {
+		if _, ok := ids[name]; !ok {
+			diags.AddError("read pipe labels failed", fmt.Sprintf("label %q not found after apply", name))
+			return
+		}
+	}
+	labelIds, d := types.MapValueFrom(ctx, types.StringType, ids)
+	diags.Append(d...)
+	data.LabelIds = labelIds
+}
+
+func (r *PipeLabelsResource) deleteLabel(ctx context.Context, id string) error {
+	var out struct {
+		DeleteLabel struct {
+			Success bool `json:"success"`
+		} `json:"deleteLabel"`
+	}
+	if err := r.api.DoGraphQL(ctx, deleteLabelMutation, map[string]any{"id": id}, &out); err != nil {
+		return err
+	}
+	if !out.DeleteLabel.Success {
+		return fmt.Errorf("success=false")
+	}
+	return nil
+}
+
+// labelMaps keys the pipe's labels by name. When several labels share a name
+// the first one wins and the name is reported in duplicates.
+func labelMaps(labels []labelgql.Label) (colors, ids map[string]string, duplicates []string) {
+	colors = make(map[string]string, len(labels))
+	ids = make(map[string]string, len(labels))
+	for _, l := range labels {
+		if _, seen := ids[l.Name]; seen {
+			duplicates = append(duplicates, l.Name)
+			continue
+		}
+		colors[l.Name] = l.Color
+		ids[l.Name] = l.Id
+	}
+	return colors, ids, duplicates
+}
+
+func (m *PipeLabelsModel) setMaps(ctx context.Context, colors, ids map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	labels, d := types.MapValueFrom(ctx, types.StringType, colors)
+	diags.Append(d...)
+	labelIds, d := types.MapValueFrom(ctx, types.StringType, ids)
+	diags.Append(d...)
+	m.Labels = labels
+	m.LabelIds = labelIds
+	return diags
+}
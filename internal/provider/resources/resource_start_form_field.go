@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pipefy/terraform-provider-pipefy/internal/provider/client"
+)
+
+var _ resource.Resource = &StartFormFieldResource{}
+var _ resource.ResourceWithImportState = &StartFormFieldResource{}
+var _ resource.ResourceWithValidateConfig = &StartFormFieldResource{}
+
+func NewStartFormFieldResource() resource.Resource { return &StartFormFieldResource{} }
+
+// StartFormFieldResource manages a field of a pipe's start form. The start form
+// is a hidden phase; its ID is resolved from pipe_id, and the field is then
+// written like a pipefy_field in that phase.
+type StartFormFieldResource struct{ api *client.ApiClient }
+
+type StartFormFieldModel struct {
+	FieldModel
+	PipeId types.String `tfsdk:"pipe_id"`
+}
+
+const getStartFormPhaseIDQuery = "query GetStartFormPhaseId_tf($id:ID!){ pipe(id:$id){ startFormPhaseId } }"
+
+// resolveStartFormPhaseID returns the ID of a pipe's start form phase. found is
+// false when the pipe no longer exists.
+func resolveStartFormPhaseID(ctx context.Context, api *client.ApiClient, pipeID string) (phaseID string, found bool, err error) {
+	var out struct {
+		Pipe *struct {
+			StartFormPhaseId string `json:"startFormPhaseId"`
+		} `json:"pipe"`
+	}
+	if err := api.DoGraphQL(ctx, getStartFormPhaseIDQuery, map[string]any{"id": pipeID}, &out); err != nil {
+		return "", false, fmt.Errorf("resolve pipe %q start form phase: %w", pipeID, err)
+	}
+	if out.Pipe == nil {
+		return "", false, nil
+	}
+	if out.Pipe.StartFormPhaseId == "" {
+		return "", true, fmt.Errorf("resolve pipe %q start form phase: expected a non-empty startFormPhaseId", pipeID)
+	}
+	return out.Pipe.StartFormPhaseId, true, nil
+}
+
+func (r *StartFormFieldResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_start_form_field"
+}
+
+func (r *StartFormFieldResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := fieldAttributes()
+	attributes["pipe_id"] = schema.StringAttribute{
+		Required:      true,
+		Description:   "The ID of the pipe whose start form the field belongs to",
+		PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+	}
+	attributes["phase_id"] = schema.StringAttribute{
+		Computed:      true,
+		Description:   "The ID of the pipe's start form phase, resolved from pipe_id",
+		PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+	}
+	attributes["index"] = schema.Float64Attribute{
+		Optional:      true,
+		Computed:      true,
+		Description:   "Position of the field within the start form. Use `pipefy_field_order` with the pipe's `start_form_phase_id` to order the start form declaratively and leave index unset.",
+		PlanModifiers: []planmodifier.Float64{float64planmodifier.UseStateForUnknown()},
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Start form field resource. Manages a field of a pipe's start form without looking up the start form phase.",
+		Attributes:          attributes,
+	}
+}
+
+func (r *StartFormFieldResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateFieldConnector(ctx, req.Config, &resp.Diagnostics)
+}
+
+func (r *StartFormFieldResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	api, ok := req.ProviderData.(*client.ApiClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("expected *ApiClient, got %T", req.ProviderData))
+		return
+	}
+	r.api = api
+}
+
+func (r *StartFormFieldResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data StartFormFieldModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	phaseID, found, err := resolveStartFormPhaseID(ctx, r.api, data.PipeId.ValueString())
+	if err == nil && !found {
+		err = fmt.Errorf("pipe %s not found", data.PipeId.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("create start form field failed", err.Error())
+		return
+	}
+	data.PhaseId = types.StringValue(phaseID)
+
+	// The pipe is the repo phase-field writes lock on, so there is no need to
+	// resolve it from the phase like pipefy_field does.
+	createField(ctx, r.api, data.PipeId.ValueString(), &data.FieldModel, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StartFormFieldResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data StartFormFieldModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.Uuid.IsNull() || data.Uuid.ValueString() == "" {
+		return
+	}
+	// Imports only set pipe_id and uuid, so the start form phase is resolved here.
+	if data.PhaseId.IsNull() || data.PhaseId.ValueString() == "" {
+		phaseID, found, err := resolveStartFormPhaseID(ctx, r.api, data.PipeId.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("read start form field failed", err.Error())
+			return
+		}
+		if !found {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		data.PhaseId = types.StringValue(phaseID)
+	}
+
+	found := readField(ctx, r.api, &data.FieldModel, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StartFormFieldResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data StartFormFieldModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	updateField(ctx, r.api, &data.FieldModel, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StartFormFieldResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data StartFormFieldModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	deleteField(ctx, r.api, data.PipeId.ValueString(), data.FieldModel, &resp.Diagnostics)
+}
+
+func (r *StartFormFieldResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, ok := splitImportID(req.ID)
+	if !ok || len(parts) != 2 {
+		resp.Diagnostics.AddError("invalid import ID", "expected pipe_id/field_uuid, got "+req.ID)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("pipe_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("uuid"), parts[1])...)
+}
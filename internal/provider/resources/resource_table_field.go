@@ -23,6 +23,7 @@ import (
 
 var _ resource.Resource = &TableFieldResource{}
 var _ resource.ResourceWithImportState = &TableFieldResource{}
+var _ resource.ResourceWithValidateConfig = &TableFieldResource{}
 
 func NewTableFieldResource() resource.Resource { return &TableFieldResource{} }
 
@@ -43,6 +44,8 @@ type TableFieldModel struct {
 	MinimalView      types.Bool   `tfsdk:"minimal_view"`
 	CustomValidation types.String `tfsdk:"custom_validation"`
 	Unique           types.Bool   `tfsdk:"unique"`
+
+	Connector *fieldConnectorModel `tfsdk:"connector"`
 }
 
 func (r *TableFieldResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -102,10 +105,15 @@ func (r *TableFieldResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Description:   "Whether the field value must be unique across the table's records",
 				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
 			},
+			"connector": fieldConnectorAttribute(),
 		},
 	}
 }
 
+func (r *TableFieldResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	validateFieldConnector(ctx, req.Config, &resp.Diagnostics)
+}
+
 func (r *TableFieldResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -130,12 +138,15 @@ func (r *TableFieldResource) Create(ctx context.Context, req resource.CreateRequ
 	unlock := locks.LockRepo(data.TableId.ValueString())
 	defer unlock()
 
-	mutation := "mutation CreateTableField_tf($tableId:ID!,$type:ID!,$label:String!,$required:Boolean,$options:[String],$description:String,$help:String,$minimalView:Boolean,$customValidation:String,$unique:Boolean){ createTableField(input:{ table_id:$tableId, type:$type, label:$label, required:$required, options:$options, description:$description, help:$help, minimal_view:$minimalView, custom_validation:$customValidation, unique:$unique }){ table_field{ " + tablefieldgql.Selection + " } } }"
+	mutation := "mutation CreateTableField_tf($tableId:ID!,$type:ID!,$label:String!,$required:Boolean,$options:[String],$description:String,$help:String,$minimalView:Boolean,$customValidation:String,$unique:Boolean,$connectedRepoId:ID,$canCreateNewConnected:Boolean,$canConnectExisting:Boolean,$canConnectMultiples:Boolean){ createTableField(input:{ table_id:$tableId, type:$type, label:$label, required:$required, options:$options, description:$description, help:$help, minimal_view:$minimalView, custom_validation:$customValidation, unique:$unique, connectedRepoId:$connectedRepoId, canCreateNewConnected:$canCreateNewConnected, canConnectExisting:$canConnectExisting, canConnectMultiples:$canConnectMultiples }){ table_field{ " + tablefieldgql.Selection + " } } }"
 	vars := map[string]any{
 		"tableId": data.TableId.ValueString(),
 		"type":    data.Type.ValueString(),
 		"label":   data.Label.ValueString(),
 	}
+	if data.Connector != nil {
+		vars["connectedRepoId"] = data.Connector.RepoId.ValueString()
+	}
 	addTableFieldWriteVars(ctx, data, vars, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
@@ -202,7 +213,7 @@ func (r *TableFieldResource) Update(ctx context.Context, req resource.UpdateRequ
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	mutation := "mutation UpdateTableField_tf($id:ID!,$tableId:ID!,$label:String,$required:Boolean,$options:[String],$description:String,$help:String,$minimalView:Boolean,$customValidation:String,$unique:Boolean){ updateTableField(input:{ id:$id, table_id:$tableId, label:$label, required:$required, options:$options, description:$description, help:$help, minimal_view:$minimalView, custom_validation:$customValidation, unique:$unique }){ table_field{ " + tablefieldgql.Selection + " } } }"
+	mutation := "mutation UpdateTableField_tf($id:ID!,$tableId:ID!,$label:String,$required:Boolean,$options:[String],$description:String,$help:String,$minimalView:Boolean,$customValidation:String,$unique:Boolean,$canCreateNewConnected:Boolean,$canConnectExisting:Boolean,$canConnectMultiples:Boolean){ updateTableField(input:{ id:$id, table_id:$tableId, label:$label, required:$required, options:$options, description:$description, help:$help, minimal_view:$minimalView, custom_validation:$customValidation, unique:$unique, canCreateNewConnected:$canCreateNewConnected, canConnectExisting:$canConnectExisting, canConnectMultiples:$canConnectMultiples }){ table_field{ " + tablefieldgql.Selection + " } } }"
 	vars := map[string]any{
 		"id":      data.Id.ValueString(),
 		"tableId": data.TableId.ValueString(),
@@ -291,6 +302,7 @@ func addTableFieldWriteVars(ctx context.Context, data TableFieldModel, vars map[
 	if !data.Unique.IsNull() && !data.Unique.IsUnknown() {
 		vars["unique"] = data.Unique.ValueBool()
 	}
+	addConnectorWriteVars(data.Connector, vars)
 }
 
 // applyTableFieldToModel maps a fetched field onto the model. table_id is not in the
@@ -308,4 +320,5 @@ func applyTableFieldToModel(ctx context.Context, data *TableFieldModel, f tablef
 	data.CustomValidation = strPtr(f.CustomValidation)
 	data.Unique = boolPtr(f.Unique)
 	data.Options = optionsToList(ctx, f.Options, diags)
+	data.Connector = connectorFromAPI(f.Type, f.ConnectedRepoID(), f.CanCreateNewConnected, f.CanConnectExisting, f.CanConnectMultiples)
 }
@@ -10,6 +10,8 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -18,6 +20,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/pipefy/terraform-provider-pipefy/internal/provider/client"
 	"github.com/pipefy/terraform-provider-pipefy/internal/provider/validators"
@@ -26,6 +29,7 @@ import (
 
 var _ resource.Resource = &WebhookResource{}
 var _ resource.ResourceWithImportState = &WebhookResource{}
+var _ resource.ResourceWithModifyPlan = &WebhookResource{}
 
 func NewWebhookResource() resource.Resource { return &WebhookResource{} }
 
@@ -39,6 +43,9 @@ type WebhookModel struct {
 	Name    types.String         `tfsdk:"name"`
 	Headers jsontypes.Normalized `tfsdk:"headers"`
 	Filters jsontypes.Normalized `tfsdk:"filters"`
+
+	HeadersWo        jsontypes.Normalized `tfsdk:"headers_wo"`
+	HeadersWoVersion types.Int64          `tfsdk:"headers_wo_version"`
 }
 
 func (r *WebhookResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -46,36 +53,118 @@ func (r *WebhookResource) Metadata(ctx context.Context, req resource.MetadataReq
 }
 
 func (r *WebhookResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := webhookAttributes("The events that trigger the webhook (e.g. card.create, card.move). The supported values are defined by the Pipefy API; see https://developers.pipefy.com/reference for the current list.")
+	attributes["id"] = schema.StringAttribute{Computed: true, Description: "The ID of the webhook", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}}
+	attributes["pipe_id"] = schema.StringAttribute{Required: true, Description: "The ID of the pipe that the webhook belongs to", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}}
+	actions := attributes["actions"].(schema.ListAttribute)
+	actions.Description += " Unknown actions are rejected at plan time, using the list the API publishes when it has one."
+	attributes["actions"] = actions
+	filters := attributes["filters"].(schema.StringAttribute)
+	filters.Description += " Filter keys must apply to one of the actions (from_phase_id and to_phase_id to card.move, field_id to card.field_update) and take a list of IDs."
+	filters.Validators = append(filters.Validators, validators.WebhookFilters(validators.PipeWebhookFilters))
+	attributes["filters"] = filters
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Sends an HTTP request to a URL when the selected events occur on a pipe.",
-		Attributes: map[string]schema.Attribute{
-			"id":      schema.StringAttribute{Computed: true, Description: "The ID of the webhook", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
-			"pipe_id": schema.StringAttribute{Required: true, Description: "The ID of the pipe that the webhook belongs to", PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
-			"url": schema.StringAttribute{
-				Required:    true,
-				Description: "The URL that receives the webhook notifications.",
-				Validators:  []validator.String{validators.URL()},
-			},
-			"actions": schema.ListAttribute{
-				ElementType:   types.StringType,
-				Required:      true,
-				Description:   "The events that trigger the webhook (e.g. card.create, card.move). The supported values are defined by the Pipefy API; see https://developers.pipefy.com/reference for the current list.",
-				PlanModifiers: []planmodifier.List{listplanmodifier.UseStateForUnknown()},
-			},
-			"name": schema.StringAttribute{Required: true, Description: "Name of the webhook"},
-			"headers": schema.StringAttribute{
-				Optional:    true,
-				Sensitive:   true,
-				CustomType:  jsontypes.NormalizedType{},
-				Description: "Custom HTTP headers sent with the webhook, as a JSON object string (e.g. \"{\\\"Authorization\\\":\\\"Bearer ...\\\"}\"). Being sensitive, it is not read back from the API: the configured value is authoritative and re-sent on every apply, and removing it clears the headers. Changes made outside Terraform are not detected.",
-			},
-			"filters": schema.StringAttribute{
-				Optional:    true,
-				CustomType:  jsontypes.NormalizedType{},
-				Description: "Filters that restrict when the webhook fires, as a JSON string. Refreshed from the API so drift is detected, and removing it clears the filters. The supported keys and constraints per action are defined by the API; see https://developers.pipefy.com/reference.",
+		Attributes:          attributes,
+	}
+}
+
+// webhookAttributes returns the attributes pipe and organization webhooks
+// share; callers add the ID and the scope they belong to.
+func webhookAttributes(actionsDescription string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"url": schema.StringAttribute{
+			Required:    true,
+			Description: "The URL that receives the webhook notifications. Must satisfy the provider's `webhook_url_policy` when one is set.",
+			Validators:  []validator.String{validators.URL()},
+		},
+		"actions": schema.ListAttribute{
+			ElementType:   types.StringType,
+			Required:      true,
+			Description:   actionsDescription,
+			PlanModifiers: []planmodifier.List{listplanmodifier.UseStateForUnknown()},
+		},
+		"name": schema.StringAttribute{Required: true, Description: "Name of the webhook"},
+		"headers": schema.StringAttribute{
+			Optional:    true,
+			Sensitive:   true,
+			CustomType:  jsontypes.NormalizedType{},
+			Description: "Custom HTTP headers sent with the webhook, as a JSON object string (e.g. \"{\\\"Authorization\\\":\\\"Bearer ...\\\"}\"). Being sensitive, it is not read back from the API: the configured value is authoritative and re-sent on every apply, and removing it clears the headers. Changes made outside Terraform are not detected. The value is stored in state; use `headers_wo` to keep it out.",
+			Validators:  []validator.String{stringvalidator.ConflictsWith(path.MatchRoot("headers_wo"))},
+		},
+		"headers_wo": schema.StringAttribute{
+			Optional:    true,
+			WriteOnly:   true,
+			Sensitive:   true,
+			CustomType:  jsontypes.NormalizedType{},
+			Description: "Write-only alternative to `headers`, as a JSON object string, that is never stored in plan or state, so it can come from an ephemeral resource. Requires Terraform 1.11 or later and `headers_wo_version`. The headers are sent on create and with every update; change `headers_wo_version` to send new headers when nothing else changed.",
+			Validators: []validator.String{
+				stringvalidator.ConflictsWith(path.MatchRoot("headers")),
+				stringvalidator.AlsoRequires(path.MatchRoot("headers_wo_version")),
 			},
 		},
+		"headers_wo_version": schema.Int64Attribute{
+			Optional:    true,
+			Description: "Version of `headers_wo`. Changing it updates the webhook, which re-sends the current `headers_wo`.",
+			Validators:  []validator.Int64{int64validator.AlsoRequires(path.MatchRoot("headers_wo"))},
+		},
+		"filters": schema.StringAttribute{
+			Optional:    true,
+			CustomType:  jsontypes.NormalizedType{},
+			Description: "Filters that restrict when the webhook fires, as a JSON string. Refreshed from the API so drift is detected, and removing it clears the filters. The supported keys and constraints per action are defined by the API; see https://developers.pipefy.com/reference.",
+		},
+	}
+}
+
+// ModifyPlan validates the url against the provider's webhook_url_policy, and
+// actions. Both run here rather than as schema validators because the policy
+// and the list the API publishes are only reachable once the provider is
+// configured; the published list wins over the built-in one.
+func (r *WebhookResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var url types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("url"), &url)...)
+	checkWebhookURL(r.api, url, &resp.Diagnostics)
+
+	var actions types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("actions"), &actions)...)
+	if resp.Diagnostics.HasError() || actions.IsUnknown() {
+		return
+	}
+	check := validator.ListRequest{
+		Path:           path.Root("actions"),
+		PathExpression: path.MatchRoot("actions"),
+		Config:         req.Config,
+		ConfigValue:    actions,
 	}
+	var result validator.ListResponse
+	validators.WebhookActions(allowedWebhookActions(ctx, r.api)).ValidateList(ctx, check, &result)
+	resp.Diagnostics.Append(result.Diagnostics...)
+}
+
+// checkWebhookURL reports an error on url when it breaks the provider's
+// webhook_url_policy. Unknown values are checked again on apply.
+func checkWebhookURL(api *client.ApiClient, url types.String, diags *diag.Diagnostics) {
+	if api == nil || api.WebhookURLPolicy == nil || url.IsNull() || url.IsUnknown() {
+		return
+	}
+	if err := api.WebhookURLPolicy.Check(url.ValueString()); err != nil {
+		diags.AddAttributeError(path.Root("url"), "Webhook URL not allowed", err.Error())
+	}
+}
+
+// allowedWebhookActions returns the actions the API publishes, or
+// validators.PipeWebhookActions when it publishes none or cannot be asked.
+func allowedWebhookActions(ctx context.Context, api *client.ApiClient) []string {
+	if api == nil {
+		return validators.PipeWebhookActions
+	}
+	if actions, err := api.WebhookActions(ctx); err == nil && len(actions) > 0 {
+		return actions
+	}
+	return validators.PipeWebhookActions
 }
 
 func (r *WebhookResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -93,6 +182,7 @@ func (r *WebhookResource) Configure(ctx context.Context, req resource.ConfigureR
 func (r *WebhookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data WebhookModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	checkWebhookURL(r.api, data.Url, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -109,8 +199,8 @@ func (r *WebhookResource) Create(ctx context.Context, req resource.CreateRequest
 		"name":    data.Name.ValueString(),
 		"actions": actions,
 	}
-	addHeadersInput(input, data.Headers)
-	if !addFiltersInput(input, data.Filters, &resp.Diagnostics) {
+	addHeadersInput(input, webhookHeaders(ctx, req.Config, data.Headers, &resp.Diagnostics))
+	if resp.Diagnostics.HasError() || !addFiltersInput(input, data.Filters, &resp.Diagnostics) {
 		return
 	}
 
@@ -199,6 +289,7 @@ func normalizeFilters(raw json.RawMessage) jsontypes.Normalized {
 func (r *WebhookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data WebhookModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	checkWebhookURL(r.api, data.Url, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -218,7 +309,10 @@ func (r *WebhookResource) Update(ctx context.Context, req resource.UpdateRequest
 		}
 		input["actions"] = actions
 	}
-	updateHeadersInput(input, data.Headers)
+	updateHeadersInput(input, webhookHeaders(ctx, req.Config, data.Headers, &resp.Diagnostics))
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if !updateFiltersInput(input, data.Filters, &resp.Diagnostics) {
 		return
 	}
@@ -268,6 +362,16 @@ func (r *WebhookResource) ImportState(ctx context.Context, req resource.ImportSt
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
 }
 
+// webhookHeaders returns the headers to send: headers when set, otherwise
+// headers_wo, which as a write-only attribute is only present in config.
+func webhookHeaders(ctx context.Context, config tfsdk.Config, headers jsontypes.Normalized, diags *diag.Diagnostics) jsontypes.Normalized {
+	if !headers.IsNull() {
+		return headers
+	}
+	diags.Append(config.GetAttribute(ctx, path.Root("headers_wo"), &headers)...)
+	return headers
+}
+
 // addHeadersInput adds headers to the input map when set. The API's headers
 // field is the Json scalar, which expects a JSON document encoded as a string
 // (e.g. "{\"Authorization\":\"...\"}"), so the raw attribute value is passed
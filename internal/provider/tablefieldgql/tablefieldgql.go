@@ -7,7 +7,8 @@
 package tablefieldgql
 
 const Selection = "id internal_id uuid label type required options " +
-	"description help minimal_view custom_validation unique"
+	"description help minimal_view custom_validation unique " +
+	"connectedRepo{ ... on Pipe{ id } ... on Table{ id } } canCreateNewConnected canConnectExisting canConnectMultiples"
 
 type Field struct {
 	Id               string   `json:"id"`
@@ -22,6 +23,26 @@ type Field struct {
 	MinimalView      *bool    `json:"minimal_view"`
 	CustomValidation *string  `json:"custom_validation"`
 	Unique           *bool    `json:"unique"`
+
+	// Connector settings, only set for connector fields.
+	ConnectedRepo         *ConnectedRepo `json:"connectedRepo"`
+	CanCreateNewConnected *bool          `json:"canCreateNewConnected"`
+	CanConnectExisting    *bool          `json:"canConnectExisting"`
+	CanConnectMultiples   *bool          `json:"canConnectMultiples"`
+}
+
+// ConnectedRepo is the pipe or table a connector field links to.
+type ConnectedRepo struct {
+	Id string `json:"id"`
+}
+
+// ConnectedRepoID returns the ID of the pipe or table the field links to, or ""
+// when it links to none.
+func (f Field) ConnectedRepoID() string {
+	if f.ConnectedRepo == nil {
+		return ""
+	}
+	return f.ConnectedRepo.Id
 }
 
 func FindByUUID(fields []Field, uuid string) (Field, bool) {
@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	// Embedded so time zones validate on hosts without a zoneinfo database.
+	_ "time/tzdata"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Cron returns a validator.String that ensures the value is a five-field cron
+// expression (minute hour day-of-month month day-of-week). Each field accepts
+// "*", numbers, ranges ("1-5"), lists ("1,15") and steps ("*/15", "0-30/10");
+// months and weekdays also accept three-letter names ("JAN", "MON").
+func Cron() validator.String { return cronValidator{} }
+
+type cronValidator struct{}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+func (v cronValidator) Description(_ context.Context) string {
+	return "value must be a five-field cron expression, e.g. \"0 9 * * MON\""
+}
+
+func (v cronValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cronValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if err := parseCron(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid cron expression", err.Error())
+	}
+}
+
+func parseCron(expr string) error {
+	parts := strings.Fields(expr)
+	if len(parts) != len(cronFields) {
+		return fmt.Errorf("expected %d fields (minute hour day-of-month month day-of-week), got %d in %q", len(cronFields), len(parts), expr)
+	}
+	for i, part := range parts {
+		f := cronFields[i]
+		for _, item := range strings.Split(part, ",") {
+			if err := f.parseItem(item); err != nil {
+				return fmt.Errorf("%s field %q: %s", f.name, part, err.Error())
+			}
+		}
+	}
+	return nil
+}
+
+func (f cronField) parseItem(item string) error {
+	rangePart, step, hasStep := strings.Cut(item, "/")
+	if hasStep {
+		n, err := strconv.Atoi(step)
+		if err != nil || n < 1 {
+			return fmt.Errorf("step %q must be a positive number", step)
+		}
+	}
+	if rangePart == "*" {
+		return nil
+	}
+	lo, hi, isRange := strings.Cut(rangePart, "-")
+	start, err := f.value(lo)
+	if err != nil {
+		return err
+	}
+	if !isRange {
+		return nil
+	}
+	end, err := f.value(hi)
+	if err != nil {
+		return err
+	}
+	if start > end {
+		return fmt.Errorf("range %q is reversed", rangePart)
+	}
+	return nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("%q is not between %d and %d", s, f.min, f.max)
+	}
+	return n, nil
+}
+
+// TimeZone returns a validator.String that ensures the value is an IANA time
+// zone name such as "America/Sao_Paulo" or "UTC".
+func TimeZone() validator.String { return timeZoneValidator{} }
+
+type timeZoneValidator struct{}
+
+func (v timeZoneValidator) Description(_ context.Context) string {
+	return "value must be an IANA time zone name, e.g. \"America/Sao_Paulo\""
+}
+
+func (v timeZoneValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timeZoneValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+	// LoadLocation treats "" and "Local" as the host's zone, which means
+	// nothing to the API.
+	if _, err := time.LoadLocation(value); err != nil || value == "" || value == "Local" {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid time zone",
+			"expected an IANA time zone name like America/Sao_Paulo, got: "+value)
+	}
+}
+
+// Date returns a validator.String that ensures the value is a calendar date
+// ("2025-01-31") or an RFC 3339 timestamp ("2025-01-31T09:00:00Z").
+func Date() validator.String { return dateValidator{} }
+
+type dateValidator struct{}
+
+func (v dateValidator) Description(_ context.Context) string {
+	return "value must be a date (YYYY-MM-DD) or an RFC 3339 timestamp"
+}
+
+func (v dateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v dateValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	value := req.ConfigValue.ValueString()
+	if _, err := time.Parse(time.DateOnly, value); err == nil {
+		return
+	}
+	if _, err := time.Parse(time.RFC3339, value); err == nil {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(req.Path, "Invalid date",
+		"expected a date like 2025-01-31 or a timestamp like 2025-01-31T09:00:00Z, got: "+value)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type scheduleCase struct {
+	name    string
+	value   types.String
+	wantErr bool
+}
+
+func runStringCases(t *testing.T, v validator.String, cases []scheduleCase) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("value"), ConfigValue: tc.value}
+			resp := &validator.StringResponse{}
+			v.ValidateString(t.Context(), req, resp)
+			if got := resp.Diagnostics.HasError(); got != tc.wantErr {
+				t.Fatalf("want err=%v, got err=%v (diagnostics: %v)", tc.wantErr, got, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestCron(t *testing.T) {
+	runStringCases(t, Cron(), []scheduleCase{
+		{"null is allowed", types.StringNull(), false},
+		{"unknown is allowed", types.StringUnknown(), false},
+		{"every minute", types.StringValue("* * * * *"), false},
+		{"weekday mornings", types.StringValue("0 9 * * MON-FRI"), false},
+		{"steps and lists", types.StringValue("*/15 0-12/2 1,15 jan,jul 0"), false},
+		{"sunday as 7", types.StringValue("0 0 * * 7"), false},
+		{"too few fields", types.StringValue("0 9 * *"), true},
+		{"seconds field", types.StringValue("0 0 9 * * MON"), true},
+		{"minute out of range", types.StringValue("60 * * * *"), true},
+		{"day of month zero", types.StringValue("0 0 0 * *"), true},
+		{"reversed range", types.StringValue("0 9-5 * * *"), true},
+		{"zero step", types.StringValue("*/0 * * * *"), true},
+		{"unknown name", types.StringValue("0 9 * * FUN"), true},
+		{"empty", types.StringValue(""), true},
+	})
+}
+
+func TestTimeZone(t *testing.T) {
+	runStringCases(t, TimeZone(), []scheduleCase{
+		{"null is allowed", types.StringNull(), false},
+		{"utc", types.StringValue("UTC"), false},
+		{"region", types.StringValue("America/Sao_Paulo"), false},
+		{"local", types.StringValue("Local"), true},
+		{"empty", types.StringValue(""), true},
+		{"offset", types.StringValue("+03:00"), true},
+		{"unknown zone", types.StringValue("Mars/Olympus"), true},
+	})
+}
+
+func TestDate(t *testing.T) {
+	runStringCases(t, Date(), []scheduleCase{
+		{"null is allowed", types.StringNull(), false},
+		{"date", types.StringValue("2025-01-31"), false},
+		{"timestamp", types.StringValue("2025-01-31T09:00:00-03:00"), false},
+		{"invalid day", types.StringValue("2025-02-30"), true},
+		{"us format", types.StringValue("01/31/2025"), true},
+		{"timestamp without zone", types.StringValue("2025-01-31T09:00:00"), true},
+	})
+}
@@ -5,6 +5,7 @@ package validators
 
 import (
 	"context"
+	"errors"
 	"net/url"
 
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -36,12 +37,16 @@ func (v urlValidator) ValidateString(ctx context.Context, req validator.StringRe
 		return
 	}
 	value := req.ConfigValue.ValueString()
+	if _, err := parseHTTPURL(value); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid URL", err.Error())
+	}
+}
+
+// parseHTTPURL parses value as an absolute http or https URL with a host.
+func parseHTTPURL(value string) (*url.URL, error) {
 	u, err := url.Parse(value)
 	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
-		resp.Diagnostics.AddAttributeError(
-			req.Path,
-			"Invalid URL",
-			"expected an http or https URL like https://example.com/hook, got: "+value,
-		)
+		return nil, errors.New("expected an http or https URL like https://example.com/hook, got: " + value)
 	}
+	return u, nil
 }
@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// URLPolicy restricts the URLs webhooks may send data to. The zero value
+// allows any URL URL() accepts.
+type URLPolicy struct {
+	// AllowedHosts are host patterns: an exact host ("hooks.example.com") or a
+	// wildcard matching any subdomain ("*.example.com"). Empty allows any host.
+	AllowedHosts []string
+	// RequireHTTPS rejects plain http URLs.
+	RequireHTTPS bool
+	// DenyPrivateIPs rejects hosts that are loopback, private, carrier-grade
+	// NAT, link-local or unspecified IP addresses, and localhost. Numeric hosts
+	// that are not dotted-decimal IPv4, such as 2130706433, 127.1 or 0x7f.0.0.1,
+	// are rejected too, as HTTP clients read them as IPv4 addresses. Host names
+	// are not resolved.
+	DenyPrivateIPs bool
+}
+
+// ValidateHostPattern returns why pattern is not a valid AllowedHosts entry,
+// or nil.
+func ValidateHostPattern(pattern string) error {
+	host := strings.TrimPrefix(pattern, "*.")
+	if host == "" || strings.ContainsAny(host, "*/:@ ") || strings.HasPrefix(host, ".") || strings.HasSuffix(host, ".") {
+		return fmt.Errorf("expected a host like hooks.example.com or a wildcard like *.example.com, got %q", pattern)
+	}
+	return nil
+}
+
+// Check returns why value breaks the policy, or nil.
+func (p URLPolicy) Check(value string) error {
+	u, err := parseHTTPURL(value)
+	if err != nil {
+		return err
+	}
+	host := strings.ToLower(u.Hostname())
+	if p.RequireHTTPS && u.Scheme != "https" {
+		return fmt.Errorf("%s uses %s, but the provider's webhook_url_policy requires https", value, u.Scheme)
+	}
+	if p.DenyPrivateIPs && isNumericHost(host) && net.ParseIP(host) == nil {
+		return fmt.Errorf("%s uses the numeric host %s, which is not a dotted-decimal IP address; the provider's webhook_url_policy denies it because it may point to a private address", value, host)
+	}
+	if p.DenyPrivateIPs && isPrivateHost(host) {
+		return fmt.Errorf("%s points to the private address %s, which the provider's webhook_url_policy denies", value, host)
+	}
+	if len(p.AllowedHosts) > 0 && !hostAllowed(host, p.AllowedHosts) {
+		return fmt.Errorf("host %s is not in the provider's webhook_url_policy allowed_hosts (%s)",
+			host, strings.Join(p.AllowedHosts, ", "))
+	}
+	return nil
+}
+
+func hostAllowed(host string, patterns []string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if suffix, wildcard := strings.CutPrefix(pattern, "*"); wildcard {
+			if strings.HasSuffix(host, suffix) && len(host) > len(suffix) {
+				return true
+			}
+		} else if host == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// carrierGradeNAT is the shared address space of RFC 6598, which net.IP's
+// IsPrivate does not cover.
+var carrierGradeNAT = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+func isPrivateHost(host string) bool {
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && (ip.IsLoopback() || ip.IsPrivate() || carrierGradeNAT.Contains(ip) ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified())
+}
+
+// isNumericHost reports whether host's last label is a decimal, octal or hex
+// number, which makes URL parsers treat the whole host as an IPv4 address.
+func isNumericHost(host string) bool {
+	labels := strings.Split(strings.TrimSuffix(host, "."), ".")
+	last := labels[len(labels)-1]
+	if hex, ok := strings.CutPrefix(last, "0x"); ok {
+		return strings.Trim(hex, "0123456789abcdef") == ""
+	}
+	return last != "" && strings.Trim(last, "0123456789") == ""
+}
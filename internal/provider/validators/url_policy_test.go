@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestURLPolicyCheck(t *testing.T) {
+	strict := URLPolicy{
+		AllowedHosts:   []string{"hooks.example.com", "*.internal.example.com"},
+		RequireHTTPS:   true,
+		DenyPrivateIPs: true,
+	}
+	cases := []struct {
+		name    string
+		policy  URLPolicy
+		value   string
+		wantErr string
+	}{
+		{"zero policy allows http", URLPolicy{}, "http://10.0.0.1/hook", ""},
+		{"exact host", strict, "https://hooks.example.com/pipefy", ""},
+		{"host is case insensitive", strict, "https://Hooks.Example.com/pipefy", ""},
+		{"wildcard subdomain", strict, "https://a.b.internal.example.com:8443/hook", ""},
+		{"wildcard does not match apex", strict, "https://internal.example.com/hook", "not in the provider's webhook_url_policy allowed_hosts"},
+		{"suffix without dot", strict, "https://evilhooks.example.com/hook", "not in the provider's webhook_url_policy allowed_hosts"},
+		{"http when https is required", strict, "http://hooks.example.com/hook", "requires https"},
+		{"private IPv4", URLPolicy{DenyPrivateIPs: true}, "https://192.168.1.10/hook", "private address 192.168.1.10"},
+		{"loopback IPv6", URLPolicy{DenyPrivateIPs: true}, "https://[::1]:8080/hook", "private address ::1"},
+		{"link-local metadata", URLPolicy{DenyPrivateIPs: true}, "https://169.254.169.254/latest", "private address"},
+		{"localhost", URLPolicy{DenyPrivateIPs: true}, "https://localhost/hook", "private address localhost"},
+		{"public IP", URLPolicy{DenyPrivateIPs: true}, "https://8.8.8.8/hook", ""},
+		{"carrier-grade NAT", URLPolicy{DenyPrivateIPs: true}, "https://100.64.12.1/hook", "private address 100.64.12.1"},
+		{"just outside carrier-grade NAT", URLPolicy{DenyPrivateIPs: true}, "https://100.128.0.1/hook", ""},
+		{"decimal integer host", URLPolicy{DenyPrivateIPs: true}, "https://2130706433/hook", "numeric host 2130706433"},
+		{"shortened IPv4", URLPolicy{DenyPrivateIPs: true}, "https://127.1/hook", "numeric host 127.1"},
+		{"hex IPv4", URLPolicy{DenyPrivateIPs: true}, "https://0x7f.0.0.1/hook", "numeric host 0x7f.0.0.1"},
+		{"octal IPv4", URLPolicy{DenyPrivateIPs: true}, "https://0177.0.0.1/hook", "numeric host 0177.0.0.1"},
+		{"numeric host allowed without the deny", URLPolicy{}, "https://2130706433/hook", ""},
+		{"host name with digits", URLPolicy{DenyPrivateIPs: true}, "https://hooks1.example.com/hook", ""},
+		{"not a URL", URLPolicy{}, "hooks.example.com", "expected an http or https URL"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy.Check(tc.value)
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+				t.Fatalf("error = %v, want one containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateHostPattern(t *testing.T) {
+	for _, pattern := range []string{"hooks.example.com", "*.example.com", "localhost"} {
+		if err := ValidateHostPattern(pattern); err != nil {
+			t.Errorf("ValidateHostPattern(%q) = %v, want nil", pattern, err)
+		}
+	}
+	for _, pattern := range []string{"", "*", "*.", "https://example.com", "example.com/hook", "a.*.example.com", ".example.com"} {
+		if err := ValidateHostPattern(pattern); err == nil {
+			t.Errorf("ValidateHostPattern(%q) = nil, want an error", pattern)
+		}
+	}
+}
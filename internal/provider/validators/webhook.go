@@ -0,0 +1,221 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// PipeWebhookActions are the pipe webhook events documented by Pipefy, used
+// when the API does not publish its own list.
+var PipeWebhookActions = []string{
+	"card.create",
+	"card.delete",
+	"card.done",
+	"card.email_received",
+	"card.expired",
+	"card.field_update",
+	"card.late",
+	"card.move",
+	"card.overdue",
+}
+
+// PipeWebhookFilters maps each pipe webhook filter key to the actions that
+// accept it. Every filter takes a list of IDs.
+var PipeWebhookFilters = map[string][]string{
+	"from_phase_id": {"card.move"},
+	"to_phase_id":   {"card.move"},
+	"field_id":      {"card.field_update"},
+}
+
+// WebhookActions returns a validator.List that ensures every element is one of
+// allowed, suggesting the closest supported action for a misspelled one (e.g.
+// "card.moved"). Null, unknown and unknown elements are allowed through.
+func WebhookActions(allowed []string) validator.List {
+	return webhookActionsValidator{allowed: allowed}
+}
+
+type webhookActionsValidator struct{ allowed []string }
+
+func (v webhookActionsValidator) Description(_ context.Context) string {
+	return "each value must be one of: " + strings.Join(v.allowed, ", ")
+}
+
+func (v webhookActionsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v webhookActionsValidator) ValidateList(_ context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for index, element := range req.ConfigValue.Elements() {
+		action, ok := element.(types.String)
+		if !ok || action.IsNull() || action.IsUnknown() || slices.Contains(v.allowed, action.ValueString()) {
+			continue
+		}
+		detail := fmt.Sprintf("%q is not a supported webhook action.", action.ValueString())
+		if suggestion := closest(action.ValueString(), v.allowed); suggestion != "" {
+			detail = fmt.Sprintf("%q is not a supported webhook action; did you mean %q?", action.ValueString(), suggestion)
+		}
+		resp.Diagnostics.AddAttributeError(
+			req.Path.AtListIndex(index),
+			"Invalid webhook action",
+			detail+" Supported actions: "+strings.Join(v.allowed, ", "),
+		)
+	}
+}
+
+// closest returns the candidate within edit distance 2 of value, or "" when
+// there is none.
+func closest(value string, candidates []string) string {
+	best, bestDistance := "", 3
+	for _, candidate := range candidates {
+		if distance := editDistance(value, candidate); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+// WebhookFilters returns a validator.String for a webhook's filters JSON. The
+// value must be an object whose keys appear in keys, map to a list of IDs, and
+// apply to at least one of the actions configured in the sibling "actions"
+// attribute. The action check is skipped while actions is unknown. Null and
+// unknown values are allowed through.
+func WebhookFilters(keys map[string][]string) validator.String {
+	return webhookFiltersValidator{keys: keys}
+}
+
+type webhookFiltersValidator struct{ keys map[string][]string }
+
+func (v webhookFiltersValidator) Description(_ context.Context) string {
+	return "value must be a JSON object of filter keys to ID lists, using keys that apply to the webhook's actions"
+}
+
+func (v webhookFiltersValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v webhookFiltersValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	raw := []byte(req.ConfigValue.ValueString())
+	if !json.Valid(raw) {
+		// Reported by the attribute's JSON type.
+		return
+	}
+	var filters map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&filters); err != nil || filters == nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid webhook filters",
+			"expected a JSON object like {\"from_phase_id\":[123]}")
+		return
+	}
+
+	var actions types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, req.Path.ParentPath().AtName("actions"), &actions)...)
+	configured, actionsKnown := knownStrings(actions)
+
+	names := make([]string, 0, len(filters))
+	for name := range filters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		accepted, ok := v.keys[name]
+		switch {
+		case !ok:
+			resp.Diagnostics.AddAttributeError(req.Path, "Invalid webhook filters",
+				fmt.Sprintf("%q is not a supported filter; supported filters: %s", name, strings.Join(sortedKeys(v.keys), ", ")))
+		case actionsKnown && !slices.ContainsFunc(accepted, func(action string) bool { return slices.Contains(configured, action) }):
+			resp.Diagnostics.AddAttributeError(req.Path, "Invalid webhook filters",
+				fmt.Sprintf("filter %q applies to %s, which actions does not include", name, strings.Join(accepted, " or ")))
+		case !isIDList(filters[name]):
+			resp.Diagnostics.AddAttributeError(req.Path, "Invalid webhook filters",
+				fmt.Sprintf("filter %q must be a non-empty list of IDs, e.g. [123]", name))
+		}
+	}
+}
+
+// knownStrings returns the values of a list of strings, and false when the
+// list or any element is unknown.
+func knownStrings(list types.List) ([]string, bool) {
+	if list.IsUnknown() {
+		return nil, false
+	}
+	var values []string
+	for _, element := range list.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsUnknown() {
+			return nil, false
+		}
+		values = append(values, value.ValueString())
+	}
+	return values, true
+}
+
+// isIDList reports whether value is a non-empty list of integer IDs, given as
+// numbers or as strings of digits.
+func isIDList(value any) bool {
+	ids, ok := value.([]any)
+	if !ok || len(ids) == 0 {
+		return false
+	}
+	for _, id := range ids {
+		var text string
+		switch id := id.(type) {
+		case json.Number:
+			text = id.String()
+		case string:
+			text = id
+		default:
+			return false
+		}
+		if text == "" || strings.Trim(text, "0123456789") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validators
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestWebhookActions(t *testing.T) {
+	cases := []struct {
+		name    string
+		value   types.List
+		allowed []string
+		wantErr string
+	}{
+		{"null is allowed", types.ListNull(types.StringType), PipeWebhookActions, ""},
+		{"unknown is allowed", types.ListUnknown(types.StringType), PipeWebhookActions, ""},
+		{"known actions", stringList("card.create", "card.move"), PipeWebhookActions, ""},
+		{"unknown element is allowed", types.ListValueMust(types.StringType, []attr.Value{types.StringUnknown()}), PipeWebhookActions, ""},
+		{"misspelled action", stringList("card.create", "card.moved"), PipeWebhookActions, `did you mean "card.move"?`},
+		{"unrelated action", stringList("pipe.create"), PipeWebhookActions, `"pipe.create" is not a supported webhook action. Supported`},
+		{"published list wins", stringList("card.comment_create"), []string{"card.comment_create"}, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := validator.ListRequest{Path: path.Root("actions"), ConfigValue: tc.value}
+			resp := &validator.ListResponse{}
+			WebhookActions(tc.allowed).ValidateList(t.Context(), req, resp)
+			assertDiagnostic(t, resp.Diagnostics.Errors(), tc.wantErr)
+		})
+	}
+}
+
+func TestWebhookFilters(t *testing.T) {
+	move := stringList("card.move")
+	cases := []struct {
+		name    string
+		value   types.String
+		actions types.List
+		wantErr string
+	}{
+		{"null is allowed", types.StringNull(), move, ""},
+		{"unknown is allowed", types.StringUnknown(), move, ""},
+		{"phase filter for move", types.StringValue(`{"from_phase_id":[268],"to_phase_id":["301"]}`), move, ""},
+		{"field filter for field update", types.StringValue(`{"field_id":[9007199254740993]}`), stringList("card.field_update"), ""},
+		{"unknown actions skip the action check", types.StringValue(`{"field_id":[1]}`), types.ListUnknown(types.StringType), ""},
+		{"not an object", types.StringValue(`[268]`), move, "expected a JSON object"},
+		{"unsupported key", types.StringValue(`{"phase_id":[268]}`), move, `"phase_id" is not a supported filter`},
+		{"key for another action", types.StringValue(`{"field_id":[1]}`), move, `filter "field_id" applies to card.field_update`},
+		{"empty list", types.StringValue(`{"from_phase_id":[]}`), move, "non-empty list of IDs"},
+		{"not a list", types.StringValue(`{"from_phase_id":268}`), move, "non-empty list of IDs"},
+		{"name instead of ID", types.StringValue(`{"from_phase_id":["Doing"]}`), move, "non-empty list of IDs"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("filters"),
+				ConfigValue: tc.value,
+				Config:      webhookConfig(t, tc.actions),
+			}
+			resp := &validator.StringResponse{}
+			WebhookFilters(PipeWebhookFilters).ValidateString(t.Context(), req, resp)
+			assertDiagnostic(t, resp.Diagnostics.Errors(), tc.wantErr)
+		})
+	}
+}
+
+func stringList(values ...string) types.List {
+	elements := make([]attr.Value, len(values))
+	for index, value := range values {
+		elements[index] = types.StringValue(value)
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
+
+// webhookConfig returns a config holding only the actions attribute, which is
+// what the filters validator reads.
+func webhookConfig(t *testing.T, actions types.List) tfsdk.Config {
+	t.Helper()
+	raw, err := actions.ToTerraformValue(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tfsdk.Config{
+		Schema: schema.Schema{Attributes: map[string]schema.Attribute{
+			"actions": schema.ListAttribute{ElementType: types.StringType, Optional: true},
+		}},
+		Raw: tftypes.NewValue(
+			tftypes.Object{AttributeTypes: map[string]tftypes.Type{"actions": tftypes.List{ElementType: tftypes.String}}},
+			map[string]tftypes.Value{"actions": raw},
+		),
+	}
+}
+
+// assertDiagnostic checks that there is no error when want is empty, and
+// otherwise exactly one whose detail contains want.
+func assertDiagnostic(t *testing.T, errors diag.Diagnostics, want string) {
+	t.Helper()
+	if want == "" {
+		if errors.HasError() {
+			t.Fatalf("unexpected errors: %v", errors)
+		}
+		return
+	}
+	if len(errors) != 1 || !strings.Contains(errors[0].Detail(), want) {
+		t.Fatalf("errors = %v, want one containing %q", errors, want)
+	}
+}
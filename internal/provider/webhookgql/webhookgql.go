@@ -2,15 +2,67 @@
 // SPDX-License-Identifier: MPL-2.0
 
 // Package webhookgql holds the GraphQL field selection and the typed webhook
-// payload shared across the pipefy_webhook resource's reads. headers is omitted
-// on purpose: it is sensitive and never refreshed from the API. filters is
-// included so it can be reconciled for drift detection.
+// payload shared by the pipefy_webhook and pipefy_organization_webhook reads,
+// which return the same fields. headers is omitted on purpose: it is sensitive and never refreshed
+// from the API. filters is included so it can be reconciled for drift
+// detection.
 package webhookgql
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"strings"
+)
 
 const Selection = "id name url actions filters"
 
+// ActionsQuery introspects the enum the API uses for pipe webhook actions. The
+// enum name is not part of Pipefy's published reference, so a wrong guess is
+// expected: __type is then null and Actions returns nil.
+const ActionsQuery = `query WebhookActions_tf{ __type(name:"WebhookAction"){ enumValues{ name } } }`
+
+// ActionsIntrospection is the payload of ActionsQuery.
+type ActionsIntrospection struct {
+	Type *struct {
+		EnumValues []struct {
+			Name string `json:"name"`
+		} `json:"enumValues"`
+	} `json:"__type"`
+}
+
+// Actions returns the webhook actions the enum publishes. Enum values cannot
+// contain dots, so each is matched to the known action it spells ignoring case
+// and separators (CARD_FIELD_UPDATE is card.field_update); other values are
+// split at the first underscore. It returns nil when the enum does not exist
+// or none of its values is a known action, since the type is then not the one
+// webhooks take.
+func (i ActionsIntrospection) Actions(known []string) []string {
+	if i.Type == nil {
+		return nil
+	}
+	byKey := make(map[string]string, len(known))
+	for _, action := range known {
+		byKey[actionKey(action)] = action
+	}
+	actions := make([]string, 0, len(i.Type.EnumValues))
+	matched := false
+	for _, value := range i.Type.EnumValues {
+		action, ok := byKey[actionKey(value.Name)]
+		if !ok {
+			action = strings.ToLower(strings.Replace(value.Name, "_", ".", 1))
+		}
+		matched = matched || ok
+		actions = append(actions, action)
+	}
+	if !matched {
+		return nil
+	}
+	return actions
+}
+
+func actionKey(name string) string {
+	return strings.ToLower(strings.NewReplacer(".", "", "_", "").Replace(name))
+}
+
 type Webhook struct {
 	Id      string          `json:"id"`
 	Name    string          `json:"name"`
@@ -4,6 +4,8 @@
 package webhookgql_test
 
 import (
+	"encoding/json"
+	"reflect"
 	"testing"
 
 	"github.com/pipefy/terraform-provider-pipefy/internal/provider/webhookgql"
@@ -37,3 +39,35 @@ func TestFindByID_EmptySlice(t *testing.T) {
 		t.Error("FindByID(nil, ...) returned ok=true, want false")
 	}
 }
+
+func TestActionsIntrospection(t *testing.T) {
+	known := []string{"card.create", "card.email_received", "card.field_update", "card.move"}
+	cases := map[string]struct {
+		payload string
+		want    []string
+	}{
+		"enum values mapped to known actions": {
+			payload: `{"__type":{"enumValues":[{"name":"CARD_MOVE"},{"name":"CARD_FIELD_UPDATE"},{"name":"CARD_EMAIL_RECEIVED"}]}}`,
+			want:    []string{"card.move", "card.field_update", "card.email_received"},
+		},
+		"new value split at first underscore": {
+			payload: `{"__type":{"enumValues":[{"name":"CARD_CREATE"},{"name":"CARD_SLA_BREACH"}]}}`,
+			want:    []string{"card.create", "card.sla_breach"},
+		},
+		"lowercase values": {
+			payload: `{"__type":{"enumValues":[{"name":"card_move"}]}}`,
+			want:    []string{"card.move"},
+		},
+		"no enum":        {payload: `{"__type":null}`},
+		"unrelated enum": {payload: `{"__type":{"enumValues":[{"name":"ACTIVE"},{"name":"INACTIVE"}]}}`},
+	}
+	for name, c := range cases {
+		var published webhookgql.ActionsIntrospection
+		if err := json.Unmarshal([]byte(c.payload), &published); err != nil {
+			t.Fatal(err)
+		}
+		if got := published.Actions(known); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: Actions() = %v, want %v", name, got, c.want)
+		}
+	}
+}